@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Check is a single go-lint analysis.
+type Check struct {
+	Name string
+	Doc  string
+	// Pack groups checks that can be disabled together. Checks in the
+	// "tests" pack only run on _test.go files, and checks in the "security"
+	// pack never do.
+	Pack string
+	// Severity marks findings that the hook treats specially: "security"
+	// findings block unless the project opts out, and "warning" findings come
+	// from heuristics and never block. Empty findings block like vet issues.
+	Severity string
+	Run      func(*Pass)
+}
+
+// checks lists every available check in the order they run.
+var checks = []*Check{
+	structTagCheck,
+	embedCheck,
+	ctxPropagateCheck,
+	ctxBackgroundCheck,
+	goCancelCheck,
+	timeAfterCheck,
+	testHelperCheck,
+	testGoroutineCheck,
+	testParallelCheck,
+	testLoopVarCheck,
+	testTempDirCheck,
+	slogArgsCheck,
+	slogKeysCheck,
+	legacyLogCheck,
+	sqlConcatCheck,
+	weakCryptoCheck,
+	tlsInsecureCheck,
+	pathTraversalCheck,
+	execArgsCheck,
+	hardcodedSecretCheck,
+}
+
+func findCheck(name string) *Check {
+	for _, c := range checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// appliesTo reports whether the check runs on the file at path.
+func (c *Check) appliesTo(path string) bool {
+	switch c.Pack {
+	case "tests":
+		return strings.HasSuffix(path, "_test.go")
+	case "security":
+		return !strings.HasSuffix(path, "_test.go")
+	}
+	return true
+}
+
+// Finding is a problem reported by a check.
+type Finding struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+	Check   string
+	// Severity is the check's severity, printed before the message.
+	Severity string
+}
+
+func (f Finding) String() string {
+	if f.Severity != "" {
+		return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", f.Path, f.Line, f.Column, f.Severity, f.Message, f.Check)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s (%s)", f.Path, f.Line, f.Column, f.Message, f.Check)
+}
+
+// Pass holds the edited file and the rest of its package for checks to
+// inspect. Only findings inside File are reported.
+type Pass struct {
+	Fset   *token.FileSet
+	File   *ast.File
+	Path   string
+	Dir    string
+	Files  []*ast.File
+	Config *Config
+
+	check    *Check
+	findings []Finding
+}
+
+// Reportf records a finding at pos in the edited file.
+func (p *Pass) Reportf(pos token.Pos, format string, args ...any) {
+	position := p.Fset.Position(pos)
+	p.findings = append(p.findings, Finding{
+		Path:     p.Path,
+		Line:     position.Line,
+		Column:   position.Column,
+		Message:  fmt.Sprintf(format, args...),
+		Check:    p.check.Name,
+		Severity: p.check.Severity,
+	})
+}
+
+// loadPass parses the edited file and the other files of its package.
+// Test files are included only when the edited file is one, and an external
+// _test package only sees its own files.
+func loadPass(path string, config *Config) (*Pass, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	pass := &Pass{
+		Fset:   fset,
+		File:   file,
+		Path:   path,
+		Dir:    dir,
+		Files:  []*ast.File{file},
+		Config: config,
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return pass, nil
+	}
+
+	isTest := strings.HasSuffix(path, "_test.go")
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || name == filepath.Base(path) {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.go") && !isTest {
+			continue
+		}
+
+		other, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil || other.Name.Name != file.Name.Name {
+			continue
+		}
+		pass.Files = append(pass.Files, other)
+	}
+
+	return pass, nil
+}
+
+// lookupType finds a type declared at package level in any file of the
+// package.
+func (p *Pass) lookupType(name string) *ast.TypeSpec {
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.Name == name {
+					return ts
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// importName returns the name an import path is bound to in the edited file,
+// or "" if the file does not import it.
+func (p *Pass) importName(path string) string {
+	for _, spec := range p.File.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || importPath != path {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return importPath[strings.LastIndex(importPath, "/")+1:]
+	}
+	return ""
+}
+
+// isPkgCall reports whether call is pkgName.fn(...) for one of the names.
+func isPkgCall(call *ast.CallExpr, pkgName string, names ...string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || pkgName == "" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || ident.Name != pkgName {
+		return false
+	}
+	for _, name := range names {
+		if sel.Sel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// inspectStack walks root like ast.Inspect, also passing the ancestors of
+// each node (outermost first).
+func inspectStack(root ast.Node, f func(n ast.Node, stack []ast.Node) bool) {
+	var stack []ast.Node
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if !f(n, stack) {
+			return false
+		}
+		stack = append(stack, n)
+		return true
+	})
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The -callgraph mode narrows test impact analysis to the TestXxx functions
+// that can reach a changed function. The graph is built from syntax alone:
+// package-level functions are resolved by name within a package and through
+// import names across packages, and a method call x.M() links to every method
+// named M in the module. That over-approximates calls through interfaces and
+// function values, so a test is only dropped when no path can exist.
+
+// listedPackage is one object of the go list -json stream read on stdin.
+type listedPackage struct {
+	ImportPath   string
+	Dir          string
+	GoFiles      []string
+	CgoFiles     []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+}
+
+// funcNode is a function, method or package-level variable in the module.
+type funcNode struct {
+	pkg     string // import path of the package under test (no _test suffix)
+	display string // "pkg.Func" or "pkg.Type.Method"
+	file    string // path relative to the root
+	start   int
+	end     int
+	isTest  bool
+	method  bool
+	name    string
+	callees map[*funcNode]bool
+}
+
+// callUnit is a package as the compiler sees it: the package with its
+// internal test files, or the external _test package.
+type callUnit struct {
+	path  string
+	funcs map[string]*funcNode
+	files []*callFile
+}
+
+type callFile struct {
+	ast     *ast.File
+	rel     string
+	imports map[string]string // import name -> import path
+}
+
+var testFuncPattern = regexp.MustCompile(`^Test([^a-z]|$)`)
+
+// runCallGraph reads the go list -json stream from stdin and the changed line
+// ranges ("file<TAB>start<TAB>end", relative to root) from changesPath, and
+// prints "test<TAB>pkg<TAB>TestName<TAB>chain" for every test that reaches a
+// changed function, and "outside<TAB>file:line" for changes it cannot place
+// in a function.
+func runCallGraph(root, changesPath string, stdin io.Reader, stdout io.Writer) error {
+	packages, err := decodePackages(stdin)
+	if err != nil {
+		return err
+	}
+	changes, err := readChanges(changesPath)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	units := map[string]*callUnit{}
+	methods := map[string][]*funcNode{}
+	byFile := map[string][]*funcNode{}
+	parsed := map[string]*ast.File{}
+
+	addFile := func(unitPath, pkg, dir, name string, isTestFile bool) {
+		path := filepath.Join(dir, name)
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+
+		unit := units[unitPath]
+		if unit == nil {
+			unit = &callUnit{path: unitPath, funcs: map[string]*funcNode{}}
+			units[unitPath] = unit
+		}
+		cf := &callFile{ast: file, rel: rel, imports: fileImports(file)}
+		unit.files = append(unit.files, cf)
+		parsed[rel] = file
+
+		short := pkg[strings.LastIndex(pkg, "/")+1:]
+		for _, decl := range file.Decls {
+			// Package-level variables are nodes too, so functions stored in
+			// them (var handler = serve) keep their callers
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for _, ident := range vs.Names {
+						node := &funcNode{
+							pkg:     pkg,
+							display: short + "." + ident.Name,
+							file:    rel,
+							start:   fset.Position(vs.Pos()).Line,
+							end:     fset.Position(vs.End()).Line,
+							name:    ident.Name,
+							callees: map[*funcNode]bool{},
+						}
+						unit.funcs[ident.Name] = node
+						byFile[rel] = append(byFile[rel], node)
+					}
+				}
+				continue
+			}
+
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			node := &funcNode{
+				pkg:     pkg,
+				file:    rel,
+				start:   fset.Position(fn.Pos()).Line,
+				end:     fset.Position(fn.End()).Line,
+				name:    fn.Name.Name,
+				callees: map[*funcNode]bool{},
+			}
+			if fn.Recv != nil && len(fn.Recv.List) > 0 {
+				recv := receiverName(fn.Recv.List[0].Type)
+				node.display = short + "." + recv + "." + fn.Name.Name
+				node.method = true
+				methods[fn.Name.Name] = append(methods[fn.Name.Name], node)
+			} else {
+				node.display = short + "." + fn.Name.Name
+				node.isTest = isTestFile && testFuncPattern.MatchString(fn.Name.Name)
+				unit.funcs[fn.Name.Name] = node
+			}
+			byFile[rel] = append(byFile[rel], node)
+		}
+	}
+
+	for _, p := range packages {
+		for _, name := range append(append(append([]string{}, p.GoFiles...), p.CgoFiles...), p.TestGoFiles...) {
+			addFile(p.ImportPath, p.ImportPath, p.Dir, name, strings.HasSuffix(name, "_test.go"))
+		}
+		for _, name := range p.XTestGoFiles {
+			addFile(p.ImportPath+"_test", p.ImportPath, p.Dir, name, true)
+		}
+	}
+
+	// Link every declaration to the functions it references
+	for _, unit := range units {
+		for _, cf := range unit.files {
+			for _, decl := range cf.ast.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					if decl.Body != nil {
+						caller := findNode(byFile[cf.rel], fset.Position(decl.Pos()).Line, decl.Name.Name)
+						linkReferences(caller, decl.Body, unit, cf, units, methods)
+					}
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						if vs, ok := spec.(*ast.ValueSpec); ok && decl.Tok == token.VAR {
+							for _, ident := range vs.Names {
+								caller := findNode(byFile[cf.rel], fset.Position(vs.Pos()).Line, ident.Name)
+								for _, value := range vs.Values {
+									linkReferences(caller, value, unit, cf, units, methods)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	changed, outside := changedFuncs(changes, byFile, parsed, fset, root)
+	for _, position := range outside {
+		fmt.Fprintf(stdout, "outside\t%s\n", position)
+	}
+
+	// Breadth-first from the changed functions along reversed edges, keeping
+	// the next hop toward a changed function for every caller reached
+	callers := map[*funcNode][]*funcNode{}
+	for _, nodes := range byFile {
+		for _, caller := range nodes {
+			for callee := range caller.callees {
+				callers[callee] = append(callers[callee], caller)
+			}
+		}
+	}
+	next := map[*funcNode]*funcNode{}
+	queue := changed
+	for _, node := range changed {
+		next[node] = nil
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, caller := range callers[node] {
+			if _, seen := next[caller]; !seen {
+				next[caller] = node
+				queue = append(queue, caller)
+			}
+		}
+	}
+
+	var lines []string
+	for node := range next {
+		if !node.isTest {
+			continue
+		}
+		chain := []string{node.name}
+		for hop := next[node]; hop != nil; hop = next[hop] {
+			chain = append(chain, hop.display)
+		}
+		lines = append(lines, fmt.Sprintf("test\t%s\t%s\t%s", node.pkg, node.name, strings.Join(chain, " -> ")))
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Fprintln(stdout, line)
+	}
+	return nil
+}
+
+func decodePackages(r io.Reader) ([]listedPackage, error) {
+	var packages []listedPackage
+	decoder := json.NewDecoder(r)
+	for {
+		var p listedPackage
+		if err := decoder.Decode(&p); err == io.EOF {
+			return packages, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading go list output: %v", err)
+		}
+		packages = append(packages, p)
+	}
+}
+
+// lineRange is a changed range of lines in a file; end < start marks a pure
+// deletion after start.
+type lineRange struct {
+	file       string
+	start, end int
+}
+
+func readChanges(path string) ([]lineRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var changes []lineRange
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		start, err1 := strconv.Atoi(fields[1])
+		end, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		changes = append(changes, lineRange{file: fields[0], start: start, end: end})
+	}
+	return changes, scanner.Err()
+}
+
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return "?"
+}
+
+func findNode(nodes []*funcNode, line int, name string) *funcNode {
+	for _, node := range nodes {
+		if node.start == line && node.name == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// linkReferences adds an edge from caller to every function or variable
+// root names: package-level declarations of its own unit, pkg.Func through
+// the file's imports (including the package under test from an external
+// test), and all methods sharing a selected name.
+func linkReferences(caller *funcNode, root ast.Node, unit *callUnit, cf *callFile, units map[string]*callUnit, methods map[string][]*funcNode) {
+	if caller == nil {
+		return
+	}
+	var visit func(ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok {
+				if path, ok := cf.imports[ident.Name]; ok {
+					if target := units[path]; target != nil {
+						if callee := target.funcs[n.Sel.Name]; callee != nil {
+							caller.callees[callee] = true
+						}
+					}
+					return false
+				}
+			}
+			for _, callee := range methods[n.Sel.Name] {
+				caller.callees[callee] = true
+			}
+			// Sel is a field or method name, not a package-level reference
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.Ident:
+			if callee := unit.funcs[n.Name]; callee != nil {
+				caller.callees[callee] = true
+			}
+		}
+		return true
+	}
+	ast.Inspect(root, visit)
+}
+
+// changedFuncs maps changed line ranges to the declarations they touch.
+// Lines in imports, comments or blank lines are ignored; anything else
+// outside a function (types, variables, deleted files) is returned as a
+// position so the caller can fall back to whole packages.
+func changedFuncs(changes []lineRange, byFile map[string][]*funcNode, parsed map[string]*ast.File, fset *token.FileSet, root string) ([]*funcNode, []string) {
+	var changed []*funcNode
+	var outside []string
+	seen := map[*funcNode]bool{}
+	reported := map[string]bool{}
+
+	for _, change := range changes {
+		file := parsed[change.file]
+		if file == nil {
+			if !reported[change.file] {
+				reported[change.file] = true
+				outside = append(outside, change.file)
+			}
+			continue
+		}
+
+		end := change.end
+		if end < change.start {
+			end = change.start
+		}
+		ignored := ignoredLines(file, fset, filepath.Join(root, change.file))
+		for line := change.start; line <= end; line++ {
+			var hit *funcNode
+			for _, node := range byFile[change.file] {
+				if line >= node.start && line <= node.end {
+					hit = node
+					break
+				}
+			}
+			// init and TestMain run before every test of the package
+			if hit != nil && !hit.method && (hit.name == "init" || hit.name == "TestMain") {
+				hit = nil
+			}
+			switch {
+			case hit != nil:
+				if !seen[hit] {
+					seen[hit] = true
+					changed = append(changed, hit)
+				}
+			case !ignored[line]:
+				position := fmt.Sprintf("%s:%d", change.file, line)
+				if !reported[change.file] {
+					reported[change.file] = true
+					outside = append(outside, position)
+				}
+			}
+		}
+	}
+	return changed, outside
+}
+
+// ignoredLines marks the lines of a file that cannot change behaviour on
+// their own: the package clause, imports, comments and blank lines.
+func ignoredLines(file *ast.File, fset *token.FileSet, path string) map[int]bool {
+	ignored := map[int]bool{}
+	mark := func(from, to token.Pos) {
+		for line := fset.Position(from).Line; line <= fset.Position(to).Line; line++ {
+			ignored[line] = true
+		}
+	}
+
+	mark(file.Package, file.Name.End())
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			mark(gen.Pos(), gen.End())
+		}
+	}
+	for _, group := range file.Comments {
+		mark(group.Pos(), group.End())
+	}
+
+	if data, err := os.ReadFile(path); err == nil {
+		for i, line := range strings.Split(string(data), "\n") {
+			if strings.TrimSpace(line) == "" {
+				ignored[i+1] = true
+			}
+		}
+	}
+	return ignored
+}
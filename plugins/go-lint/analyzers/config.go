@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config is the "analyzers" section of .go-lint.json.
+type Config struct {
+	Disable      []string           `json:"disable"`
+	StructTag    StructTagConfig    `json:"structtag"`
+	CtxPropagate CtxPropagateConfig `json:"ctxpropagate"`
+	TestParallel TestParallelConfig `json:"testparallel"`
+	Slog         SlogConfig         `json:"slog"`
+	Security     SecurityConfig     `json:"security"`
+}
+
+// StructTagConfig configures the structtag check.
+type StructTagConfig struct {
+	// Tags adds or overrides tag grammars by tag key.
+	Tags map[string]TagGrammar `json:"tags"`
+}
+
+// CtxPropagateConfig configures the ctxpropagate check.
+type CtxPropagateConfig struct {
+	// Calls adds ctx-less calls and their replacements. Keys are
+	// "import/path.Func" for functions or ".Method" for methods.
+	Calls map[string]string `json:"calls"`
+}
+
+// TestParallelConfig configures the testparallel check.
+type TestParallelConfig struct {
+	// Require is "tests" to require t.Parallel() in every Test function,
+	// "subtests" to also require it in t.Run closures, or "" (the default)
+	// to not require it.
+	Require string `json:"require"`
+}
+
+// SlogConfig configures the slog pack.
+type SlogConfig struct {
+	// KeyPattern is a regular expression keys must match (default
+	// snake_case).
+	KeyPattern string `json:"key_pattern"`
+	// ForbiddenKeys lists keys that must not be logged, such as PII.
+	// Matching ignores case.
+	ForbiddenKeys []string `json:"forbidden_keys"`
+}
+
+// SecurityConfig configures the security pack. Its "block" setting is read by
+// the hook, which blocks on security findings unless it is false.
+type SecurityConfig struct {
+	// SecretPatterns adds regular expressions for project-specific
+	// credential formats to the secrets check.
+	SecretPatterns []string `json:"secret_patterns"`
+}
+
+// TagGrammar describes the shape of one struct tag key.
+type TagGrammar struct {
+	// Kind is "name" for `key:"name,opt,..."` tags or "validate" for
+	// go-playground/validator rule lists.
+	Kind string `json:"kind"`
+	// Options lists the options allowed after the name. Nil allows any.
+	Options []string `json:"options"`
+	// Pattern is a regular expression tag names must match.
+	Pattern string `json:"pattern"`
+	// Rules lists extra validator rules (custom validations).
+	Rules []string `json:"rules"`
+}
+
+// defaultTagGrammars covers the tag keys checked without configuration.
+var defaultTagGrammars = map[string]TagGrammar{
+	"json":         {Kind: "name", Options: []string{"omitempty", "omitzero", "string"}},
+	"yaml":         {Kind: "name", Options: []string{"omitempty", "flow", "inline"}},
+	"db":           {Kind: "name", Options: []string{}, Pattern: `^[a-z_][a-z0-9_]*$`},
+	"mapstructure": {Kind: "name", Options: []string{"omitempty", "omitzero", "squash", "remain"}},
+	"validate":     {Kind: "validate"},
+}
+
+// loadConfig reads the analyzers section of a .go-lint.json file. A missing
+// path or file yields the defaults.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{}
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Analyzers *Config `json:"analyzers"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if file.Analyzers != nil {
+		config = file.Analyzers
+	}
+	return config, nil
+}
+
+// disabled reports whether a check is disabled by its name or its pack.
+func (c *Config) disabled(check *Check) bool {
+	for _, name := range c.Disable {
+		if name == check.Name || (check.Pack != "" && name == check.Pack) {
+			return true
+		}
+	}
+	return false
+}
+
+// tagGrammars merges configured grammars over the defaults. Fields left
+// empty in the config keep their default values.
+func (c *Config) tagGrammars() map[string]TagGrammar {
+	grammars := make(map[string]TagGrammar, len(defaultTagGrammars))
+	for key, g := range defaultTagGrammars {
+		grammars[key] = g
+	}
+
+	for key, override := range c.StructTag.Tags {
+		g, ok := grammars[key]
+		if !ok {
+			g = TagGrammar{Kind: "name"}
+		}
+		if override.Kind != "" {
+			g.Kind = override.Kind
+		}
+		if override.Options != nil {
+			g.Options = override.Options
+		}
+		if override.Pattern != "" {
+			g.Pattern = override.Pattern
+		}
+		if override.Rules != nil {
+			g.Rules = override.Rules
+		}
+		grammars[key] = g
+	}
+	return grammars
+}
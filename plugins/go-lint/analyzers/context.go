@@ -0,0 +1,210 @@
+package main
+
+import (
+	"go/ast"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ctxPropagateCheck = &Check{
+	Name:     "ctxpropagate",
+	Doc:      "functions with a ctx that call ctx-less variants (http.Get, db.Query, ...)",
+	Severity: "warning",
+	Run:      runCtxPropagate,
+}
+
+var ctxBackgroundCheck = &Check{
+	Name:     "ctxbackground",
+	Doc:      "context.Background/TODO outside main packages and tests",
+	Severity: "warning",
+	Run:      runCtxBackground,
+}
+
+// defaultCtxCalls maps ctx-less calls to their ctx-aware replacements.
+// Function keys are "import/path.Func"; method keys are ".Method".
+var defaultCtxCalls = map[string]string{
+	"net/http.Get":        "http.NewRequestWithContext and Client.Do",
+	"net/http.Head":       "http.NewRequestWithContext and Client.Do",
+	"net/http.Post":       "http.NewRequestWithContext and Client.Do",
+	"net/http.PostForm":   "http.NewRequestWithContext and Client.Do",
+	"net/http.NewRequest": "http.NewRequestWithContext",
+	"net.Dial":            "(&net.Dialer{}).DialContext",
+	"net.DialTimeout":     "(&net.Dialer{Timeout: ...}).DialContext",
+	"os/exec.Command":     "exec.CommandContext",
+	"time.Sleep":          "a select on ctx.Done() and a timer",
+	".Query":              "QueryContext",
+	".QueryRow":           "QueryRowContext",
+	".Exec":               "ExecContext",
+	".Prepare":            "PrepareContext",
+	".Begin":              "BeginTx",
+	".Ping":               "PingContext",
+}
+
+// Receivers that look like database handles (db, s.db, userDB, tx, conn, stmt),
+// for the method entries above.
+var dbReceiverPattern = regexp.MustCompile(`(?i)(^|[a-z_.])(db|tx|conn|stmt)$`)
+
+// ctxParam returns the name of a context.Context parameter, or "".
+func ctxParam(fn *ast.FuncType, ctxPkg string) string {
+	if fn == nil || fn.Params == nil {
+		return ""
+	}
+	for _, field := range fn.Params.List {
+		sel, ok := field.Type.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Context" {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != ctxPkg {
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				return name.Name
+			}
+		}
+	}
+	return ""
+}
+
+// ctxInScope returns the innermost ctx parameter visible from a node,
+// looking through enclosing closures.
+func ctxInScope(stack []ast.Node, ctxPkg string) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var name string
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			name = ctxParam(fn.Type, ctxPkg)
+		case *ast.FuncLit:
+			name = ctxParam(fn.Type, ctxPkg)
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// enclosingFunc returns the innermost function declaration or literal.
+func enclosingFunc(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return stack[i]
+		}
+	}
+	return nil
+}
+
+func runCtxPropagate(pass *Pass) {
+	ctxPkg := pass.importName("context")
+	if ctxPkg == "" {
+		return
+	}
+
+	calls := make(map[string]string)
+	for key, replacement := range defaultCtxCalls {
+		calls[key] = replacement
+	}
+	for key, replacement := range pass.Config.CtxPropagate.Calls {
+		calls[key] = replacement
+	}
+
+	// Resolve "import/path.Func" keys to the names used in this file
+	funcs := make(map[string]string)
+	methods := make(map[string]string)
+	keys := make([]string, 0, len(calls))
+	for key := range calls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if method, ok := strings.CutPrefix(key, "."); ok {
+			methods[method] = calls[key]
+			continue
+		}
+		dot := strings.LastIndex(key, ".")
+		if dot < 0 {
+			continue
+		}
+		if name := pass.importName(key[:dot]); name != "" {
+			funcs[name+"."+key[dot+1:]] = calls[key]
+		}
+	}
+
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ctx := ctxInScope(stack, ctxPkg)
+		if ctx == "" {
+			return true
+		}
+
+		if isPkgCall(call, ctxPkg, "Background", "TODO") {
+			pass.Reportf(call.Pos(), "%s.%s() discards %s; pass %s (or %s.WithoutCancel(%s) for work that must outlive it)",
+				ctxPkg, sel.Sel.Name, ctx, ctx, ctxPkg, ctx)
+			return true
+		}
+
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			name := pkg.Name + "." + sel.Sel.Name
+			if replacement, ok := funcs[name]; ok {
+				pass.Reportf(call.Pos(), "%s ignores %s; use %s", name, ctx, replacement)
+				return true
+			}
+		}
+
+		if replacement, ok := methods[sel.Sel.Name]; ok {
+			receiver := exprName(sel.X)
+			if receiver != "" && dbReceiverPattern.MatchString(receiver) {
+				pass.Reportf(call.Pos(), "%s.%s ignores %s; use %s", receiver, sel.Sel.Name, ctx, replacement)
+			}
+		}
+		return true
+	})
+}
+
+func runCtxBackground(pass *Pass) {
+	ctxPkg := pass.importName("context")
+	if ctxPkg == "" || pass.File.Name.Name == "main" || strings.HasSuffix(pass.Path, "_test.go") {
+		return
+	}
+
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isPkgCall(call, ctxPkg, "Background", "TODO") {
+			return true
+		}
+		// Inside a function with a ctx, ctxpropagate reports it instead
+		if ctxInScope(stack, ctxPkg) != "" {
+			return true
+		}
+		if decl, ok := enclosingFunc(stack).(*ast.FuncDecl); ok && decl.Recv == nil && decl.Name.Name == "init" {
+			return true
+		}
+
+		fn := call.Fun.(*ast.SelectorExpr).Sel.Name
+		pass.Reportf(call.Pos(), "%s.%s() in library code cuts callers off from cancellation; accept a ctx parameter instead",
+			ctxPkg, fn)
+		return true
+	})
+}
+
+// exprName renders an identifier or selector chain like "s.db", or "".
+func exprName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		if x := exprName(e.X); x != "" {
+			return x + "." + e.Sel.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var embedCheck = &Check{
+	Name: "embed",
+	Doc:  "//go:embed patterns that match no files or are invalid",
+	Run:  runEmbed,
+}
+
+func runEmbed(pass *Pass) {
+	for _, group := range pass.File.Comments {
+		for _, comment := range group.List {
+			args, ok := strings.CutPrefix(comment.Text, "//go:embed")
+			if !ok || (args != "" && args[0] != ' ' && args[0] != '\t') {
+				continue
+			}
+
+			patterns, err := parseEmbedPatterns(args)
+			if err != nil {
+				pass.Reportf(comment.Pos(), "invalid //go:embed directive: %v", err)
+				continue
+			}
+			if len(patterns) == 0 {
+				pass.Reportf(comment.Pos(), "//go:embed directive has no patterns")
+				continue
+			}
+			for _, pattern := range patterns {
+				checkEmbedPattern(pass, comment.Pos(), pattern)
+			}
+		}
+	}
+}
+
+// parseEmbedPatterns splits directive arguments, honouring Go string quoting.
+func parseEmbedPatterns(args string) ([]string, error) {
+	var patterns []string
+	args = strings.TrimLeft(args, " \t")
+	for args != "" {
+		end := len(args)
+		switch args[0] {
+		case '`':
+			end = -1
+			if i := strings.IndexByte(args[1:], '`'); i >= 0 {
+				end = i + 2
+			}
+		case '"':
+			end = -1
+			for i := 1; i < len(args); i++ {
+				if args[i] == '\\' {
+					i++
+				} else if args[i] == '"' {
+					end = i + 1
+					break
+				}
+			}
+		default:
+			if i := strings.IndexAny(args, " \t"); i >= 0 {
+				end = i
+			}
+		}
+		if end <= 0 {
+			return nil, fmt.Errorf("unterminated quoted pattern %s", args)
+		}
+
+		pattern := args[:end]
+		if args[0] == '"' || args[0] == '`' {
+			unquoted, err := strconv.Unquote(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted pattern %s", pattern)
+			}
+			pattern = unquoted
+		}
+		patterns = append(patterns, pattern)
+		args = strings.TrimLeft(args[end:], " \t")
+	}
+	return patterns, nil
+}
+
+// checkEmbedPattern applies the go command's embed rules to one pattern.
+func checkEmbedPattern(pass *Pass, pos token.Pos, pattern string) {
+	glob, all := strings.CutPrefix(pattern, "all:")
+
+	if !validEmbedPattern(glob) {
+		pass.Reportf(pos, "//go:embed pattern %q: invalid pattern syntax", pattern)
+		return
+	}
+
+	matches, err := filepath.Glob(filepath.Join(pass.Dir, filepath.FromSlash(glob)))
+	if err != nil {
+		pass.Reportf(pos, "//go:embed pattern %q: invalid pattern syntax", pattern)
+		return
+	}
+	if len(matches) == 0 {
+		pass.Reportf(pos, "//go:embed pattern %q: no matching files found", pattern)
+		return
+	}
+
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.IsDir() {
+			return
+		}
+		if hasEmbeddableFiles(match, all) {
+			return
+		}
+	}
+
+	rel, _ := filepath.Rel(pass.Dir, matches[0])
+	pass.Reportf(pos, "//go:embed pattern %q: cannot embed directory %s: contains no embeddable files", pattern, filepath.ToSlash(rel))
+}
+
+// validEmbedPattern mirrors the go command: slash-separated, unrooted, and
+// free of "." and ".." elements and empty elements.
+func validEmbedPattern(pattern string) bool {
+	if pattern == "" || strings.HasPrefix(pattern, "/") || strings.HasSuffix(pattern, "/") {
+		return false
+	}
+	if strings.ContainsAny(pattern, `\:`) {
+		return false
+	}
+	for _, elem := range strings.Split(pattern, "/") {
+		if elem == "" || elem == "." || elem == ".." {
+			return false
+		}
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// hasEmbeddableFiles reports whether a directory tree holds a file the go
+// command would embed. Without the all: prefix, names starting with "." or
+// "_" are skipped, and nested modules are never embedded.
+func hasEmbeddableFiles(dir string, all bool) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !all && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			continue
+		}
+		if entry.IsDir() {
+			if _, err := os.Stat(filepath.Join(dir, name, "go.mod")); err == nil {
+				continue
+			}
+			if hasEmbeddableFiles(filepath.Join(dir, name), all) {
+				return true
+			}
+			continue
+		}
+		if entry.Type().IsRegular() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+module github.com/cheolwanpark/useful-claude-plugins/plugins/go-lint/analyzers
+
+go 1.22
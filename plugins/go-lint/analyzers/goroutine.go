@@ -0,0 +1,174 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+)
+
+var goCancelCheck = &Check{
+	Name:     "gocancel",
+	Doc:      "go func() closures that loop forever without a ctx or done-channel exit",
+	Severity: "warning",
+	Run:      runGoCancel,
+}
+
+var timeAfterCheck = &Check{
+	Name:     "timeafter",
+	Doc:      "time.After in a select inside a loop",
+	Severity: "warning",
+	Run:      runTimeAfter,
+}
+
+// Channels whose receive signals shutdown.
+var doneChannelPattern = regexp.MustCompile(`(?i)(^|\.)(ctx|done|quit|stop|stopch|donech|closing|shutdown|exit|cancel)[a-z]*$`)
+
+func runGoCancel(pass *Pass) {
+	timePkg := pass.importName("time")
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+
+		loop := endlessLoop(lit.Body, timePkg)
+		if loop != nil && !hasCancellation(lit.Body) {
+			pass.Reportf(stmt.Pos(), "goroutine loops forever with no ctx.Done() or done-channel exit; it leaks once its caller is gone")
+		}
+		return true
+	})
+}
+
+// endlessLoop finds a loop in a goroutine body (not in nested closures) that
+// only stops via return or break: "for {}" or a range over a ticker.
+func endlessLoop(body *ast.BlockStmt, timePkg string) ast.Node {
+	var found ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch loop := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt:
+			if loop.Cond == nil && !exitsLoop(loop.Body) {
+				found = loop
+			}
+		case *ast.RangeStmt:
+			if isTickerChannel(loop.X, timePkg) && !exitsLoop(loop.Body) {
+				found = loop
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isTickerChannel matches ticker.C and time.Tick(...), which never close.
+func isTickerChannel(expr ast.Expr, timePkg string) bool {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		return e.Sel.Name == "C"
+	case *ast.CallExpr:
+		return isPkgCall(e, timePkg, "Tick")
+	}
+	return false
+}
+
+// exitsLoop reports whether a loop body contains a return, a goto, or a
+// break that leaves the loop (labelled, or not nested in select/switch/for).
+func exitsLoop(body *ast.BlockStmt) bool {
+	exits := false
+	var visit func(n ast.Node, nested bool) bool
+	visit = func(n ast.Node, nested bool) bool {
+		ast.Inspect(n, func(child ast.Node) bool {
+			if exits || child == nil {
+				return false
+			}
+			switch s := child.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				exits = true
+			case *ast.BranchStmt:
+				if s.Tok == token.GOTO || (s.Tok == token.BREAK && (s.Label != nil || !nested)) {
+					exits = true
+				}
+			case *ast.SelectStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.ForStmt, *ast.RangeStmt:
+				if child != n {
+					visit(child, true)
+					return false
+				}
+			}
+			return true
+		})
+		return exits
+	}
+	return visit(body, false)
+}
+
+// hasCancellation looks for ctx.Done(), ctx.Err() or a receive from a
+// done-like channel anywhere in a goroutine body.
+func hasCancellation(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch e := n.(type) {
+		case *ast.CallExpr:
+			if sel, ok := e.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Done" || sel.Sel.Name == "Err") && len(e.Args) == 0 {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if e.Op == token.ARROW && doneChannelPattern.MatchString(exprName(e.X)) {
+				found = true
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func runTimeAfter(pass *Pass) {
+	timePkg := pass.importName("time")
+	if timePkg == "" {
+		return
+	}
+
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || !isPkgCall(call, timePkg, "After") {
+			return true
+		}
+
+		// The call must be a select case's channel, with a loop around the
+		// select in the same function
+		inSelectCase := false
+		for i := len(stack) - 1; i >= 0; i-- {
+			switch node := stack[i].(type) {
+			case *ast.CommClause:
+				inSelectCase = node.Comm != nil && containsNode(node.Comm, call)
+			case *ast.ForStmt, *ast.RangeStmt:
+				if inSelectCase {
+					pass.Reportf(call.Pos(), "%s.After in a select loop starts a new timer every iteration, so the timeout never fires while other cases are ready; create a %s.Timer outside the loop and Reset it",
+						timePkg, timePkg)
+				}
+				return true
+			case *ast.FuncLit, *ast.FuncDecl:
+				return true
+			}
+		}
+		return true
+	})
+}
+
+// containsNode reports whether target is inside root.
+func containsNode(root, target ast.Node) bool {
+	return root.Pos() <= target.Pos() && target.End() <= root.End()
+}
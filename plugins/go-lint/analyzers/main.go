@@ -0,0 +1,186 @@
+// Command go-lint-analyze runs go-lint's syntax-level checks on Go files and
+// prints findings as "path:line:col: message (check)", with the severity
+// before the message for checks that have one ("path:line:col: security:
+// message (check)").
+//
+// The hook builds this binary on first use (see scripts/go-lint-analyzers.sh)
+// and runs it after go vet. Checks only need the standard library and the
+// sources of the edited file's package, so they work offline and without a
+// module cache.
+//
+// With -callgraph it instead reads `go list -json` output on stdin and maps
+// changed line ranges to the tests that can reach them (see callgraph.go),
+// for /go-lint:test-impact. With -mutate it writes mutant copies of the given
+// files for /go-lint:mutate (see mutate.go). With -outline it reads the same
+// go list stream and prints an outline of the packages under -target for
+// /go-lint:outline (see outline.go).
+//
+// Usage:
+//
+//	go-lint-analyze [-config .go-lint.json] [-checks a,b] file.go...
+//	go-lint-analyze -list
+//	go list -json ./... | go-lint-analyze -callgraph -root dir -changes ranges.tsv
+//	go-lint-analyze -mutate -root dir [-changes ranges.tsv] -out dir file.go...
+//	go list -json ./... | go-lint-analyze -outline -root dir [-target rel] [-depth n] [-exported] [-match re] [-tests] [-docs=false]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to .go-lint.json")
+	checkNames := flag.String("checks", "", "comma-separated checks or packs to run (default: all enabled)")
+	list := flag.Bool("list", false, "list available checks and exit")
+	callGraph := flag.Bool("callgraph", false, "list tests reaching the changed lines instead of checking a file")
+	mutate := flag.Bool("mutate", false, "write mutants of the given files to -out instead of checking a file")
+	outline := flag.Bool("outline", false, "outline the packages under -target instead of checking a file")
+	root := flag.String("root", ".", "project root the -changes paths are relative to (with -callgraph, -mutate or -outline)")
+	changes := flag.String("changes", "", "file of changed \"path<TAB>start<TAB>end\" line ranges (with -callgraph or -mutate)")
+	outDir := flag.String("out", "", "directory for mutant files (with -mutate)")
+	target := flag.String("target", ".", "directory to outline, relative to -root (with -outline)")
+	depth := flag.Int("depth", -1, "package levels below -target to outline, -1 for all (with -outline)")
+	exported := flag.Bool("exported", false, "leave out unexported declarations (with -outline)")
+	match := flag.String("match", "", "only outline declarations whose name matches this regexp (with -outline)")
+	tests := flag.Bool("tests", false, "include declarations of _test.go files (with -outline)")
+	docs := flag.Bool("docs", true, "append the first sentence of doc comments (with -outline)")
+	flag.Parse()
+
+	if *callGraph {
+		if err := runCallGraph(*root, *changes, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+
+	if *outline {
+		opts := outlineOptions{target: *target, depth: *depth, exported: *exported, tests: *tests, docs: *docs}
+		if *match != "" {
+			re, err := regexp.Compile(*match)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "go-lint-analyze: -match: %v\n", err)
+				os.Exit(2)
+			}
+			opts.match = re
+		}
+		if err := runOutline(*root, opts, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+
+	if *mutate {
+		if *outDir == "" || flag.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "usage: go-lint-analyze -mutate -root dir [-changes ranges.tsv] -out dir file.go...")
+			os.Exit(2)
+		}
+		if err := runMutate(*root, *changes, *outDir, flag.Args(), os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+
+	if *list {
+		for _, c := range checks {
+			doc := c.Doc
+			if c.Pack != "" {
+				doc += " [" + c.Pack + "]"
+			}
+			fmt.Printf("%-14s %s\n", c.Name, doc)
+		}
+		return
+	}
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: go-lint-analyze [-config file] [-checks a,b] file.go...")
+		os.Exit(2)
+	}
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+		os.Exit(2)
+	}
+
+	selected, err := selectChecks(*checkNames, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Files that fail to parse are skipped so the rest are still checked
+	exitCode := 0
+	for _, path := range flag.Args() {
+		pass, err := loadPass(path, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "go-lint-analyze: %v\n", err)
+			exitCode = 2
+			continue
+		}
+
+		for _, c := range selected {
+			if !c.appliesTo(pass.Path) {
+				continue
+			}
+			pass.check = c
+			c.Run(pass)
+		}
+
+		findings := pass.findings
+		sort.SliceStable(findings, func(i, j int) bool {
+			if findings[i].Line != findings[j].Line {
+				return findings[i].Line < findings[j].Line
+			}
+			return findings[i].Column < findings[j].Column
+		})
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// selectChecks resolves the -checks flag, falling back to every check not
+// disabled in the config. A pack name in -checks selects the pack's checks
+// that the config does not disable.
+func selectChecks(names string, config *Config) ([]*Check, error) {
+	if names == "" {
+		var enabled []*Check
+		for _, c := range checks {
+			if !config.disabled(c) {
+				enabled = append(enabled, c)
+			}
+		}
+		return enabled, nil
+	}
+
+	var selected []*Check
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if c := findCheck(name); c != nil {
+			selected = append(selected, c)
+			continue
+		}
+		pack := false
+		for _, c := range checks {
+			if c.Pack == name {
+				pack = true
+				if !config.disabled(c) {
+					selected = append(selected, c)
+				}
+			}
+		}
+		if !pack {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// The -mutate mode generates mutants for /go-lint:mutate. Each mutant is a
+// copy of one source file with a single operator or expression replaced, so
+// the script can test it through go test -overlay without touching the
+// working tree. Edits are made on the source bytes at AST positions rather
+// than by printing the AST, which keeps comments and formatting intact.
+
+// mutant is one replacement of the bytes [offset, offset+length) of a file.
+type mutant struct {
+	file     string // path relative to the root
+	line     int
+	column   int
+	operator string
+	offset   int
+	length   int
+	with     string
+	from     string // source shown in the report
+	to       string
+}
+
+// Operator replacements per mutation kind.
+var (
+	boundaryMutations = map[token.Token]token.Token{
+		token.LSS: token.LEQ,
+		token.LEQ: token.LSS,
+		token.GTR: token.GEQ,
+		token.GEQ: token.GTR,
+	}
+	negationMutations = map[token.Token]token.Token{
+		token.EQL: token.NEQ,
+		token.NEQ: token.EQL,
+		token.LSS: token.GEQ,
+		token.LEQ: token.GTR,
+		token.GTR: token.LEQ,
+		token.GEQ: token.LSS,
+	}
+	arithmeticMutations = map[token.Token]token.Token{
+		token.ADD: token.SUB,
+		token.SUB: token.ADD,
+		token.MUL: token.QUO,
+		token.QUO: token.MUL,
+		token.REM: token.MUL,
+	}
+)
+
+// maxSnippet is the longest expression quoted in a mutant description.
+const maxSnippet = 60
+
+// runMutate writes a mutant copy of files for every mutation inside the
+// functions that overlap the changed line ranges in changesPath (every
+// function when it is empty) to outDir/<id>.go. It prints
+// "func<TAB>file:line<TAB>name" for each function in scope and
+// "mutant<TAB>id<TAB>file:line:col<TAB>operator<TAB>from<TAB>to" for each
+// mutant, with paths relative to root.
+func runMutate(root, changesPath, outDir string, files []string, stdout io.Writer) error {
+	var changes []lineRange
+	if changesPath != "" {
+		var err error
+		if changes, err = readChanges(changesPath); err != nil {
+			return err
+		}
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	id := 0
+	for _, path := range files {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		if ast.IsGenerated(file) {
+			continue
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			start := fset.Position(fn.Pos()).Line
+			end := fset.Position(fn.End()).Line
+			if changesPath != "" && !overlapsChange(changes, rel, start, end) {
+				continue
+			}
+			fmt.Fprintf(out, "func\t%s:%d\t%s\n", rel, start, funcDeclName(fn))
+
+			for _, m := range funcMutants(fset, src, fn) {
+				id++
+				m.file = rel
+				mutated := make([]byte, 0, len(src)+len(m.with))
+				mutated = append(mutated, src[:m.offset]...)
+				mutated = append(mutated, m.with...)
+				mutated = append(mutated, src[m.offset+m.length:]...)
+				if err := os.WriteFile(filepath.Join(outDir, fmt.Sprintf("%d.go", id)), mutated, 0o644); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "mutant\t%d\t%s:%d:%d\t%s\t%s\t%s\n",
+					id, m.file, m.line, m.column, m.operator, m.from, m.to)
+			}
+		}
+	}
+	return nil
+}
+
+// funcMutants lists the mutations of a function body in source order.
+func funcMutants(fset *token.FileSet, src []byte, fn *ast.FuncDecl) []mutant {
+	var mutants []mutant
+	add := func(pos token.Pos, operator string, length int, with string, node ast.Node, replaced token.Pos) {
+		p := fset.Position(pos)
+		m := mutant{
+			line:     p.Line,
+			column:   p.Column,
+			operator: operator,
+			offset:   p.Offset,
+			length:   length,
+			with:     with,
+		}
+		m.from, m.to = describeMutation(fset, src, node, replaced, length, with)
+		mutants = append(mutants, m)
+	}
+
+	// Returns only drop an error when the last result is declared as error
+	// and every result is spelled out
+	errorResult := false
+	resultCount := 0
+	if results := fn.Type.Results; results != nil && len(results.List) > 0 {
+		last := results.List[len(results.List)-1]
+		if ident, ok := last.Type.(*ast.Ident); ok && ident.Name == "error" {
+			errorResult = true
+		}
+		for _, field := range results.List {
+			resultCount += max(1, len(field.Names))
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Returns inside a closure belong to the closure's signature
+			return false
+		case *ast.BinaryExpr:
+			op := n.Op.String()
+			if to, ok := boundaryMutations[n.Op]; ok {
+				add(n.OpPos, "boundary", len(op), to.String(), n, n.OpPos)
+			}
+			if to, ok := negationMutations[n.Op]; ok {
+				add(n.OpPos, "negation", len(op), to.String(), n, n.OpPos)
+			}
+			if to, ok := arithmeticMutations[n.Op]; ok && !isStringLiteral(n.X) && !isStringLiteral(n.Y) {
+				add(n.OpPos, "arithmetic", len(op), to.String(), n, n.OpPos)
+			}
+		case *ast.ReturnStmt:
+			if !errorResult || len(n.Results) != resultCount {
+				break
+			}
+			last := n.Results[len(n.Results)-1]
+			if ident, ok := last.(*ast.Ident); ok && ident.Name == "nil" {
+				break
+			}
+			start := fset.Position(last.Pos()).Offset
+			end := fset.Position(last.End()).Offset
+			add(last.Pos(), "error-return", end-start, "nil", n, last.Pos())
+		}
+		return true
+	})
+
+	// Closures are visited on their own so their returns use their own results
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		mutants = append(mutants, funcMutants(fset, src, &ast.FuncDecl{Type: lit.Type, Body: lit.Body})...)
+		return false
+	})
+
+	return mutants
+}
+
+// describeMutation quotes the mutated node before and after the replacement,
+// or just the replaced text when the node is long or spans lines.
+func describeMutation(fset *token.FileSet, src []byte, node ast.Node, replaced token.Pos, length int, with string) (string, string) {
+	start := fset.Position(node.Pos()).Offset
+	end := fset.Position(node.End()).Offset
+	at := fset.Position(replaced).Offset - start
+	snippet := src[start:end]
+
+	if len(snippet) > maxSnippet || bytes.ContainsAny(snippet, "\n\t") {
+		return string(src[start+at : start+at+length]), with
+	}
+	return string(snippet), string(snippet[:at]) + with + string(snippet[at+length:])
+}
+
+func isStringLiteral(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+func funcDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		recv = t.X
+	case *ast.IndexListExpr:
+		recv = t.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// overlapsChange reports whether a changed range of file touches the lines
+// start..end; a pure deletion counts when it falls inside.
+func overlapsChange(changes []lineRange, file string, start, end int) bool {
+	for _, c := range changes {
+		if c.file != file {
+			continue
+		}
+		last := max(c.start, c.end)
+		if c.start <= end && last >= start {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,680 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The outline mode prints a compact, Go-like outline of the packages under a
+// directory for /go-lint:outline, so the API of a package can be read without
+// opening its files: constants, variables, functions, and types with their
+// constructors and methods, each with its file:line and the first sentence of
+// its doc comment.
+//
+// Like the call graph it works from syntax alone. Interfaces list the module
+// types that implement them; a type implements an interface when its method
+// set has every method with the same parameter and result types, compared as
+// written with package qualifiers stripped. Embedded interfaces are expanded
+// within the module and for a few standard ones (error, fmt.Stringer, io.*);
+// interfaces embedding anything else list no implementers.
+
+// outlineOptions selects what the outline shows.
+type outlineOptions struct {
+	target   string         // directory relative to the root, "." for the root
+	depth    int            // package levels below the target; negative for all
+	exported bool           // leave out unexported declarations
+	match    *regexp.Regexp // keep declarations whose name matches (nil for all)
+	tests    bool           // include declarations of in-package _test.go files
+	docs     bool           // append the first sentence of doc comments
+}
+
+// Caps that keep one declaration on one short line.
+const (
+	outlineMaxFields       = 12
+	outlineMaxImplementers = 10
+	outlineMaxValue        = 60
+	outlineMaxSummary      = 120
+)
+
+// outlinePackage is a parsed package of the module.
+type outlinePackage struct {
+	path  string // import path
+	name  string
+	rel   string // directory relative to the root
+	doc   string // package doc comment
+	files []*ast.File
+	types map[string]*outlineType
+	order []*outlineType // source order
+}
+
+// outlineType is a named type with the methods declared on it.
+type outlineType struct {
+	pkg     *outlinePackage
+	file    *ast.File
+	name    string
+	spec    *ast.TypeSpec
+	doc     *ast.CommentGroup
+	methods []*ast.FuncDecl
+}
+
+// Method sets of embeddable standard interfaces, keyed as in methodKey.
+var knownInterfaces = map[string]map[string]string{
+	"error":        {"Error": "()(string)"},
+	"fmt.Stringer": {"String": "()(string)"},
+	"io.Reader":    {"Read": "([]byte)(int,error)"},
+	"io.Writer":    {"Write": "([]byte)(int,error)"},
+	"io.Closer":    {"Close": "()(error)"},
+	"io.ReadWriter": {
+		"Read": "([]byte)(int,error)", "Write": "([]byte)(int,error)",
+	},
+	"io.ReadCloser": {
+		"Read": "([]byte)(int,error)", "Close": "()(error)",
+	},
+	"io.WriteCloser": {
+		"Write": "([]byte)(int,error)", "Close": "()(error)",
+	},
+	"io.ReadWriteCloser": {
+		"Read": "([]byte)(int,error)", "Write": "([]byte)(int,error)", "Close": "()(error)",
+	},
+}
+
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	qualifierPattern  = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*\.`)
+	testDeclPattern   = regexp.MustCompile(`^(Test|Benchmark|Fuzz|Example)([^a-z]|$)`)
+)
+
+type outliner struct {
+	fset     *token.FileSet
+	opts     outlineOptions
+	packages []*outlinePackage
+	byPath   map[string]*outlinePackage
+}
+
+// runOutline parses the packages of the go list -json stream on stdin and
+// writes a markdown section with a go code block for every package under the
+// target that has something to show.
+func runOutline(root string, opts outlineOptions, stdin io.Reader, stdout io.Writer) error {
+	listed, err := decodePackages(stdin)
+	if err != nil {
+		return err
+	}
+
+	o := &outliner{fset: token.NewFileSet(), opts: opts, byPath: map[string]*outlinePackage{}}
+	for _, lp := range listed {
+		names := append(append([]string{}, lp.GoFiles...), lp.CgoFiles...)
+		if opts.tests {
+			names = append(names, lp.TestGoFiles...)
+		}
+		if len(names) == 0 {
+			continue
+		}
+		rel, err := filepath.Rel(root, lp.Dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		pkg := &outlinePackage{path: lp.ImportPath, rel: filepath.ToSlash(rel), types: map[string]*outlineType{}}
+		for _, name := range names {
+			f, err := parser.ParseFile(o.fset, filepath.Join(lp.Dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+			if f == nil || (err != nil && len(f.Decls) == 0) {
+				continue
+			}
+			pkg.files = append(pkg.files, f)
+			if pkg.name == "" {
+				pkg.name = f.Name.Name
+			}
+			if f.Doc != nil && pkg.doc == "" {
+				pkg.doc = f.Doc.Text()
+			}
+		}
+		if len(pkg.files) == 0 {
+			continue
+		}
+		o.collectTypes(pkg)
+		o.packages = append(o.packages, pkg)
+		o.byPath[pkg.path] = pkg
+	}
+	sort.Slice(o.packages, func(i, j int) bool { return o.packages[i].path < o.packages[j].path })
+
+	for _, pkg := range o.packages {
+		if !o.inTarget(pkg.rel) {
+			continue
+		}
+		if lines := o.outlinePackage(pkg); len(lines) > 0 {
+			fmt.Fprintf(stdout, "### %s\n\n```go\n", pkg.path)
+			for _, line := range lines {
+				fmt.Fprintln(stdout, line)
+			}
+			fmt.Fprint(stdout, "```\n\n")
+		}
+	}
+	return nil
+}
+
+// inTarget reports whether a package directory is under the target and
+// within the depth limit.
+func (o *outliner) inTarget(rel string) bool {
+	var below string
+	switch {
+	case o.opts.target == "." || o.opts.target == "":
+		below = rel
+	case rel == o.opts.target:
+		below = "."
+	case strings.HasPrefix(rel, o.opts.target+"/"):
+		below = strings.TrimPrefix(rel, o.opts.target+"/")
+	default:
+		return false
+	}
+	if o.opts.depth < 0 || below == "." {
+		return true
+	}
+	return strings.Count(below, "/")+1 <= o.opts.depth
+}
+
+// collectTypes records the named types of a package and attaches methods to
+// their receiver types.
+func (o *outliner) collectTypes(pkg *outlinePackage) {
+	for _, f := range pkg.files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				t := &outlineType{pkg: pkg, file: f, name: ts.Name.Name, spec: ts, doc: ts.Doc}
+				if t.doc == nil && len(gen.Specs) == 1 {
+					t.doc = gen.Doc
+				}
+				pkg.types[t.name] = t
+				pkg.order = append(pkg.order, t)
+			}
+		}
+	}
+	for _, f := range pkg.files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			if t := pkg.types[receiverName(fn.Recv.List[0].Type)]; t != nil {
+				t.methods = append(t.methods, fn)
+			}
+		}
+	}
+}
+
+// keep reports whether a declaration passes the exported filter.
+func (o *outliner) keep(name string) bool {
+	return !o.opts.exported || ast.IsExported(name)
+}
+
+// matches reports whether a name passes the -match filter.
+func (o *outliner) matches(name string) bool {
+	return o.opts.match == nil || o.opts.match.MatchString(name)
+}
+
+// outlinePackage renders the lines of one package's code block.
+func (o *outliner) outlinePackage(pkg *outlinePackage) []string {
+	var consts, vars, funcs, types []string
+
+	// Types shown, and the functions grouped under them as constructors
+	shown := map[string]bool{}
+	for _, t := range pkg.order {
+		if o.keep(t.name) {
+			shown[t.name] = true
+		}
+	}
+	ctors := map[string][]*ast.FuncDecl{}
+
+	for _, f := range pkg.files {
+		testFile := strings.HasSuffix(o.fset.File(f.Pos()).Name(), "_test.go")
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				switch decl.Tok {
+				case token.CONST:
+					consts = append(consts, o.values(decl)...)
+				case token.VAR:
+					vars = append(vars, o.values(decl)...)
+				}
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if decl.Recv != nil || name == "init" || name == "_" || !o.keep(name) {
+					continue
+				}
+				if testFile && testDeclPattern.MatchString(name) {
+					continue
+				}
+				if result := constructorOf(decl); result != "" && shown[result] {
+					ctors[result] = append(ctors[result], decl)
+					continue
+				}
+				if o.matches(name) {
+					funcs = append(funcs, o.funcLine(decl))
+				}
+			}
+		}
+	}
+
+	for _, t := range pkg.order {
+		if !shown[t.name] {
+			continue
+		}
+		whole := o.matches(t.name)
+		var members []string
+		for _, fn := range ctors[t.name] {
+			if whole || o.matches(fn.Name.Name) {
+				members = append(members, "\t"+o.funcLine(fn))
+			}
+		}
+		for _, fn := range t.methods {
+			if o.keep(fn.Name.Name) && (whole || o.matches(fn.Name.Name)) {
+				members = append(members, "\t"+o.funcLine(fn))
+			}
+		}
+		if !whole && len(members) == 0 {
+			continue
+		}
+		types = append(types, o.typeLines(t)...)
+		types = append(types, members...)
+	}
+
+	if len(consts)+len(vars)+len(funcs)+len(types) == 0 {
+		return nil
+	}
+	header := "package " + pkg.name
+	if summary := o.summary(pkg.doc); summary != "" {
+		header += " // " + summary
+	}
+	lines := []string{header}
+	for _, group := range [][]string{consts, vars, funcs, types} {
+		if len(group) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, group...)
+		}
+	}
+	return lines
+}
+
+// values renders a const or var declaration, keeping parenthesized groups
+// (iota enumerations) together.
+func (o *outliner) values(gen *ast.GenDecl) []string {
+	var specs []string
+	for _, spec := range gen.Specs {
+		vs := spec.(*ast.ValueSpec)
+		var names []string
+		for _, ident := range vs.Names {
+			if ident.Name != "_" && o.keep(ident.Name) && o.matches(ident.Name) {
+				names = append(names, ident.Name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		line := strings.Join(names, ", ")
+		if vs.Type != nil {
+			line += " " + o.node(vs.Type)
+		}
+		if len(names) == len(vs.Names) && len(vs.Values) > 0 {
+			var values []string
+			for _, value := range vs.Values {
+				values = append(values, o.node(value))
+			}
+			line += " = " + truncate(strings.Join(values, ", "), outlineMaxValue)
+		}
+		docGroup := vs.Doc
+		if docGroup == nil && len(gen.Specs) == 1 {
+			docGroup = gen.Doc
+		}
+		if docGroup == nil {
+			docGroup = vs.Comment
+		}
+		specs = append(specs, line+o.comment(vs.Pos(), docGroup))
+	}
+
+	keyword := gen.Tok.String()
+	if len(specs) == 0 {
+		return nil
+	}
+	if !gen.Lparen.IsValid() || len(specs) == 1 {
+		return []string{keyword + " " + specs[0]}
+	}
+	lines := []string{keyword + " ("}
+	for _, spec := range specs {
+		lines = append(lines, "\t"+spec)
+	}
+	return append(lines, ")")
+}
+
+// funcLine renders a function or method signature.
+func (o *outliner) funcLine(fn *ast.FuncDecl) string {
+	line := "func "
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		recv := fn.Recv.List[0]
+		line += "("
+		if len(recv.Names) > 0 {
+			line += recv.Names[0].Name + " "
+		}
+		line += o.node(recv.Type) + ") "
+	}
+	line += fn.Name.Name + strings.TrimPrefix(o.node(fn.Type), "func")
+	return line + o.comment(fn.Pos(), fn.Doc)
+}
+
+// typeLines renders a type declaration: structs on one line, interfaces with
+// one method per line and their implementers.
+func (o *outliner) typeLines(t *outlineType) []string {
+	head := "type " + t.name + o.typeParams(t.spec.TypeParams)
+	if t.spec.Assign.IsValid() {
+		head += " ="
+	}
+	comment := o.comment(t.spec.Pos(), t.doc)
+
+	switch typ := t.spec.Type.(type) {
+	case *ast.StructType:
+		var fields []string
+		hidden := false
+		for _, field := range typ.Fields.List {
+			var names []string
+			for _, ident := range field.Names {
+				if o.keep(ident.Name) {
+					names = append(names, ident.Name)
+				} else {
+					hidden = true
+				}
+			}
+			switch {
+			case len(field.Names) == 0 && o.keep(receiverName(field.Type)):
+				fields = append(fields, o.node(field.Type))
+			case len(field.Names) == 0:
+				hidden = true
+			case len(names) > 0:
+				fields = append(fields, strings.Join(names, ", ")+" "+o.node(field.Type))
+			}
+		}
+		if len(fields) > outlineMaxFields {
+			fields = append(fields[:outlineMaxFields], fmt.Sprintf("... %d more", len(fields)-outlineMaxFields))
+		} else if hidden {
+			fields = append(fields, "...")
+		}
+		if len(fields) == 0 {
+			return []string{head + " struct{}" + comment}
+		}
+		return []string{head + " struct{ " + strings.Join(fields, "; ") + " }" + comment}
+
+	case *ast.InterfaceType:
+		if len(typ.Methods.List) == 0 {
+			return []string{head + " interface{}" + comment}
+		}
+		lines := []string{head + " interface {" + comment}
+		for _, field := range typ.Methods.List {
+			if len(field.Names) == 0 {
+				lines = append(lines, "\t"+o.node(field.Type))
+				continue
+			}
+			if ft, ok := field.Type.(*ast.FuncType); ok && o.keep(field.Names[0].Name) {
+				lines = append(lines, "\t"+field.Names[0].Name+strings.TrimPrefix(o.node(ft), "func"))
+			}
+		}
+		closing := "}"
+		if impls := o.implementers(t); len(impls) > 0 {
+			if len(impls) > outlineMaxImplementers {
+				impls = append(impls[:outlineMaxImplementers], fmt.Sprintf("... %d more", len(impls)-outlineMaxImplementers))
+			}
+			closing += " // implemented by: " + strings.Join(impls, ", ")
+		}
+		return append(lines, closing)
+	}
+	return []string{head + " " + o.node(t.spec.Type) + comment}
+}
+
+// typeParams renders a type parameter list as "[K comparable, V any]".
+func (o *outliner) typeParams(list *ast.FieldList) string {
+	if list == nil || len(list.List) == 0 {
+		return ""
+	}
+	var params []string
+	for _, field := range list.List {
+		var names []string
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+o.node(field.Type))
+	}
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// comment renders the trailing "// file.go:12: Summary." of a declaration.
+func (o *outliner) comment(pos token.Pos, group *ast.CommentGroup) string {
+	position := o.fset.Position(pos)
+	comment := fmt.Sprintf(" // %s:%d", filepath.Base(position.Filename), position.Line)
+	if group != nil {
+		if summary := o.summary(group.Text()); summary != "" {
+			comment += ": " + summary
+		}
+	}
+	return comment
+}
+
+// summary returns the first sentence of a doc comment, or "" without -docs.
+func (o *outliner) summary(text string) string {
+	if !o.opts.docs {
+		return ""
+	}
+	return truncate(new(doc.Package).Synopsis(text), outlineMaxSummary)
+}
+
+// node prints an expression on one line.
+func (o *outliner) node(n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, o.fset, n); err != nil {
+		return "?"
+	}
+	s := whitespacePattern.ReplaceAllString(buf.String(), " ")
+	s = strings.NewReplacer("( ", "(", ", )", ")", " )", ")", "{ }", "{}").Replace(s)
+	return s
+}
+
+// implementers lists the module types whose method sets satisfy an interface,
+// as "T" or "*T", qualified with the package name outside the interface's
+// package.
+func (o *outliner) implementers(iface *outlineType) []string {
+	if iface.spec.TypeParams != nil {
+		return nil
+	}
+	required := o.interfaceMethods(iface, map[*outlineType]bool{})
+	if len(required) == 0 {
+		return nil
+	}
+
+	var impls []string
+	for _, pkg := range o.packages {
+		for _, t := range pkg.order {
+			if _, isIface := t.spec.Type.(*ast.InterfaceType); isIface || t.spec.Assign.IsValid() {
+				continue
+			}
+			name := t.name
+			if pkg != iface.pkg {
+				name = pkg.name + "." + name
+			}
+			switch {
+			case satisfies(o.methodSet(t, false, map[*outlineType]bool{}), required):
+				impls = append(impls, name)
+			case satisfies(o.methodSet(t, true, map[*outlineType]bool{}), required):
+				impls = append(impls, "*"+name)
+			}
+		}
+	}
+	return impls
+}
+
+func satisfies(set, required map[string]string) bool {
+	for name, key := range required {
+		if set[name] != key {
+			return false
+		}
+	}
+	return true
+}
+
+// interfaceMethods returns the method keys an interface requires, or nil when
+// an embedded interface cannot be resolved.
+func (o *outliner) interfaceMethods(t *outlineType, seen map[*outlineType]bool) map[string]string {
+	typ, ok := t.spec.Type.(*ast.InterfaceType)
+	if !ok || seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	methods := map[string]string{}
+	for _, field := range typ.Methods.List {
+		if len(field.Names) > 0 {
+			if ft, ok := field.Type.(*ast.FuncType); ok {
+				methods[field.Names[0].Name] = o.methodKey(ft)
+			}
+			continue
+		}
+		var embedded map[string]string
+		if known, ok := knownInterfaces[o.node(field.Type)]; ok {
+			embedded = known
+		} else if et := o.resolveType(t, field.Type); et != nil {
+			embedded = o.interfaceMethods(et, seen)
+		}
+		if embedded == nil {
+			return nil
+		}
+		for name, key := range embedded {
+			methods[name] = key
+		}
+	}
+	return methods
+}
+
+// methodSet returns the method keys of T, or of *T with pointer, including
+// methods promoted from embedded module types.
+func (o *outliner) methodSet(t *outlineType, pointer bool, seen map[*outlineType]bool) map[string]string {
+	set := map[string]string{}
+	if seen[t] {
+		return set
+	}
+	seen[t] = true
+
+	if st, ok := t.spec.Type.(*ast.StructType); ok {
+		for _, field := range st.Fields.List {
+			if len(field.Names) > 0 {
+				continue
+			}
+			_, embeddedPointer := field.Type.(*ast.StarExpr)
+			if et := o.resolveType(t, field.Type); et != nil {
+				if _, isIface := et.spec.Type.(*ast.InterfaceType); isIface {
+					for name, key := range o.interfaceMethods(et, map[*outlineType]bool{}) {
+						set[name] = key
+					}
+					continue
+				}
+				for name, key := range o.methodSet(et, pointer || embeddedPointer, seen) {
+					set[name] = key
+				}
+			}
+		}
+	}
+	// Methods declared on the type win over promoted ones
+	for _, fn := range t.methods {
+		if _, pointerRecv := fn.Recv.List[0].Type.(*ast.StarExpr); pointer || !pointerRecv {
+			set[fn.Name.Name] = o.methodKey(fn.Type)
+		}
+	}
+	return set
+}
+
+// resolveType finds the module type an embedded type expression names, in
+// the package of t or through the imports of t's file.
+func (o *outliner) resolveType(t *outlineType, expr ast.Expr) *outlineType {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return o.resolveType(t, e.X)
+	case *ast.Ident:
+		return t.pkg.types[e.Name]
+	case *ast.SelectorExpr:
+		pkgName := exprName(e.X)
+		for _, spec := range t.file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			pkg := o.byPath[path]
+			if pkg == nil {
+				continue
+			}
+			name := pkg.name
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == pkgName {
+				return pkg.types[e.Sel.Name]
+			}
+		}
+	}
+	return nil
+}
+
+// methodKey renders the parameter and result types of a signature without
+// names or package qualifiers: "(Context,int)(*User,error)".
+func (o *outliner) methodKey(ft *ast.FuncType) string {
+	types := func(list *ast.FieldList) string {
+		var out []string
+		if list != nil {
+			for _, field := range list.List {
+				typ := qualifierPattern.ReplaceAllString(o.node(field.Type), "")
+				for i := 0; i < max(1, len(field.Names)); i++ {
+					out = append(out, typ)
+				}
+			}
+		}
+		return "(" + strings.Join(out, ",") + ")"
+	}
+	return types(ft.Params) + types(ft.Results)
+}
+
+// constructorOf returns the package type a function's first result is (T or
+// *T), like go doc's grouping of constructors.
+func constructorOf(fn *ast.FuncDecl) string {
+	if fn.Type.Results == nil || len(fn.Type.Results.List) == 0 {
+		return ""
+	}
+	typ := fn.Type.Results.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		typ = t.X
+	case *ast.IndexListExpr:
+		typ = t.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// truncate shortens s to at most n runes, ending in "...".
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
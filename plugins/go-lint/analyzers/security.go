@@ -0,0 +1,780 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The "security" pack covers the gosec rules that can be decided from the
+// syntax of one package, offline: SQL built from strings, weak crypto,
+// disabled TLS verification, request input reaching file paths or commands,
+// and credentials committed in string literals. Findings carry the
+// "security" severity, which the hook blocks on unless the project opts out.
+// The pack skips _test.go files, where fixtures and test servers use these
+// patterns on purpose.
+
+var sqlConcatCheck = &Check{
+	Name:     "sqlconcat",
+	Doc:      "SQL queries built with string concatenation or fmt.Sprintf",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runSQLConcat,
+}
+
+var weakCryptoCheck = &Check{
+	Name:     "weakcrypto",
+	Doc:      "MD5, SHA-1, DES and RC4, and RSA keys under 2048 bits",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runWeakCrypto,
+}
+
+var tlsInsecureCheck = &Check{
+	Name:     "tlsinsecure",
+	Doc:      "InsecureSkipVerify and TLS versions before 1.2",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runTLSInsecure,
+}
+
+var pathTraversalCheck = &Check{
+	Name:     "pathtraversal",
+	Doc:      "HTTP request input used as a file path without a check for \"..\"",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runPathTraversal,
+}
+
+var execArgsCheck = &Check{
+	Name:     "execargs",
+	Doc:      "exec.Command with HTTP request input or a shell script built at run time",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runExecArgs,
+}
+
+var hardcodedSecretCheck = &Check{
+	Name:     "secrets",
+	Doc:      "hard-coded credentials: known token formats and high-entropy values of credential-named variables",
+	Pack:     "security",
+	Severity: "security",
+	Run:      runHardcodedSecret,
+}
+
+// Methods that take a SQL query, with the index of the query argument. They
+// are matched on receivers named like database handles or in files importing
+// database/sql or sqlx, as in ctxpropagate.
+var sqlQueryArg = map[string]int{
+	"Query": 0, "QueryRow": 0, "Exec": 0, "Prepare": 0, "Raw": 0,
+	"Queryx": 0, "QueryRowx": 0, "MustExec": 0,
+	"QueryContext": 1, "QueryRowContext": 1, "ExecContext": 1, "PrepareContext": 1,
+	"QueryxContext": 1, "QueryRowxContext": 1, "MustExecContext": 1,
+	"Get": 1, "Select": 1,
+	"GetContext": 2, "SelectContext": 2,
+}
+
+// Weak primitives by import path and function, with the replacement to
+// suggest.
+var weakCryptoFuncs = map[string]struct {
+	funcs       []string
+	name        string
+	replacement string
+}{
+	"crypto/md5":  {[]string{"New", "Sum"}, "MD5", "crypto/sha256"},
+	"crypto/sha1": {[]string{"New", "Sum"}, "SHA-1", "crypto/sha256"},
+	"crypto/des":  {[]string{"NewCipher", "NewTripleDESCipher"}, "DES", "crypto/aes with cipher.NewGCM"},
+	"crypto/rc4":  {[]string{"NewCipher"}, "RC4", "crypto/aes with cipher.NewGCM"},
+}
+
+// TLS versions before 1.2.
+var insecureTLSVersions = map[string]bool{
+	"VersionSSL30": true, "VersionTLS10": true, "VersionTLS11": true,
+}
+
+// Fields and methods of *http.Request that carry client input.
+var requestInputs = map[string]bool{
+	"FormValue": true, "PostFormValue": true, "PathValue": true, "URL": true,
+	"Form": true, "PostForm": true, "MultipartForm": true, "Header": true,
+	"Cookie": true, "RequestURI": true,
+}
+
+// Router functions that return request input, by import path.
+var routerInputFuncs = map[string][]string{
+	"github.com/gorilla/mux":   {"Vars"},
+	"github.com/go-chi/chi":    {"URLParam"},
+	"github.com/go-chi/chi/v5": {"URLParam"},
+}
+
+// File functions and the index of their path argument, by import path.
+var pathSinks = map[string]map[string]int{
+	"os": {
+		"Open": 0, "OpenFile": 0, "ReadFile": 0, "Create": 0, "WriteFile": 0,
+		"Remove": 0, "RemoveAll": 0, "ReadDir": 0,
+	},
+	"io/ioutil": {"ReadFile": 0, "WriteFile": 0, "ReadDir": 0},
+	"net/http":  {"ServeFile": 2},
+}
+
+// Programs that run their -c argument as a script.
+var shellPrograms = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "/bin/sh": true, "/bin/bash": true,
+	"/usr/bin/env": true, "cmd": true, "cmd.exe": true, "powershell": true,
+}
+
+// Token formats worth blocking on wherever they appear.
+var secretFormats = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"an AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"a GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"a Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"a Stripe live key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{20,}`)},
+	{"a Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"a private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"a JSON Web Token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+}
+
+// URLs with a password in the userinfo part (DSNs, broker URLs).
+var urlPasswordPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s:@]+)@`)
+
+// Final name words that hold credentials (dbPassword, apiToken,
+// client_secret), and the words that make a final "key" one (apiKey,
+// PRIVATE_KEY). tokenCacheKey and passwordResetTemplate name something else.
+var (
+	secretNameWords = map[string]bool{
+		"password": true, "passwd": true, "pwd": true, "secret": true, "token": true,
+		"credential": true, "credentials": true,
+		"apikey": true, "accesskey": true, "privatekey": true, "authkey": true,
+	}
+	secretKeyWords = map[string]bool{"api": true, "access": true, "private": true, "auth": true, "secret": true, "signing": true}
+)
+
+// Values that stand in for a credential rather than being one.
+var placeholderPattern = regexp.MustCompile(`(?i)(example|changeme|change_me|placeholder|dummy|redacted|sample|xxxx|\*\*\*\*|<|\{\{|\$\{|%[sv])`)
+
+// Values that point at a credential rather than holding one: paths, file
+// names, ARNs and URNs, and colon- or dot-separated keys (auth:token:v2).
+var secretReferencePattern = regexp.MustCompile(`^(arn|urn):|^(/|\./|\.\./|~/|[A-Za-z]:\\)|^[\w.-]+(/[\w.-]+)+$|^[\w-]+([:.][\w-]+)+$`)
+
+// Shannon entropy (bits per character) a credential-named value needs, and
+// its minimum length. Words and repeated characters stay below it.
+const (
+	secretMinEntropy = 3.5
+	secretMinLength  = 16
+)
+
+// isConstString reports whether expr is a string the compiler knows: a
+// literal, a constant or a concatenation of them.
+func (p *Pass) isConstString(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Kind == token.STRING
+	case *ast.ParenExpr:
+		return p.isConstString(e.X)
+	case *ast.BinaryExpr:
+		return e.Op == token.ADD && p.isConstString(e.X) && p.isConstString(e.Y)
+	case *ast.Ident:
+		if e.Obj != nil {
+			return e.Obj.Kind == ast.Con
+		}
+		return p.lookupConst(e.Name)
+	}
+	return false
+}
+
+// lookupConst reports whether a constant is declared at package level in any
+// file of the package.
+func (p *Pass) lookupConst(name string) bool {
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					if ident.Name == name {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// builtQuery describes how a query argument is assembled at run time ("string
+// concatenation", "fmt.Sprintf"), following a local variable to its
+// assignments in fn. It returns "" for constant and unknown queries.
+func (p *Pass) builtQuery(expr ast.Expr, fn ast.Node, fmtPkg string) string {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return p.builtQuery(e.X, fn, fmtPkg)
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD && !p.isConstString(e) {
+			return "string concatenation"
+		}
+	case *ast.CallExpr:
+		if isPkgCall(e, fmtPkg, "Sprintf", "Sprint", "Sprintln") {
+			for _, arg := range e.Args[1:] {
+				if !p.isConstString(arg) {
+					return fmtPkg + "." + e.Fun.(*ast.SelectorExpr).Sel.Name
+				}
+			}
+		}
+	case *ast.Ident:
+		if fn == nil || e.Obj == nil || e.Obj.Kind != ast.Var {
+			return ""
+		}
+		how := ""
+		ast.Inspect(fn, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || how != "" || len(assign.Lhs) != len(assign.Rhs) {
+				return how == ""
+			}
+			for i, lhs := range assign.Lhs {
+				if ident, ok := lhs.(*ast.Ident); !ok || ident.Obj != e.Obj {
+					continue
+				}
+				if assign.Tok == token.ADD_ASSIGN && !p.isConstString(assign.Rhs[i]) {
+					how = "string concatenation"
+				} else if _, self := assign.Rhs[i].(*ast.Ident); !self {
+					how = p.builtQuery(assign.Rhs[i], nil, fmtPkg)
+				}
+			}
+			return how == ""
+		})
+		return how
+	}
+	return ""
+}
+
+func runSQLConcat(pass *Pass) {
+	fmtPkg := pass.importName("fmt")
+
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		index, ok := sqlQueryArg[sel.Sel.Name]
+		if !ok || index >= len(call.Args) {
+			return true
+		}
+		receiver := exprName(sel.X)
+		if receiver == "" || !dbReceiverPattern.MatchString(receiver) {
+			return true
+		}
+
+		if how := pass.builtQuery(call.Args[index], enclosingFunc(stack), fmtPkg); how != "" {
+			pass.Reportf(call.Args[index].Pos(), "%s.%s query is built with %s, which allows SQL injection; use placeholders and pass the values as arguments",
+				receiver, sel.Sel.Name, how)
+		}
+		return true
+	})
+}
+
+func runWeakCrypto(pass *Pass) {
+	rsaPkg := pass.importName("crypto/rsa")
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for path, weak := range weakCryptoFuncs {
+			if pkg := pass.importName(path); isPkgCall(call, pkg, weak.funcs...) {
+				pass.Reportf(call.Pos(), "%s is broken for security use; use %s", weak.name, weak.replacement)
+				return true
+			}
+		}
+		if isPkgCall(call, rsaPkg, "GenerateKey") && len(call.Args) == 2 {
+			if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.INT {
+				if bits, err := strconv.Atoi(lit.Value); err == nil && bits < 2048 {
+					pass.Reportf(lit.Pos(), "%d-bit RSA keys can be factored; use at least 2048 bits", bits)
+				}
+			}
+		}
+		return true
+	})
+}
+
+func runTLSInsecure(pass *Pass) {
+	tlsPkg := pass.importName("crypto/tls")
+
+	check := func(field string, value ast.Expr) {
+		switch field {
+		case "InsecureSkipVerify":
+			if ident, ok := value.(*ast.Ident); ok && ident.Name == "true" {
+				pass.Reportf(value.Pos(), "InsecureSkipVerify disables certificate checks and allows man-in-the-middle attacks; trust the server's CA with RootCAs instead")
+			}
+		case "MinVersion", "MaxVersion":
+			if sel, ok := value.(*ast.SelectorExpr); ok && tlsPkg != "" && exprName(sel.X) == tlsPkg && insecureTLSVersions[sel.Sel.Name] {
+				pass.Reportf(value.Pos(), "%s %s.%s allows TLS versions with known attacks; use %s.VersionTLS12 or later",
+					field, tlsPkg, sel.Sel.Name, tlsPkg)
+			}
+		}
+	}
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok {
+				check(key.Name, n.Value)
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok {
+					check(sel.Sel.Name, n.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+}
+
+// requestTaint tracks the variables of a function that hold HTTP request
+// input.
+type requestTaint struct {
+	pass      *Pass
+	requests  map[*ast.Object]bool
+	tainted   map[*ast.Object]string
+	sanitized map[*ast.Object]bool
+	routers   map[string][]string
+}
+
+// newRequestTaint collects the *http.Request parameters of fn and its
+// closures, and the variables assigned from their input. Variables passed
+// to filepath.Base, filepath.IsLocal or strings.Contains(v, "..") count as
+// checked.
+func (p *Pass) newRequestTaint(fn *ast.FuncDecl) *requestTaint {
+	httpPkg := p.importName("net/http")
+	t := &requestTaint{
+		pass:      p,
+		requests:  make(map[*ast.Object]bool),
+		tainted:   make(map[*ast.Object]string),
+		sanitized: make(map[*ast.Object]bool),
+		routers:   make(map[string][]string),
+	}
+	if httpPkg == "" || fn.Body == nil {
+		return t
+	}
+	for path, funcs := range routerInputFuncs {
+		if name := p.importName(path); name != "" {
+			t.routers[name] = funcs
+		}
+	}
+
+	addRequests := func(fields *ast.FieldList) {
+		for _, field := range fields.List {
+			star, ok := field.Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			if sel, ok := star.X.(*ast.SelectorExpr); !ok || exprName(sel.X) != httpPkg || sel.Sel.Name != "Request" {
+				continue
+			}
+			for _, name := range field.Names {
+				if name.Obj != nil {
+					t.requests[name.Obj] = true
+				}
+			}
+		}
+	}
+	addRequests(fn.Type.Params)
+
+	filepathPkg := p.importName("path/filepath")
+	pathPkg := p.importName("path")
+	stringsPkg := p.importName("strings")
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			addRequests(n.Type.Params)
+		case *ast.CallExpr:
+			checked := isPkgCall(n, filepathPkg, "Base", "IsLocal") || isPkgCall(n, pathPkg, "Base")
+			if isPkgCall(n, stringsPkg, "Contains") && len(n.Args) == 2 {
+				if lit, ok := n.Args[1].(*ast.BasicLit); ok && lit.Value == `".."` {
+					checked = true
+				}
+			}
+			if checked && len(n.Args) > 0 {
+				if ident, ok := n.Args[0].(*ast.Ident); ok && ident.Obj != nil {
+					t.sanitized[ident.Obj] = true
+				}
+			}
+		}
+		return true
+	})
+
+	// Assignments are visited in source order, so input flows forward
+	// through chains of variables
+	taint := func(lhs []ast.Expr, rhs []ast.Expr) {
+		for i, target := range lhs {
+			ident, ok := target.(*ast.Ident)
+			if !ok || ident.Obj == nil {
+				continue
+			}
+			value := rhs[0]
+			if len(rhs) == len(lhs) {
+				value = rhs[i]
+			}
+			if source := t.input(value); source != "" {
+				t.tainted[ident.Obj] = source
+			}
+		}
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) > 0 {
+				taint(n.Lhs, n.Rhs)
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) > 0 {
+				lhs := make([]ast.Expr, len(n.Names))
+				for i, name := range n.Names {
+					lhs[i] = name
+				}
+				taint(lhs, n.Values)
+			}
+		case *ast.RangeStmt:
+			source := t.input(n.X)
+			if source == "" {
+				return true
+			}
+			for _, v := range []ast.Expr{n.Key, n.Value} {
+				if ident, ok := v.(*ast.Ident); ok && ident.Obj != nil {
+					t.tainted[ident.Obj] = source
+				}
+			}
+		}
+		return true
+	})
+	return t
+}
+
+// input describes the request input expr carries ("r.FormValue",
+// "mux.Vars"), directly or through variables, or returns "" when it carries
+// none.
+// Values passed through filepath.Base are file names, not paths.
+func (t *requestTaint) input(expr ast.Expr) string {
+	if expr == nil || len(t.requests) == 0 {
+		return ""
+	}
+	filepathPkg := t.pass.importName("path/filepath")
+	pathPkg := t.pass.importName("path")
+
+	source := ""
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if source != "" {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if isPkgCall(n, filepathPkg, "Base") || isPkgCall(n, pathPkg, "Base") {
+				return false
+			}
+			for pkg, funcs := range t.routers {
+				if isPkgCall(n, pkg, funcs...) {
+					source = pkg + "." + n.Fun.(*ast.SelectorExpr).Sel.Name
+					return false
+				}
+			}
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok && ident.Obj != nil && t.requests[ident.Obj] && requestInputs[n.Sel.Name] {
+				source = ident.Name + "." + n.Sel.Name
+				return false
+			}
+		case *ast.Ident:
+			if n.Obj != nil && t.tainted[n.Obj] != "" && !t.sanitized[n.Obj] {
+				source = t.tainted[n.Obj]
+				return false
+			}
+		}
+		return true
+	})
+	return source
+}
+
+// eachRequestFunc calls f for every top-level function of the edited file
+// that has request input, with its taint.
+func (p *Pass) eachRequestFunc(f func(fn *ast.FuncDecl, taint *requestTaint)) {
+	if p.importName("net/http") == "" {
+		return
+	}
+	for _, decl := range p.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if taint := p.newRequestTaint(fn); len(taint.requests) > 0 {
+			f(fn, taint)
+		}
+	}
+}
+
+func runPathTraversal(pass *Pass) {
+	sinks := make(map[string]map[string]int)
+	for path, funcs := range pathSinks {
+		if name := pass.importName(path); name != "" {
+			sinks[name] = funcs
+		}
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	pass.eachRequestFunc(func(fn *ast.FuncDecl, taint *requestTaint) {
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			funcs, ok := sinks[exprName(sel.X)]
+			if !ok {
+				return true
+			}
+			index, ok := funcs[sel.Sel.Name]
+			if !ok || index >= len(call.Args) {
+				return true
+			}
+			if source := taint.input(call.Args[index]); source != "" {
+				pass.Reportf(call.Args[index].Pos(), "%s.%s path comes from %s and can reach outside the intended directory with \"..\"; check it with filepath.IsLocal or open it through os.Root",
+					exprName(sel.X), sel.Sel.Name, source)
+			}
+			return true
+		})
+	})
+}
+
+func runExecArgs(pass *Pass) {
+	execPkg := pass.importName("os/exec")
+	if execPkg == "" {
+		return
+	}
+
+	taints := make(map[*ast.FuncDecl]*requestTaint)
+	pass.eachRequestFunc(func(fn *ast.FuncDecl, taint *requestTaint) {
+		taints[fn] = taint
+	})
+
+	for _, decl := range pass.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || !isPkgCall(call, execPkg, "Command", "CommandContext") {
+				return true
+			}
+			name := call.Fun.(*ast.SelectorExpr).Sel.Name
+			args := call.Args
+			if name == "CommandContext" && len(args) > 0 {
+				args = args[1:]
+			}
+			if len(args) == 0 {
+				return true
+			}
+
+			if taint := taints[fn]; taint != nil {
+				for _, arg := range args {
+					if source := taint.input(arg); source != "" {
+						pass.Reportf(arg.Pos(), "%s.%s runs input from %s; check it against an allow list, and end options with \"--\" before user-supplied arguments",
+							execPkg, name, source)
+						return true
+					}
+				}
+			}
+
+			if program, ok := args[0].(*ast.BasicLit); ok {
+				if value, err := strconv.Unquote(program.Value); err == nil && shellPrograms[value] {
+					for i := 1; i+1 < len(args); i++ {
+						flag, ok := args[i].(*ast.BasicLit)
+						if !ok || (flag.Value != `"-c"` && flag.Value != `"/C"` && flag.Value != `"-Command"`) {
+							continue
+						}
+						if !pass.isConstString(args[i+1]) {
+							pass.Reportf(args[i+1].Pos(), "%s.%s runs a shell script built at run time, which allows command injection; run the program directly with separate arguments",
+								execPkg, name)
+						}
+						break
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// nameWords splits an identifier or map key into lower-case words at
+// underscores, dashes and camelCase boundaries (userDBPassword is user, db,
+// password).
+func nameWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if i > start {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+	}
+	if start < len(runes) {
+		words = append(words, strings.ToLower(string(runes[start:])))
+	}
+	return words
+}
+
+// isSecretName reports whether name ends in a word that holds a credential.
+func isSecretName(name string) bool {
+	words := nameWords(name)
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	if secretNameWords[last] {
+		return true
+	}
+	return last == "key" && len(words) > 1 && secretKeyWords[words[len(words)-2]]
+}
+
+// looksLikeSecret reports whether a credential-named value is a real
+// credential rather than a word, placeholder or reference to one.
+func looksLikeSecret(value string) bool {
+	if len(value) < secretMinLength || strings.ContainsAny(value, " \t\n") ||
+		placeholderPattern.MatchString(value) || secretReferencePattern.MatchString(value) {
+		return false
+	}
+	return shannonEntropy(value) >= secretMinEntropy
+}
+
+func runHardcodedSecret(pass *Pass) {
+	formats := secretFormats
+	for _, pattern := range pass.Config.Security.SecretPatterns {
+		if re, err := regexp.Compile(pattern); err == nil {
+			formats = append(formats, struct {
+				name    string
+				pattern *regexp.Regexp
+			}{"a secret matching " + pattern, re})
+		}
+	}
+
+	reported := make(map[token.Pos]bool)
+	report := func(lit *ast.BasicLit, format string, args ...any) {
+		if !reported[lit.Pos()] {
+			reported[lit.Pos()] = true
+			pass.Reportf(lit.Pos(), format, args...)
+		}
+	}
+
+	// A value assigned to a credential-named variable, field or map key
+	named := func(name string, value ast.Expr) {
+		lit, ok := value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		name = name[strings.LastIndex(name, ".")+1:]
+		if !isSecretName(name) {
+			return
+		}
+		if s, err := strconv.Unquote(lit.Value); err == nil && looksLikeSecret(s) {
+			report(lit, "%s is set to a hard-coded credential; load it from the environment or a secret manager", name)
+		}
+	}
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ImportSpec, *ast.Field:
+			// Import paths and struct tags are not values
+			return false
+		case *ast.ValueSpec:
+			for i, ident := range n.Names {
+				if i < len(n.Values) {
+					named(ident.Name, n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					named(exprName(lhs), n.Rhs[i])
+				}
+			}
+		case *ast.KeyValueExpr:
+			switch key := n.Key.(type) {
+			case *ast.Ident:
+				named(key.Name, n.Value)
+			case *ast.BasicLit:
+				if s, err := strconv.Unquote(key.Value); err == nil {
+					named(s, n.Value)
+				}
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.EQL || n.Op == token.NEQ {
+				named(exprName(n.X), n.Y)
+			}
+		case *ast.BasicLit:
+			if n.Kind != token.STRING {
+				return true
+			}
+			s, err := strconv.Unquote(n.Value)
+			if err != nil {
+				return true
+			}
+			for _, format := range formats {
+				if match := format.pattern.FindString(s); match != "" && !placeholderPattern.MatchString(match) {
+					report(n, "string contains %s; load it from the environment or a secret manager instead of committing it", format.name)
+					return true
+				}
+			}
+			if m := urlPasswordPattern.FindStringSubmatch(s); m != nil && !placeholderPattern.MatchString(m[1]) {
+				report(n, "URL contains a hard-coded password; load it from the environment or a secret manager")
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The "slog" pack checks log/slog calls against the project's conventions.
+// go vet's slog analyzer reports key-value mistakes it can prove with type
+// information; these checks work on the syntax of the edited file, so they
+// also run in /go-lint:lint-project alongside golangci-lint.
+
+var slogArgsCheck = &Check{
+	Name:     "slogargs",
+	Doc:      "slog calls with odd key-value arguments, non-constant keys or mixed key-value and slog.Attr arguments",
+	Pack:     "slog",
+	Severity: "warning",
+	Run:      runSlogArgs,
+}
+
+var slogKeysCheck = &Check{
+	Name:     "slogkeys",
+	Doc:      "slog keys that break the naming style or are on the forbidden list",
+	Pack:     "slog",
+	Severity: "warning",
+	Run:      runSlogKeys,
+}
+
+var legacyLogCheck = &Check{
+	Name:     "legacylog",
+	Doc:      "the log package in non-main, non-test code",
+	Pack:     "slog",
+	Severity: "warning",
+	Run:      runLegacyLog,
+}
+
+// defaultSlogKeyPattern is snake_case.
+const defaultSlogKeyPattern = `^[a-z][a-z0-9]*(_[a-z0-9]+)*$`
+
+// Index of the first key-value argument of each slog function and Logger
+// method; With and Group take nothing but key-value arguments after the
+// group name.
+var slogArgStart = map[string]int{
+	"Debug": 1, "Info": 1, "Warn": 1, "Error": 1,
+	"DebugContext": 2, "InfoContext": 2, "WarnContext": 2, "ErrorContext": 2,
+	"Log":   3,
+	"With":  0,
+	"Group": 1,
+}
+
+// Functions of log/slog that build a slog.Attr.
+var slogAttrFuncs = map[string]bool{
+	"String": true, "Int": true, "Int64": true, "Uint64": true, "Float64": true,
+	"Bool": true, "Time": true, "Duration": true, "Any": true, "Group": true,
+}
+
+// Receivers that look like *slog.Logger values, for the method calls above.
+var loggerReceiverPattern = regexp.MustCompile(`(?i)(^|[._])(l|lg|log|logger|slogger)$`)
+
+// slogCall is a call with slog key-value arguments.
+type slogCall struct {
+	call *ast.CallExpr
+	name string // "slog.Info", "logger.With", ...
+	args []ast.Expr
+}
+
+// slogCalls lists the slog calls in the edited file. Logger methods are
+// matched by receiver name, so they are only checked in files that import
+// log/slog.
+func (p *Pass) slogCalls() []slogCall {
+	slogPkg := p.importName("log/slog")
+	if slogPkg == "" {
+		return nil
+	}
+
+	var calls []slogCall
+	ast.Inspect(p.File, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		start, ok := slogArgStart[sel.Sel.Name]
+		if !ok || len(call.Args) < start {
+			return true
+		}
+
+		receiver := exprName(sel.X)
+		switch {
+		case receiver == slogPkg:
+		case sel.Sel.Name != "Group" && loggerReceiverPattern.MatchString(receiver):
+		default:
+			return true
+		}
+		calls = append(calls, slogCall{call: call, name: receiver + "." + sel.Sel.Name, args: call.Args[start:]})
+		return true
+	})
+	return calls
+}
+
+// slogArgKind classifies an argument in key position.
+type slogArgKind int
+
+const (
+	slogUnknown slogArgKind = iota
+	slogKey
+	slogAttr
+	slogDynamicKey
+)
+
+// classifySlogArg reports whether expr is a constant key (and its value when
+// it is a literal), a slog.Attr, a string built at run time, or unknown (a
+// variable that may hold either).
+func (p *Pass) classifySlogArg(expr ast.Expr, slogPkg string) (slogArgKind, string) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			key, err := strconv.Unquote(e.Value)
+			if err == nil {
+				return slogKey, key
+			}
+		}
+	case *ast.Ident:
+		if value, ok := p.lookupStringConst(e.Name); ok {
+			return slogKey, value
+		}
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && isPkgCall(e, slogPkg, sel.Sel.Name) && slogAttrFuncs[sel.Sel.Name] {
+			return slogAttr, ""
+		}
+		if isPkgCall(e, p.importName("fmt"), "Sprint", "Sprintf", "Sprintln") || isPkgCall(e, p.importName("strings"), "ToLower", "ToUpper", "Join", "TrimSpace") {
+			return slogDynamicKey, ""
+		}
+	case *ast.CompositeLit:
+		if sel, ok := e.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Attr" && exprName(sel.X) == slogPkg {
+			return slogAttr, ""
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return slogDynamicKey, ""
+		}
+	}
+	return slogUnknown, ""
+}
+
+// lookupStringConst finds a string constant declared at package level in any
+// file of the package.
+func (p *Pass) lookupStringConst(name string) (string, bool) {
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, ident := range vs.Names {
+					if ident.Name != name || i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						value, err := strconv.Unquote(lit.Value)
+						return value, err == nil
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// slogKeys walks the key-value arguments of a call and calls key for each
+// constant key. It stops at the first argument it cannot classify, since
+// the pairs after it cannot be aligned.
+func (p *Pass) slogKeys(c slogCall, slogPkg string, key func(expr ast.Expr, kind slogArgKind, name string, last bool)) {
+	spread := c.call.Ellipsis.IsValid()
+	for i := 0; i < len(c.args); {
+		if spread && i == len(c.args)-1 {
+			return
+		}
+		kind, name := p.classifySlogArg(c.args[i], slogPkg)
+		key(c.args[i], kind, name, i == len(c.args)-1)
+		switch kind {
+		case slogAttr:
+			i++
+		case slogKey, slogDynamicKey:
+			i += 2
+		default:
+			return
+		}
+	}
+}
+
+func runSlogArgs(pass *Pass) {
+	slogPkg := pass.importName("log/slog")
+	for _, c := range pass.slogCalls() {
+		var sawPair, sawAttr bool
+		pass.slogKeys(c, slogPkg, func(expr ast.Expr, kind slogArgKind, name string, last bool) {
+			switch kind {
+			case slogAttr:
+				sawAttr = true
+			case slogKey:
+				sawPair = true
+				if last {
+					pass.Reportf(expr.Pos(), "%s: key %q has no value (odd number of key-value arguments)", c.name, name)
+				}
+			case slogDynamicKey:
+				sawPair = true
+				pass.Reportf(expr.Pos(), "%s: key is built at run time; use a constant key and put the varying part in the value", c.name)
+			}
+		})
+		if sawPair && sawAttr {
+			pass.Reportf(c.call.Pos(), "%s mixes key-value pairs and slog.Attr arguments; use one style per call", c.name)
+		}
+	}
+}
+
+func runSlogKeys(pass *Pass) {
+	slogPkg := pass.importName("log/slog")
+	calls := pass.slogCalls()
+	if len(calls) == 0 {
+		return
+	}
+
+	pattern := defaultSlogKeyPattern
+	if pass.Config.Slog.KeyPattern != "" {
+		pattern = pass.Config.Slog.KeyPattern
+	}
+	keyPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		keyPattern = regexp.MustCompile(defaultSlogKeyPattern)
+	}
+	forbidden := make(map[string]bool)
+	for _, key := range pass.Config.Slog.ForbiddenKeys {
+		forbidden[strings.ToLower(key)] = true
+	}
+
+	for _, c := range calls {
+		pass.slogKeys(c, slogPkg, func(expr ast.Expr, kind slogArgKind, name string, last bool) {
+			if kind != slogKey {
+				return
+			}
+			if forbidden[strings.ToLower(name)] {
+				pass.Reportf(expr.Pos(), "%s: key %q is on the project's forbidden list (analyzers.slog.forbidden_keys); do not log this value", c.name, name)
+			} else if !keyPattern.MatchString(name) {
+				pass.Reportf(expr.Pos(), "%s: key %q does not match the key style %s", c.name, name, pattern)
+			}
+		})
+	}
+}
+
+func runLegacyLog(pass *Pass) {
+	if pass.File.Name.Name == "main" || strings.HasSuffix(pass.Path, "_test.go") {
+		return
+	}
+	for _, spec := range pass.File.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil && path == "log" {
+			pass.Reportf(spec.Pos(), "package log writes unstructured text in library code; use log/slog")
+		}
+	}
+}
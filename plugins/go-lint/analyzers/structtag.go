@@ -0,0 +1,427 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var structTagCheck = &Check{
+	Name: "structtag",
+	Doc:  "struct tag grammars (json, yaml, db, validate, mapstructure) and duplicate names",
+	Run:  runStructTag,
+}
+
+func runStructTag(pass *Pass) {
+	grammars := pass.Config.tagGrammars()
+
+	keys := make([]string, 0, len(grammars))
+	patterns := make(map[string]*regexp.Regexp)
+	for key, g := range grammars {
+		keys = append(keys, key)
+		if g.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(g.Pattern)
+		if err != nil {
+			pass.Reportf(pass.File.Package, "invalid %s tag pattern %q in .go-lint.json: %v", key, g.Pattern, err)
+			continue
+		}
+		patterns[key] = re
+	}
+	sort.Strings(keys)
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		if st, ok := n.(*ast.StructType); ok {
+			checkStructTags(pass, st, keys, grammars, patterns)
+		}
+		return true
+	})
+}
+
+// fieldTag returns the unquoted tag of a field, or "" if it has none.
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+func checkStructTags(pass *Pass, st *ast.StructType, keys []string, grammars map[string]TagGrammar, patterns map[string]*regexp.Regexp) {
+	fieldNames := make(map[string]bool)
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			fieldNames[name.Name] = true
+		}
+		if len(field.Names) == 0 {
+			fieldNames[embeddedName(field.Type)] = true
+		}
+	}
+
+	// Names already used per tag key, for duplicate detection
+	used := make(map[string]map[string]string)
+
+	for _, field := range st.Fields.List {
+		tag := fieldTag(field)
+		if tag == "" {
+			continue
+		}
+
+		for _, key := range keys {
+			value, ok := reflect.StructTag(tag).Lookup(key)
+			if !ok {
+				continue
+			}
+
+			g := grammars[key]
+			if g.Kind == "validate" {
+				checkValidateTag(pass, field.Tag.Pos(), value, fieldNames, g.Rules)
+				continue
+			}
+
+			name := checkNameTag(pass, field.Tag.Pos(), key, value, g, patterns[key])
+
+			// json duplicates follow encoding/json's promotion rules below
+			if key == "json" || name == "" || name == "-" {
+				continue
+			}
+			if used[key] == nil {
+				used[key] = make(map[string]string)
+			}
+			if other, ok := used[key][name]; ok {
+				pass.Reportf(field.Tag.Pos(), "%s name %q is also used by field %s", key, name, other)
+				continue
+			}
+			used[key][name] = fieldLabel(field)
+		}
+	}
+
+	checkJSONConflicts(pass, st)
+}
+
+// checkNameTag validates a `key:"name,opt,..."` tag and returns the name.
+func checkNameTag(pass *Pass, pos token.Pos, key, value string, g TagGrammar, pattern *regexp.Regexp) string {
+	parts := strings.Split(value, ",")
+	name := parts[0]
+
+	if g.Options != nil {
+		for _, opt := range parts[1:] {
+			if opt == "" || contains(g.Options, opt) {
+				continue
+			}
+			if len(g.Options) == 0 {
+				pass.Reportf(pos, "%s tag does not take options (got %q)", key, opt)
+			} else {
+				pass.Reportf(pos, "%s tag option %q is not one of %s", key, opt, strings.Join(g.Options, ", "))
+			}
+		}
+	}
+
+	if pattern != nil && name != "" && name != "-" && !pattern.MatchString(name) {
+		pass.Reportf(pos, "%s name %q does not match %s", key, name, pattern)
+	}
+
+	return name
+}
+
+// fieldLabel names a field for messages.
+func fieldLabel(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return embeddedName(field.Type)
+	}
+	return field.Names[0].Name
+}
+
+// embeddedName returns the type name of an embedded field.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedName(t.X)
+	}
+	return ""
+}
+
+// jsonField is a field as encoding/json sees it after promotion.
+type jsonField struct {
+	name   string
+	path   string
+	depth  int
+	tagged bool
+	outer  *ast.Field
+}
+
+// checkJSONConflicts reports json names that encoding/json silently drops
+// because several fields at the same depth claim them without exactly one
+// being tagged.
+func checkJSONConflicts(pass *Pass, st *ast.StructType) {
+	var fields []jsonField
+	collectJSONFields(pass, st, 0, "", nil, map[*ast.StructType]bool{st: true}, &fields)
+
+	byName := make(map[string][]jsonField)
+	var names []string
+	for _, f := range fields {
+		if _, ok := byName[f.name]; !ok {
+			names = append(names, f.name)
+		}
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	for _, name := range names {
+		group := byName[name]
+		minDepth := group[0].depth
+		for _, f := range group {
+			if f.depth < minDepth {
+				minDepth = f.depth
+			}
+		}
+
+		var dominant []jsonField
+		tagged := 0
+		for _, f := range group {
+			if f.depth == minDepth {
+				dominant = append(dominant, f)
+				if f.tagged {
+					tagged++
+				}
+			}
+		}
+		if len(dominant) < 2 || tagged == 1 {
+			continue
+		}
+
+		paths := make([]string, len(dominant))
+		for i, f := range dominant {
+			paths[i] = f.path
+		}
+		last := dominant[len(dominant)-1].outer
+		pos := last.Pos()
+		if last.Tag != nil && len(last.Names) > 0 {
+			pos = last.Tag.Pos()
+		}
+		pass.Reportf(pos, "json name %q is used by %s at the same depth; encoding/json silently drops all of them",
+			name, joinAnd(paths))
+	}
+}
+
+func collectJSONFields(pass *Pass, st *ast.StructType, depth int, prefix string, outer *ast.Field, seen map[*ast.StructType]bool, out *[]jsonField) {
+	for _, field := range st.Fields.List {
+		top := outer
+		if top == nil {
+			top = field
+		}
+
+		tag, hasTag := reflect.StructTag(fieldTag(field)).Lookup("json")
+		if tag == "-" {
+			continue
+		}
+		tagName := strings.Split(tag, ",")[0]
+		tagged := hasTag && tagName != ""
+
+		if len(field.Names) == 0 {
+			typeName := embeddedName(field.Type)
+
+			// Untagged embedded structs from this package promote their fields
+			if !tagged {
+				if _, isSelector := unstar(field.Type).(*ast.SelectorExpr); isSelector {
+					continue
+				}
+				if ts := pass.lookupType(typeName); ts != nil {
+					if inner, ok := ts.Type.(*ast.StructType); ok {
+						if !seen[inner] {
+							seen[inner] = true
+							collectJSONFields(pass, inner, depth+1, prefix+typeName+".", top, seen, out)
+							delete(seen, inner)
+						}
+						continue
+					}
+				}
+			}
+
+			name := typeName
+			if tagged {
+				name = tagName
+			} else if !ast.IsExported(typeName) {
+				continue
+			}
+			*out = append(*out, jsonField{name: name, path: prefix + typeName, depth: depth, tagged: tagged, outer: top})
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ast.IsExported(ident.Name) {
+				continue
+			}
+			name := ident.Name
+			if tagged {
+				name = tagName
+			}
+			*out = append(*out, jsonField{name: name, path: prefix + ident.Name, depth: depth, tagged: tagged, outer: top})
+		}
+	}
+}
+
+func unstar(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
+
+// Validator rules from github.com/go-playground/validator/v10.
+var knownValidateRules = toSet(`
+	- dive keys endkeys omitempty omitnil omitzero required isdefault structonly nostructlevel
+	required_if required_unless required_with required_with_all required_without required_without_all
+	excluded_if excluded_unless excluded_with excluded_with_all excluded_without excluded_without_all
+	len min max eq eq_ignore_case ne ne_ignore_case lt lte gt gte oneof oneofci unique
+	eqfield nefield gtfield gtefield ltfield ltefield fieldcontains fieldexcludes
+	eqcsfield necsfield gtcsfield gtecsfield ltcsfield ltecsfield
+	alpha alphanum alphaunicode alphanumunicode ascii printascii multibyte boolean numeric number
+	hexadecimal hexcolor rgb rgba hsl hsla lowercase uppercase
+	contains containsany containsrune excludes excludesall excludesrune
+	startswith endswith startsnotwith endsnotwith
+	email url http_url https_url uri urn_rfc2141 url_encoded html html_encoded datauri
+	base32 base64 base64url base64rawurl json jwt uuid uuid3 uuid4 uuid5 uuid_rfc4122 uuid3_rfc4122
+	uuid4_rfc4122 uuid5_rfc4122 ulid md4 md5 sha256 sha384 sha512 ripemd128 ripemd160
+	tiger128 tiger160 tiger192 semver cve cron
+	ip ipv4 ipv6 ip_addr ip4_addr ip6_addr cidr cidrv4 cidrv6 tcp_addr tcp4_addr tcp6_addr
+	udp_addr udp4_addr udp6_addr unix_addr mac hostname hostname_rfc1123 hostname_port fqdn port
+	dns_rfc1035_label file filepath image dir dirpath datetime timezone latitude longitude
+	e164 email isbn isbn10 isbn13 issn ssn eth_addr btc_addr btc_addr_bech32 credit_card
+	luhn_checksum mongodb mongodb_connection_string bic bcp47_language_tag
+	iso3166_1_alpha2 iso3166_1_alpha3 iso3166_1_alpha_numeric iso3166_2 iso4217 iso4217_numeric
+	postcode_iso3166_alpha2 postcode_iso3166_alpha2_field spicedb
+`)
+
+// Rules that cannot be used without a parameter.
+var paramValidateRules = toSet(`
+	required_if required_unless required_with required_with_all required_without required_without_all
+	excluded_if excluded_unless excluded_with excluded_with_all excluded_without excluded_without_all
+	len min max eq eq_ignore_case ne ne_ignore_case oneof oneofci
+	eqfield nefield gtfield gtefield ltfield ltefield fieldcontains fieldexcludes
+	eqcsfield necsfield gtcsfield gtecsfield ltcsfield ltecsfield
+	contains containsany containsrune excludes excludesall excludesrune
+	startswith endswith startsnotwith endsnotwith datetime postcode_iso3166_alpha2_field
+`)
+
+// Rules whose parameter is a size: a number, or a duration for time.Duration fields.
+var numericValidateRules = toSet(`len min max lt lte gt gte`)
+
+// Rules whose parameter names a sibling field.
+var fieldValidateRules = toSet(`eqfield nefield gtfield gtefield ltfield ltefield fieldcontains fieldexcludes`)
+
+// Rules whose parameter is a space-separated list of sibling fields.
+var fieldListValidateRules = toSet(`required_with required_with_all required_without required_without_all
+	excluded_with excluded_with_all excluded_without excluded_without_all`)
+
+// Rules whose parameter is "Field value Field value ...".
+var fieldPairValidateRules = toSet(`required_if required_unless excluded_if excluded_unless`)
+
+func checkValidateTag(pass *Pass, pos token.Pos, value string, fieldNames map[string]bool, custom []string) {
+	if value == "-" {
+		return
+	}
+
+	for _, rule := range strings.Split(value, ",") {
+		if rule == "" {
+			pass.Reportf(pos, "validate tag %q has an empty rule", value)
+			return
+		}
+		for _, alt := range strings.Split(rule, "|") {
+			checkValidateRule(pass, pos, alt, fieldNames, custom)
+		}
+	}
+}
+
+func checkValidateRule(pass *Pass, pos token.Pos, rule string, fieldNames map[string]bool, custom []string) {
+	name, param, hasParam := strings.Cut(rule, "=")
+
+	if !knownValidateRules[name] && !contains(custom, name) {
+		pass.Reportf(pos, "validate rule %q is not a known validator (add custom rules to analyzers.structtag.tags.validate.rules)", name)
+		return
+	}
+
+	if paramValidateRules[name] && (!hasParam || param == "") {
+		pass.Reportf(pos, "validate rule %q needs a parameter (%s=...)", name, name)
+		return
+	}
+	if !hasParam {
+		return
+	}
+
+	switch {
+	case numericValidateRules[name]:
+		if _, err := strconv.ParseFloat(param, 64); err != nil {
+			if _, err := time.ParseDuration(param); err != nil {
+				pass.Reportf(pos, "validate rule %q expects a number or duration", rule)
+			}
+		}
+	case fieldValidateRules[name]:
+		checkFieldRefs(pass, pos, rule, []string{param}, fieldNames)
+	case fieldListValidateRules[name]:
+		checkFieldRefs(pass, pos, rule, strings.Fields(param), fieldNames)
+	case fieldPairValidateRules[name]:
+		words := strings.Fields(param)
+		if len(words)%2 != 0 {
+			pass.Reportf(pos, "validate rule %q expects field/value pairs", rule)
+			return
+		}
+		var refs []string
+		for i := 0; i < len(words); i += 2 {
+			refs = append(refs, words[i])
+		}
+		checkFieldRefs(pass, pos, rule, refs, fieldNames)
+	}
+}
+
+func checkFieldRefs(pass *Pass, pos token.Pos, rule string, refs []string, fieldNames map[string]bool) {
+	for _, ref := range refs {
+		// Dotted references reach into nested structs, which are not resolved here
+		if strings.Contains(ref, ".") || fieldNames[ref] {
+			continue
+		}
+		pass.Reportf(pos, "validate rule %q refers to unknown field %s", rule, ref)
+	}
+}
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// joinAnd joins items as "a, b and c".
+func joinAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return fmt.Sprintf("%s and %s", strings.Join(items[:len(items)-1], ", "), items[len(items)-1])
+}
@@ -0,0 +1,586 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The "tests" pack only runs on _test.go files. go vet's testinggoroutine
+// already reports t.Fatal called directly from a goroutine, so testgoroutine
+// covers the calls it misses.
+
+var testHelperCheck = &Check{
+	Name: "testhelper",
+	Doc:  "test helpers that report failures without calling t.Helper()",
+	Pack: "tests",
+	Run:  runTestHelper,
+}
+
+var testGoroutineCheck = &Check{
+	Name: "testgoroutine",
+	Doc:  "require.* and failing helpers called from goroutines in tests",
+	Pack: "tests",
+	Run:  runTestGoroutine,
+}
+
+var testParallelCheck = &Check{
+	Name: "testparallel",
+	Doc:  "tests without t.Parallel() when the project requires it",
+	Pack: "tests",
+	Run:  runTestParallel,
+}
+
+var testLoopVarCheck = &Check{
+	Name: "testloopvar",
+	Doc:  "loop variable copies in table tests (missing before Go 1.22, redundant after)",
+	Pack: "tests",
+	Run:  runTestLoopVar,
+}
+
+var testTempDirCheck = &Check{
+	Name: "testtempdir",
+	Doc:  "os.MkdirTemp and friends in tests instead of t.TempDir()",
+	Pack: "tests",
+	Run:  runTestTempDir,
+}
+
+// Methods of testing.TB that report a failure, and the subset that stop the
+// calling goroutine.
+var (
+	testFailMethods = map[string]bool{
+		"Error": true, "Errorf": true, "Fail": true,
+		"Fatal": true, "Fatalf": true, "FailNow": true,
+	}
+	testStopMethods = map[string]bool{
+		"Fatal": true, "Fatalf": true, "FailNow": true,
+		"Skip": true, "Skipf": true, "SkipNow": true,
+	}
+)
+
+// testingParam returns the name of a *testing.T, *testing.B, *testing.F or
+// testing.TB parameter, or "".
+func testingParam(fn *ast.FuncType, testingPkg string) string {
+	if fn == nil || fn.Params == nil || testingPkg == "" {
+		return ""
+	}
+	for _, field := range fn.Params.List {
+		typ := field.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		sel, ok := typ.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != testingPkg {
+			continue
+		}
+		// T, B and F are passed by pointer, the TB interface by value
+		_, pointer := field.Type.(*ast.StarExpr)
+		switch sel.Sel.Name {
+		case "T", "B", "F":
+			if !pointer {
+				continue
+			}
+		case "TB":
+			if pointer {
+				continue
+			}
+		default:
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				return name.Name
+			}
+		}
+	}
+	return ""
+}
+
+// testingInScope returns the innermost testing parameter visible from a
+// node, looking through enclosing closures.
+func testingInScope(stack []ast.Node, testingPkg string) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var name string
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			name = testingParam(fn.Type, testingPkg)
+		case *ast.FuncLit:
+			name = testingParam(fn.Type, testingPkg)
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// isTestEntry reports whether fn is a Test, Benchmark, Fuzz or Example
+// function run by go test.
+func isTestEntry(fn *ast.FuncDecl) bool {
+	if fn.Recv != nil {
+		return false
+	}
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if rest, ok := strings.CutPrefix(fn.Name.Name, prefix); ok && (rest == "" || !isLowerStart(rest)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLowerStart(s string) bool {
+	return s[0] >= 'a' && s[0] <= 'z'
+}
+
+// methodCalls lists the methods called on recv in body, outside nested
+// closures.
+func methodCalls(body *ast.BlockStmt, recv string) map[string]token.Pos {
+	calls := make(map[string]token.Pos)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == recv {
+				if _, seen := calls[sel.Sel.Name]; !seen {
+					calls[sel.Sel.Name] = n.Pos()
+				}
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// subtestFuncs lists the package-level functions passed to t.Run, which are
+// subtest bodies rather than helpers.
+func (p *Pass) subtestFuncs() map[string]bool {
+	funcs := make(map[string]bool)
+	for _, f := range p.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Run" {
+				if ident, ok := call.Args[1].(*ast.Ident); ok {
+					funcs[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return funcs
+}
+
+func runTestHelper(pass *Pass) {
+	testingPkg := pass.importName("testing")
+	if testingPkg == "" {
+		return
+	}
+	subtests := pass.subtestFuncs()
+
+	// check reports a helper that uses t (its own parameter, or one it
+	// captures) to fail without calling t.Helper()
+	check := func(name, t string, body *ast.BlockStmt, pos token.Pos) {
+		if t == "" || body == nil {
+			return
+		}
+		calls := methodCalls(body, t)
+		if _, ok := calls["Helper"]; ok {
+			return
+		}
+		for method := range testFailMethods {
+			if _, ok := calls[method]; ok {
+				pass.Reportf(pos, "helper %s reports failures through %s without calling %s.Helper() first, so failures point at the helper instead of its caller",
+					name, t, t)
+				return
+			}
+		}
+	}
+
+	for _, decl := range pass.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || isTestEntry(fn) || (fn.Recv == nil && subtests[fn.Name.Name]) {
+			continue
+		}
+		check(fn.Name.Name, testingParam(fn.Type, testingPkg), fn.Body, fn.Name.Pos())
+	}
+
+	// Closures assigned to variables are helpers too; closures passed to
+	// t.Run are subtests
+	closure := func(name *ast.Ident, lit *ast.FuncLit, stack []ast.Node) {
+		if subtests[name.Name] {
+			return
+		}
+		t := testingParam(lit.Type, testingPkg)
+		if t == "" {
+			t = testingInScope(stack, testingPkg)
+		}
+		check(name.Name, t, lit.Body, name.Pos())
+	}
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range n.Rhs {
+				if lit, ok := rhs.(*ast.FuncLit); ok && i < len(n.Lhs) {
+					if ident, ok := n.Lhs[i].(*ast.Ident); ok {
+						closure(ident, lit, stack)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range n.Values {
+				if lit, ok := value.(*ast.FuncLit); ok && i < len(n.Names) {
+					closure(n.Names[i], lit, stack)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// lookupFunc finds a package-level function declared in any file of the
+// package.
+func (p *Pass) lookupFunc(name string) *ast.FuncDecl {
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// stopsGoroutine returns the first method of a helper's testing parameter
+// that stops the calling goroutine, or "".
+func stopsGoroutine(fn *ast.FuncDecl, testingPkg string) string {
+	t := testingParam(fn.Type, testingPkg)
+	if t == "" || fn.Body == nil {
+		return ""
+	}
+	first := ""
+	var firstPos token.Pos
+	for method, pos := range methodCalls(fn.Body, t) {
+		if testStopMethods[method] && (first == "" || pos < firstPos) {
+			first, firstPos = method, pos
+		}
+	}
+	return first
+}
+
+func runTestGoroutine(pass *Pass) {
+	testingPkg := pass.importName("testing")
+	if testingPkg == "" {
+		return
+	}
+	requirePkg := pass.importName("github.com/stretchr/testify/require")
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			// go helper(t) is reported by go vet
+			return true
+		}
+
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				if requirePkg != "" && isPkgCall(call, requirePkg, fun.Sel.Name) {
+					pass.Reportf(call.Pos(), "%s.%s calls FailNow, which cannot stop the test from another goroutine; use assert.%s or send the error back to the test goroutine",
+						requirePkg, fun.Sel.Name, fun.Sel.Name)
+				}
+			case *ast.Ident:
+				helper := pass.lookupFunc(fun.Name)
+				if helper == nil {
+					return true
+				}
+				if method := stopsGoroutine(helper, testingPkg); method != "" {
+					pass.Reportf(call.Pos(), "%s calls %s, which cannot stop the test from another goroutine; report with Error and return, or send the error back to the test goroutine",
+						fun.Name, method)
+				}
+			}
+			return true
+		})
+		return true
+	})
+}
+
+// Methods that make a test or its subtests unable to run in parallel.
+var parallelBlockers = map[string]bool{"Setenv": true, "Chdir": true}
+
+func runTestParallel(pass *Pass) {
+	require := pass.Config.TestParallel.Require
+	testingPkg := pass.importName("testing")
+	if require == "" || testingPkg == "" {
+		return
+	}
+
+	// needsParallel reports whether a test body should call t.Parallel()
+	needsParallel := func(body *ast.BlockStmt, t string) bool {
+		calls := methodCalls(body, t)
+		if _, ok := calls["Parallel"]; ok {
+			return false
+		}
+		for method := range parallelBlockers {
+			if _, ok := calls[method]; ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, decl := range pass.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || !isTestEntry(fn) || !strings.HasPrefix(fn.Name.Name, "Test") || fn.Name.Name == "TestMain" {
+			continue
+		}
+		t := testingParam(fn.Type, testingPkg)
+		if t == "" {
+			continue
+		}
+		if needsParallel(fn.Body, t) {
+			pass.Reportf(fn.Name.Pos(), "%s does not call %s.Parallel(), which this project requires", fn.Name.Name, t)
+		}
+		if require != "subtests" {
+			continue
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Run" {
+				return true
+			}
+			lit, ok := call.Args[1].(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			if sub := testingParam(lit.Type, testingPkg); sub != "" && needsParallel(lit.Body, sub) {
+				pass.Reportf(lit.Pos(), "subtest does not call %s.Parallel(), which this project requires", sub)
+			}
+			return true
+		})
+	}
+}
+
+// goMinorVersion reads the go directive of the nearest go.mod above dir and
+// returns its minor version (22 for "go 1.22.3"), or 0 if unknown.
+func goMinorVersion(dir string) int {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) != 2 || fields[0] != "go" {
+					continue
+				}
+				parts := strings.Split(fields[1], ".")
+				if len(parts) < 2 || parts[0] != "1" {
+					return 0
+				}
+				minor, _ := strconv.Atoi(parts[1])
+				return minor
+			}
+			return 0
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return 0
+		}
+		dir = parent
+	}
+}
+
+// loopVars lists the variables a loop declares per iteration.
+func loopVars(loop ast.Node) []string {
+	var names []string
+	switch l := loop.(type) {
+	case *ast.RangeStmt:
+		if l.Tok != token.DEFINE {
+			return nil
+		}
+		for _, expr := range []ast.Expr{l.Key, l.Value} {
+			if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+				names = append(names, ident.Name)
+			}
+		}
+	case *ast.ForStmt:
+		if init, ok := l.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			for _, lhs := range init.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name != "_" {
+					names = append(names, ident.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// loopCopies finds "v := v" copies of loop variables at the top of a body.
+func loopCopies(body *ast.BlockStmt, vars []string) map[string]*ast.AssignStmt {
+	copies := make(map[string]*ast.AssignStmt)
+	for _, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != len(assign.Rhs) {
+			continue
+		}
+		for i, lhs := range assign.Lhs {
+			left, ok1 := lhs.(*ast.Ident)
+			right, ok2 := assign.Rhs[i].(*ast.Ident)
+			if !ok1 || !ok2 || left.Name != right.Name {
+				continue
+			}
+			for _, v := range vars {
+				if v == left.Name {
+					copies[v] = assign
+				}
+			}
+		}
+	}
+	return copies
+}
+
+// parallelSubtestUses lists the loop variables used by a parallel subtest
+// started in a loop body.
+func parallelSubtestUses(body *ast.BlockStmt, vars []string, testingPkg string) map[string]token.Pos {
+	uses := make(map[string]token.Pos)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Run" {
+			return true
+		}
+		lit, ok := call.Args[1].(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		t := testingParam(lit.Type, testingPkg)
+		if t == "" {
+			return true
+		}
+		if _, parallel := methodCalls(lit.Body, t)["Parallel"]; !parallel {
+			return true
+		}
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				for _, v := range vars {
+					if _, seen := uses[v]; ident.Name == v && !seen {
+						uses[v] = ident.Pos()
+					}
+				}
+			}
+			return true
+		})
+		return false
+	})
+	return uses
+}
+
+func runTestLoopVar(pass *Pass) {
+	testingPkg := pass.importName("testing")
+	minor := goMinorVersion(pass.Dir)
+	if testingPkg == "" || minor == 0 {
+		return
+	}
+
+	ast.Inspect(pass.File, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch loop := n.(type) {
+		case *ast.RangeStmt:
+			body = loop.Body
+		case *ast.ForStmt:
+			body = loop.Body
+		default:
+			return true
+		}
+		vars := loopVars(n)
+		if len(vars) == 0 {
+			return true
+		}
+		copies := loopCopies(body, vars)
+
+		if minor >= 22 {
+			for _, v := range vars {
+				if assign, ok := copies[v]; ok {
+					pass.Reportf(assign.Pos(), "%s := %s is unnecessary since Go 1.22, which gives each iteration its own %s", v, v, v)
+				}
+			}
+			return true
+		}
+
+		uses := parallelSubtestUses(body, vars, testingPkg)
+		for _, v := range vars {
+			if pos, ok := uses[v]; ok && copies[v] == nil {
+				pass.Reportf(pos, "parallel subtest uses loop variable %s, which every iteration shares before Go 1.22; copy it with %s := %s before t.Run", v, v, v)
+			}
+		}
+		return true
+	})
+}
+
+func runTestTempDir(pass *Pass) {
+	testingPkg := pass.importName("testing")
+	if testingPkg == "" {
+		return
+	}
+	osPkg := pass.importName("os")
+	ioutilPkg := pass.importName("io/ioutil")
+
+	inspectStack(pass.File, func(n ast.Node, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		mkdir := isPkgCall(call, osPkg, "MkdirTemp") || isPkgCall(call, ioutilPkg, "TempDir")
+		create := (isPkgCall(call, osPkg, "CreateTemp") || isPkgCall(call, ioutilPkg, "TempFile")) &&
+			len(call.Args) > 0 && isEmptyString(call.Args[0])
+		if !mkdir && !create {
+			return true
+		}
+		t := testingInScope(stack, testingPkg)
+		if t == "" {
+			return true
+		}
+
+		name := exprName(call.Fun)
+		if mkdir {
+			pass.Reportf(call.Pos(), "%s leaves the directory behind if the test fails before cleanup; use %s.TempDir(), which is removed when the test ends",
+				name, t)
+		} else {
+			pass.Reportf(call.Pos(), "%s(\"\", ...) leaves the file in the system temp directory; create it in %s.TempDir()",
+				name, t)
+		}
+		return true
+	})
+}
+
+func isEmptyString(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING && (lit.Value == `""` || lit.Value == "``")
+}
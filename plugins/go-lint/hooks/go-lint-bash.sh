@@ -0,0 +1,86 @@
+#!/usr/bin/env bash
+#
+# Go Command Output Hook
+# Summarises go build / go test / go vet runs Claude makes through Bash
+#
+# This hook:
+# 1. Recognises go build, go test and go vet in the Bash command
+# 2. Condenses compiler errors, failing tests, panics and failing packages
+#    into a short summary with file:line locations
+# 3. Marks failing tests recorded by /go-lint:flaky as known flaky
+#
+# Passing runs produce no output. go test -json streams are decoded first.
+#
+
+set -euo pipefail
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-gocmd.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-flaky.sh"
+
+# Global error trap to ensure JSON output on unexpected failures
+trap '_gol_safe_exit "allow" "Unexpected error in go-lint bash hook" "PostToolUse" "Error code: $?"' ERR
+
+# Read stdin input with timeout to prevent indefinite hangs
+if command -v timeout &>/dev/null; then
+    INPUT=$(timeout 5s cat 2>/dev/null || true)
+else
+    INPUT=$(cat)
+fi
+
+# If no input (timeout or empty), exit silently
+if [[ -z "$INPUT" ]]; then
+    exit 0
+fi
+
+# jq is needed to read the hook input at all
+if ! command -v jq &>/dev/null; then
+    exit 0
+fi
+
+COMMAND=$(echo "$INPUT" | jq -r '.tool_input.command // empty' 2>/dev/null || echo "")
+SUBCOMMAND=$(_gol_go_subcommand "$COMMAND")
+
+# Exit silently for anything other than go build / test / vet
+if [[ -z "$SUBCOMMAND" ]]; then
+    exit 0
+fi
+
+OUTPUT=$(_gol_parse_tool_output "$INPUT")
+CWD=$(echo "$INPUT" | jq -r '.cwd // empty' 2>/dev/null || echo "")
+
+SUMMARY=$(_gol_summarize_go_output "$SUBCOMMAND" "$OUTPUT" "$CWD")
+
+# Nothing failed
+if [[ -z "$SUMMARY" ]]; then
+    exit 0
+fi
+
+# Tests recorded as flaky in the project are marked as such
+if [[ "$SUBCOMMAND" == "test" && -n "$CWD" && -d "$CWD" ]]; then
+    PROJECT_ROOT=$(_gol_find_project_root "$CWD" || true)
+    if [[ -n "$PROJECT_ROOT" ]]; then
+        SUMMARY=$(_gol_flaky_annotate "$SUMMARY" "$PROJECT_ROOT")
+        FLAKY_NOTE=$(_gol_flaky_note "$SUMMARY")
+        if [[ -n "$FLAKY_NOTE" ]]; then
+            SUMMARY="$SUMMARY
+
+$FLAKY_NOTE"
+        fi
+    fi
+fi
+
+# First line is the headline, sections follow after a blank line
+HEADLINE="${SUMMARY%%$'\n'*}"
+DETAILS="${SUMMARY#"$HEADLINE"}"
+DETAILS="${DETAILS#$'\n\n'}"
+
+_gol_safe_exit "allow" \
+    "$HEADLINE" \
+    "PostToolUse" \
+    "$DETAILS"
@@ -0,0 +1,92 @@
+#!/usr/bin/env bash
+#
+# Go Test Impact Stop Hook
+# Runs the tests affected by changed Go code before Claude finishes (opt-in)
+#
+# This hook:
+# 1. Does nothing unless impact.stop_hook is enabled in .go-lint.json (or
+#    GO_LINT_IMPACT=1 is set)
+# 2. Selects the test packages that import a package changed in the working
+#    tree (see go-lint-impact.sh), optionally narrowed by the call graph
+# 3. Runs them and blocks the stop with the failures and the selection
+#
+# Passing runs, timeouts, projects without changes and failures of tests
+# recorded as flaky by /go-lint:flaky alone produce no output. A stop that was
+# already continued by this hook is never blocked again.
+#
+
+set -euo pipefail
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-gocmd.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-impact.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-flaky.sh"
+
+# Unexpected failures must never keep Claude from stopping
+trap 'exit 0' ERR
+
+# Read stdin input with timeout to prevent indefinite hangs
+if command -v timeout &>/dev/null; then
+    INPUT=$(timeout 5s cat 2>/dev/null || true)
+else
+    INPUT=$(cat)
+fi
+
+if [[ -z "$INPUT" ]] || ! _gol_check_required_tools go git jq; then
+    exit 0
+fi
+
+# Claude is already continuing because of a Stop hook
+if [[ "$(echo "$INPUT" | jq -r '.stop_hook_active // false' 2>/dev/null)" == "true" ]]; then
+    exit 0
+fi
+
+CWD=$(echo "$INPUT" | jq -r '.cwd // empty' 2>/dev/null || echo "")
+PROJECT_ROOT=$(_gol_find_project_root "${CWD:-.}" || true)
+if [[ -z "$PROJECT_ROOT" ]]; then
+    exit 0
+fi
+
+ENABLED=$(_gol_project_config "$PROJECT_ROOT" '.impact.stop_hook' false)
+if [[ "$ENABLED" != "true" && "${GO_LINT_IMPACT:-}" != "1" ]]; then
+    exit 0
+fi
+
+USE_CALL_GRAPH=$(_gol_project_config "$PROJECT_ROOT" '.impact.callgraph' false)
+TIMEOUT=$(_gol_project_config "$PROJECT_ROOT" '.impact.timeout' 120)
+
+if ! _gol_impact_analyze "$PROJECT_ROOT" "./..." "$USE_CALL_GRAPH" || [[ -z "$_gol_IMPACT_SELECTED" ]]; then
+    exit 0
+fi
+
+_gol_impact_run_tests "$PROJECT_ROOT" "$TIMEOUT"
+if [[ $_gol_IMPACT_EXIT -eq 0 || $_gol_IMPACT_EXIT -eq 124 ]]; then
+    exit 0
+fi
+
+SUMMARY=$(_gol_summarize_go_output "test" "$_gol_IMPACT_OUTPUT" "$PROJECT_ROOT")
+if [[ -z "$SUMMARY" ]]; then
+    SUMMARY=$(echo "$_gol_IMPACT_OUTPUT" | tail -n 20)
+fi
+
+# Known-flaky tests failing on their own do not hold Claude back
+SUMMARY=$(_gol_flaky_annotate "$SUMMARY" "$PROJECT_ROOT")
+if _gol_flaky_all_known "$SUMMARY"; then
+    exit 0
+fi
+FLAKY_NOTE=$(_gol_flaky_note "$SUMMARY")
+
+jq -n --arg reason "Tests affected by the changed Go code fail. Fix them before finishing.
+
+$SUMMARY
+${FLAKY_NOTE:+
+$FLAKY_NOTE
+}
+Selected by $_gol_IMPACT_NARROWING:
+$(_gol_impact_format_selection)" '{decision: "block", reason: $reason}'
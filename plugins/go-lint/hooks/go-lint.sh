@@ -6,6 +6,24 @@
 # This hook:
 # 1. Runs goimports to format and fix imports
 # 2. Runs go vet to check for common mistakes
+# 3. Runs go-lint's own analyzers (struct tags, //go:embed patterns, context
+#    propagation, goroutine leaks, and the tests, slog and security packs);
+#    security findings block unless analyzers.security.block is false, and
+#    warnings from heuristic checks are reported without blocking
+#
+# Edits to go.mod and go.work are normalised and validated instead, and
+# direct edits to go.sum produce a warning (see go-lint-modfile.sh).
+# Edits to .golangci.{yml,yaml,json,toml} are validated against the installed
+# golangci-lint (see go-lint-golangci-config.sh).
+# Edits to go generate inputs (files with //go:generate directives, types
+# named by stringer-style directives, or inputs mapped in .go-lint.json)
+# report or run the matching go generate (see go-lint-generate.sh).
+# Go files outside any module are vetted in a scratch module instead
+# (see go-lint-scratch.sh).
+# Edits to assembly, C sources and .syso objects in a Go package vet that
+# package (see go-lint-package-files.sh).
+# Diagnostics beyond the output budget are trimmed to the most relevant ones,
+# with the full output in a log file (see go-lint-budget.sh).
 #
 # NOTE: This hook does NOT run golangci-lint (too slow for per-file hooks).
 #       Use the /go-lint:lint-project command for comprehensive linting.
@@ -19,12 +37,25 @@ PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
 
 # Source common library
 source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-modfile.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-golangci-config.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-generate.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-budget.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-scratch.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-package-files.sh"
 
 # Global error trap to ensure JSON output on unexpected failures
 trap '_gol_safe_exit "allow" "Unexpected error in go-lint hook" "PostToolUse" "Error code: $?"' ERR
 
 # Constants
 MAX_FILE_SIZE=1048576  # 1MB
+VET_TIMEOUT="${GO_LINT_VET_TIMEOUT:-20}"  # seconds, below the 30s hook timeout
+HOOK_DEADLINE=25  # seconds for all stages together, leaving time to report
+
+# go generate, go vet and the analyzers each have a time limit; they share
+# one deadline so that together they stay within the hook timeout
+_gol_start_deadline "$HOOK_DEADLINE"
 
 # Read stdin input with timeout to prevent indefinite hangs
 # Use timeout if available (GNU coreutils), otherwise fallback to cat
@@ -52,8 +83,46 @@ if [[ ! -f "$FILE_PATH" ]]; then
     exit 0
 fi
 
-# Exit silently if not a Go file
+# Module files get their own checks instead of formatting and vet
+if _gol_is_module_file "$FILE_PATH"; then
+    if ! _gol_check_required_tools go jq; then
+        MISSING_TOOLS="${_gol_MISSING_TOOLS[*]}"
+        _gol_safe_exit "allow" \
+            "Go module file checks skipped: missing tools" \
+            "PostToolUse" \
+            "Missing required tools: $MISSING_TOOLS"
+    fi
+
+    _gol_check_module_file "$FILE_PATH" "$INPUT"
+    exit 0
+fi
+
+# golangci-lint configs are validated; golangci-lint itself is optional here
+if _gol_is_golangci_config "$FILE_PATH"; then
+    _gol_check_golangci_config "$FILE_PATH"
+    exit 0
+fi
+
+# Assembly, cgo sources and .syso objects are checked through their package
+if _gol_is_package_file "$FILE_PATH" && command -v go &>/dev/null && command -v jq &>/dev/null; then
+    PACKAGE_FILE_ABS=$(_gol_get_absolute_path "$FILE_PATH")
+    PACKAGE_FILE_ROOT=$(_gol_find_project_root "$(dirname "$PACKAGE_FILE_ABS")" || true)
+    if [[ -n "$PACKAGE_FILE_ROOT" ]] && ! _gol_is_orphan_file "$PACKAGE_FILE_ABS"; then
+        _gol_check_package_file "$PACKAGE_FILE_ABS" "$PACKAGE_FILE_ROOT"
+    fi
+fi
+
+# Other non-Go files only matter as go generate inputs (.proto, .sql, templates, ...)
 if [[ ! "$FILE_PATH" =~ \.go$ ]]; then
+    if command -v jq &>/dev/null; then
+        INPUT_ROOT=$(_gol_find_project_root "$(dirname "$FILE_PATH")" || true)
+        if [[ -n "$INPUT_ROOT" ]] && _gol_check_generate "$(_gol_get_absolute_path "$FILE_PATH")" "$INPUT_ROOT"; then
+            _gol_safe_exit "allow" \
+                "$_gol_GENERATE_REASON" \
+                "PostToolUse" \
+                "$_gol_GENERATE_CONTEXT"
+        fi
+    fi
     exit 0
 fi
 
@@ -75,22 +144,22 @@ fi
 # Get file directory and find project root
 FILE_DIR=$(dirname "$FILE_PATH")
 FILE_ABS=$(_gol_get_absolute_path "$FILE_PATH")
-PROJECT_ROOT=$(_gol_find_project_root "$FILE_DIR")
+PROJECT_ROOT=$(_gol_find_project_root "$FILE_DIR" || true)
 
-# If no project root found, still try to format but skip go vet
-if [[ -z "$PROJECT_ROOT" ]]; then
-    # Just run goimports and report any errors
+# Outside any module (no project root, or a root without go.mod), format and
+# vet the file in a scratch module
+if [[ -z "$PROJECT_ROOT" ]] || _gol_is_orphan_file "$FILE_ABS"; then
     GOIMPORTS_EXIT=0
     GOIMPORTS_OUTPUT=$(goimports -w "$FILE_PATH" 2>&1) || GOIMPORTS_EXIT=$?
 
     if [[ $GOIMPORTS_EXIT -ne 0 ]]; then
         _gol_safe_exit "allow" \
-            "goimports formatting failed (no project root found)" \
+            "goimports formatting failed (no Go module found)" \
             "PostToolUse" \
             "goimports error: $GOIMPORTS_OUTPUT"
     fi
 
-    # Success - formatted without project context
+    _gol_check_scratch "$FILE_ABS" "$(_gol_edit_region "$INPUT" "$FILE_ABS")"
     exit 0
 fi
 
@@ -106,6 +175,13 @@ if [[ $GOIMPORTS_EXIT -ne 0 ]]; then
         "goimports error: $GOIMPORTS_OUTPUT"
 fi
 
+# Regenerate (or flag) code produced from this file before vetting the package
+GENERATE_NOTE=""
+if _gol_check_generate "$FILE_ABS" "$PROJECT_ROOT"; then
+    GENERATE_NOTE="$_gol_GENERATE_REASON
+$_gol_GENERATE_CONTEXT"
+fi
+
 # Run go vet on just the package containing the edited file
 # This is much faster than running on entire project
 FILE_PKG_DIR=$(dirname "$FILE_ABS")
@@ -113,28 +189,101 @@ REL_PATH=$(_gol_get_relative_path "$PROJECT_ROOT" "$FILE_ABS")
 REL_PKG_DIR=$(dirname "$REL_PATH")
 
 # Use subshell to preserve working directory
+VET_LIMIT=$(_gol_deadline_limit "$VET_TIMEOUT")
 GO_VET_EXIT=0
 GO_VET_OUTPUT=$(
-    cd "$PROJECT_ROOT" && go vet "./$REL_PKG_DIR" 2>&1
+    cd "$PROJECT_ROOT" && _gol_run_with_timeout "$VET_LIMIT" go vet "./$REL_PKG_DIR" 2>&1
 ) || GO_VET_EXIT=$?
 
+# Report a hung go vet instead of letting the hook itself time out
+if [[ $GO_VET_EXIT -eq 124 && $VET_LIMIT -le 0 ]]; then
+    _gol_safe_exit "allow" \
+        "go vet skipped: the hook's ${HOOK_DEADLINE}s time limit was used up" \
+        "PostToolUse" \
+        "Run go vet ./$REL_PKG_DIR manually to check the package${GENERATE_NOTE:+
+
+$GENERATE_NOTE}"
+fi
+if [[ $GO_VET_EXIT -eq 124 ]]; then
+    _gol_safe_exit "allow" \
+        "go vet timed out after ${VET_LIMIT}s" \
+        "PostToolUse" \
+        "go vet ./$REL_PKG_DIR did not finish within ${VET_LIMIT}s; run it manually to check the package${GENERATE_NOTE:+
+
+$GENERATE_NOTE}"
+fi
+
 # Parse go vet output for errors related to the edited file
+FILTERED_OUTPUT=""
 if [[ $GO_VET_EXIT -ne 0 ]] && [[ -n "$GO_VET_OUTPUT" ]]; then
     # Use relative path from project root for accurate matching
-    # grep -F for literal string matching (no regex interpretation)
-    # Pattern: "./$REL_PATH:" to match go vet output format
-    FILTERED_OUTPUT=$(echo "$GO_VET_OUTPUT" | grep -F "./$REL_PATH:" || echo "")
+    FILTERED_OUTPUT=$(_gol_filter_vet_output "$GO_VET_OUTPUT" "$REL_PATH")
+fi
 
-    # If there are errors in the edited file, report them
-    if [[ -n "$FILTERED_OUTPUT" ]]; then
-        # Count the number of issues (non-empty lines)
-        ISSUE_COUNT=$(echo "$FILTERED_OUTPUT" | grep -c "^" || echo "0")
+# go-lint analyzers catch what vet does not (see analyzers/); a missing binary
+# is built in the background and used from the next edit on
+ANALYZER_OUTPUT=$(_gol_run_analyzers "$PROJECT_ROOT" "$REL_PATH" background)
 
-        _gol_safe_exit "block" \
-            "go vet found $ISSUE_COUNT issue(s) in file" \
-            "PostToolUse" \
-            "$FILTERED_OUTPUT"
+# If there are errors in the edited file, report them
+if [[ -n "$FILTERED_OUTPUT" || -n "$ANALYZER_OUTPUT" ]]; then
+    if [[ -z "$ANALYZER_OUTPUT" ]]; then
+        REPORTED_BY="go vet"
+    elif [[ -z "$FILTERED_OUTPUT" ]]; then
+        REPORTED_BY="go-lint analyzers"
+    else
+        REPORTED_BY="go vet and go-lint analyzers"
+    fi
+
+    ISSUES=$(printf '%s\n%s' "$FILTERED_OUTPUT" "$ANALYZER_OUTPUT" | grep -v '^$')
+
+    # Count the number of issues (non-empty lines)
+    ISSUE_COUNT=$(echo "$ISSUES" | grep -c "^" || echo "0")
+    SECURITY_COUNT=$(_gol_count_security_findings "$ANALYZER_OUTPUT")
+    WARNING_COUNT=$(_gol_count_warning_findings "$ANALYZER_OUTPUT")
+
+    # Heuristic warnings never block; security findings block by default, but
+    # a project can report them without blocking
+    BLOCKING_COUNT=$((ISSUE_COUNT - WARNING_COUNT))
+    if [[ "$(_gol_project_config "$PROJECT_ROOT" '.analyzers.security.block' 'true')" == "false" ]]; then
+        BLOCKING_COUNT=$((BLOCKING_COUNT - SECURITY_COUNT))
+    fi
+    DECISION="block"
+    if [[ $BLOCKING_COUNT -eq 0 ]]; then
+        DECISION="allow"
     fi
+    SEVERITY_NOTES=()
+    if [[ $SECURITY_COUNT -gt 0 ]]; then
+        SEVERITY_NOTES+=("$SECURITY_COUNT security")
+    fi
+    if [[ $WARNING_COUNT -gt 0 ]]; then
+        SEVERITY_NOTES+=("$WARNING_COUNT warning(s)")
+    fi
+    SEVERITY_NOTE=""
+    if [[ ${#SEVERITY_NOTES[@]} -gt 0 ]]; then
+        SEVERITY_TEXT=$(printf '%s, ' "${SEVERITY_NOTES[@]}")
+        SEVERITY_NOTE=" (${SEVERITY_TEXT%, })"
+    fi
+
+    # Keep the most relevant issues within the output budget
+    EDIT_REGION=$(_gol_edit_region "$INPUT" "$FILE_ABS")
+    ISSUES=$(_gol_budget_diagnostics "$FILTERED_OUTPUT" "$ANALYZER_OUTPUT" \
+        "$(_gol_output_budget "$PROJECT_ROOT")" "$(basename "$FILE_ABS")" \
+        "$(echo "$EDIT_REGION" | cut -f1)" "$(echo "$EDIT_REGION" | cut -sf2)")
+
+    _gol_safe_exit "$DECISION" \
+        "$REPORTED_BY found $ISSUE_COUNT issue(s) in file$SEVERITY_NOTE" \
+        "PostToolUse" \
+        "$ISSUES${GENERATE_NOTE:+
+
+$GENERATE_NOTE}"
+fi
+
+# Report go generate results even when vet is clean
+if [[ -n "$GENERATE_NOTE" ]]; then
+    _gol_safe_exit "allow" \
+        "$_gol_GENERATE_REASON" \
+        "PostToolUse" \
+        "$_gol_GENERATE_CONTEXT"
 fi
 
 # All checks passed or no issues found in edited file - exit silently (success)
@@ -0,0 +1,10 @@
+import { McpServer } from '@modelcontextprotocol/sdk/server/mcp.js';
+/**
+ * Creates and configures the MCP server with Go tools
+ */
+export declare function createServer(): McpServer;
+/**
+ * Starts the MCP server with stdio transport
+ */
+export declare function startServer(): Promise<void>;
+//# sourceMappingURL=server.d.ts.map
\ No newline at end of file
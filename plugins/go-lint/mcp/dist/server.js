@@ -0,0 +1,64 @@
+import { McpServer } from '@modelcontextprotocol/sdk/server/mcp.js';
+import { StdioServerTransport } from '@modelcontextprotocol/sdk/server/stdio.js';
+import { resultSchema } from './utils/diagnostics.js';
+import { goLint, goLintSchema } from './tools/go-lint.js';
+import { goVet, goVetSchema } from './tools/go-vet.js';
+import { goTest, goTestSchema } from './tools/go-test.js';
+import { goFormat, goFormatSchema } from './tools/go-format.js';
+/**
+ * Creates and configures the MCP server with Go tools
+ */
+export function createServer() {
+    const server = new McpServer({
+        name: 'go-lint-server',
+        version: '1.0.0'
+    });
+    // Register go_lint tool
+    server.registerTool('go_lint', {
+        title: 'Go Lint',
+        description: 'Run golangci-lint on a Go directory or file and return its issues as structured diagnostics',
+        inputSchema: goLintSchema,
+        outputSchema: resultSchema
+    }, async (params) => {
+        return goLint(params);
+    });
+    // Register go_vet tool
+    server.registerTool('go_vet', {
+        title: 'Go Vet',
+        description: 'Run go vet on a Go directory or file and return analyzer findings and type errors as structured diagnostics',
+        inputSchema: goVetSchema,
+        outputSchema: resultSchema
+    }, async (params) => {
+        return goVet(params);
+    });
+    // Register go_test tool
+    server.registerTool('go_test', {
+        title: 'Go Test',
+        description: 'Run go test on a Go directory or file and return failing tests and compile errors as structured diagnostics',
+        inputSchema: goTestSchema,
+        outputSchema: resultSchema
+    }, async (params) => {
+        return goTest(params);
+    });
+    // Register go_format tool
+    server.registerTool('go_format', {
+        title: 'Go Format',
+        description: 'Check or apply goimports (or gofmt) formatting and return unformatted files with their diffs',
+        inputSchema: goFormatSchema,
+        outputSchema: resultSchema
+    }, async (params) => {
+        return goFormat(params);
+    });
+    return server;
+}
+/**
+ * Starts the MCP server with stdio transport
+ */
+export async function startServer() {
+    const server = createServer();
+    const transport = new StdioServerTransport();
+    await server.connect(transport);
+    // Log to stderr (stdout is used for MCP communication)
+    console.error('go-lint MCP server started');
+}
+//# sourceMappingURL=server.js.map
\ No newline at end of file
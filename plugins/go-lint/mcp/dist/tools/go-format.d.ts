@@ -0,0 +1,25 @@
+import { z } from 'zod';
+export declare const goFormatSchema: {
+    path: z.ZodOptional<z.ZodString>;
+    write: z.ZodOptional<z.ZodBoolean>;
+    max_results: z.ZodOptional<z.ZodNumber>;
+    timeout_seconds: z.ZodOptional<z.ZodNumber>;
+};
+export interface GoFormatParams {
+    path?: string;
+    write?: boolean;
+    max_results?: number;
+    timeout_seconds?: number;
+}
+/**
+ * Checks (or rewrites) formatting and import grouping; each unformatted file
+ * is one diagnostic at its first changed line, with the diff as the fix
+ */
+export declare function goFormat(params: GoFormatParams): Promise<{
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: import("../utils/diagnostics.js").ToolResult;
+}>;
+//# sourceMappingURL=go-format.d.ts.map
\ No newline at end of file
@@ -0,0 +1,73 @@
+import { statSync } from 'fs';
+import path from 'path';
+import { z } from 'zod';
+import { runCommand } from '../utils/run.js';
+import { resolveTarget } from '../utils/target.js';
+import { buildResult, errorResult, relativeFile } from '../utils/diagnostics.js';
+export const goFormatSchema = {
+    path: z.string().optional().describe('Directory or .go file to check (default: current directory)'),
+    write: z.boolean().optional().describe('Rewrite files in place instead of only reporting them (default: false)'),
+    max_results: z.number().int().positive().optional().describe('Maximum diagnostics to return (default: 100)'),
+    timeout_seconds: z.number().int().positive().optional().describe('Time limit (default: 60)')
+};
+/**
+ * Picks goimports, falling back to gofmt when it is not installed
+ */
+async function formatter(root) {
+    try {
+        await runCommand('goimports', ['-h'], root, 10);
+        return 'goimports';
+    }
+    catch {
+        return 'gofmt';
+    }
+}
+/**
+ * Checks (or rewrites) formatting and import grouping; each unformatted file
+ * is one diagnostic at its first changed line, with the diff as the fix
+ */
+export async function goFormat(params) {
+    let target;
+    try {
+        target = resolveTarget(params.path);
+        const root = target.root;
+        const timeout = params.timeout_seconds ?? 60;
+        const absolute = path.resolve(params.path || '.');
+        const scope = statSync(absolute).isFile() ? absolute : target.dir;
+        const tool = await formatter(root);
+        const listed = await runCommand(tool, ['-l', scope], root, timeout);
+        if (listed.timedOut) {
+            return buildResult('go_format', target, [], 0, `${tool} timed out after ${timeout}s`);
+        }
+        if (listed.code !== 0 && listed.stdout.trim() === '') {
+            return buildResult('go_format', target, [], 0, `${tool} exited with code ${listed.code}: ${listed.stderr.trim()}`);
+        }
+        const files = listed.stdout
+            .split('\n')
+            .filter((file) => file !== '' && !/(^|\/)(vendor|testdata)\//.test(relativeFile(file, root)));
+        const diagnostics = [];
+        for (const file of files) {
+            const diff = await runCommand(tool, ['-d', file], root, timeout);
+            const hunk = /^@@ -(\d+)/m.exec(diff.stdout);
+            diagnostics.push({
+                file: relativeFile(file, root),
+                line: hunk ? Number(hunk[1]) : 1,
+                column: 0,
+                linter: tool,
+                message: params.write ? `reformatted with ${tool}` : `file is not ${tool}-formatted`,
+                fix: diff.stdout
+            });
+        }
+        if (params.write && files.length > 0) {
+            const written = await runCommand(tool, ['-w', ...files], root, timeout);
+            if (written.code !== 0) {
+                return buildResult('go_format', target, diagnostics, params.max_results ?? 100, `${tool} -w exited with code ${written.code}: ${written.stderr.trim()}`);
+            }
+        }
+        return buildResult('go_format', target, diagnostics, params.max_results ?? 100, undefined, { written: Boolean(params.write) });
+    }
+    catch (error) {
+        return errorResult('go_format', target, error);
+    }
+}
+//# sourceMappingURL=go-format.js.map
\ No newline at end of file
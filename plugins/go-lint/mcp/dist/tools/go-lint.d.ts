@@ -0,0 +1,26 @@
+import { z } from 'zod';
+export declare const goLintSchema: {
+    path: z.ZodOptional<z.ZodString>;
+    fix: z.ZodOptional<z.ZodBoolean>;
+    linters: z.ZodOptional<z.ZodArray<z.ZodString, "many">>;
+    max_results: z.ZodOptional<z.ZodNumber>;
+    timeout_seconds: z.ZodOptional<z.ZodNumber>;
+};
+export interface GoLintParams {
+    path?: string;
+    fix?: boolean;
+    linters?: string[];
+    max_results?: number;
+    timeout_seconds?: number;
+}
+/**
+ * Runs golangci-lint on a target and returns its issues as diagnostics
+ */
+export declare function goLint(params: GoLintParams): Promise<{
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: import("../utils/diagnostics.js").ToolResult;
+}>;
+//# sourceMappingURL=go-lint.d.ts.map
\ No newline at end of file
@@ -0,0 +1,45 @@
+import { z } from 'zod';
+import { type Diagnostic } from '../utils/diagnostics.js';
+export declare const goTestSchema: {
+    path: z.ZodOptional<z.ZodString>;
+    run: z.ZodOptional<z.ZodString>;
+    short: z.ZodOptional<z.ZodBoolean>;
+    race: z.ZodOptional<z.ZodBoolean>;
+    count: z.ZodOptional<z.ZodNumber>;
+    max_results: z.ZodOptional<z.ZodNumber>;
+    timeout_seconds: z.ZodOptional<z.ZodNumber>;
+};
+export interface GoTestParams {
+    path?: string;
+    run?: string;
+    short?: boolean;
+    race?: boolean;
+    count?: number;
+    max_results?: number;
+    timeout_seconds?: number;
+}
+/**
+ * Turns go test -json events into diagnostics: one per failing test (parents
+ * that only fail through subtests are skipped), one per compile error, and
+ * one per package that failed outside any test
+ */
+export declare function parseTestEvents(output: string, root: string): Promise<{
+    diagnostics: Diagnostic[];
+    summary: {
+        passed: number;
+        failed: number;
+        skipped: number;
+    };
+}>;
+/**
+ * Runs go test on a target and returns failing tests, compile errors and
+ * pass/fail/skip counts
+ */
+export declare function goTest(params: GoTestParams): Promise<{
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: import("../utils/diagnostics.js").ToolResult;
+}>;
+//# sourceMappingURL=go-test.d.ts.map
\ No newline at end of file
@@ -0,0 +1,203 @@
+import path from 'path';
+import { z } from 'zod';
+import { runCommand } from '../utils/run.js';
+import { resolveTarget } from '../utils/target.js';
+import { buildResult, errorResult, parsePositionLine, relativeFile } from '../utils/diagnostics.js';
+export const goTestSchema = {
+    path: z.string().optional().describe('Directory or .go file whose packages to test (default: current directory)'),
+    run: z.string().optional().describe('Only run tests matching this regexp (go test -run)'),
+    short: z.boolean().optional().describe('Pass -short'),
+    race: z.boolean().optional().describe('Enable the race detector'),
+    count: z.number().int().positive().optional().describe('Run each test this many times; 1 bypasses the test cache'),
+    max_results: z.number().int().positive().optional().describe('Maximum diagnostics to return (default: 100)'),
+    timeout_seconds: z.number().int().positive().optional().describe('Time limit (default: 600)')
+};
+// "    calc_test.go:12: got 3, want 4" as printed by t.Errorf and friends
+const TEST_LOG_LINE = /^\s+([^\s:]+\.go):(\d+): (.*)$/;
+// "\t/abs/path/file.go:12 +0x1d" under a goroutine stack frame
+const STACK_POSITION = /^\s+(\/.+\.go):(\d+)(?: \+0x[0-9a-f]+)?$/;
+// Frames inside the runtime and testing packages, skipped when locating a panic
+const RUNTIME_FRAME = /^((runtime|testing)\.|panic\(|created by )/;
+/**
+ * Looks up package directories so test log positions (bare file names) can
+ * be made relative to the project root
+ */
+async function packageDirs(root, packages) {
+    const dirs = new Map();
+    if (packages.length === 0) {
+        return dirs;
+    }
+    const result = await runCommand('go', ['list', '-f', '{{.ImportPath}}\t{{.Dir}}', ...packages], root, 60);
+    for (const line of result.stdout.split('\n')) {
+        const [pkg, dir] = line.split('\t');
+        if (pkg && dir) {
+            dirs.set(pkg, dir);
+        }
+    }
+    return dirs;
+}
+/**
+ * Turns go test -json events into diagnostics: one per failing test (parents
+ * that only fail through subtests are skipped), one per compile error, and
+ * one per package that failed outside any test
+ */
+export async function parseTestEvents(output, root) {
+    const diagnostics = [];
+    const tests = new Map();
+    const packageOutput = new Map();
+    const failedPackages = new Set();
+    const summary = { passed: 0, failed: 0, skipped: 0 };
+    for (const line of output.split('\n')) {
+        if (!line.startsWith('{')) {
+            continue;
+        }
+        let event;
+        try {
+            event = JSON.parse(line);
+        }
+        catch {
+            continue;
+        }
+        if (event.Action === 'build-output' && event.Output) {
+            const diagnostic = parsePositionLine(event.Output, root, root, 'compile');
+            if (diagnostic) {
+                diagnostic.package = event.ImportPath?.replace(/ \[.*\]$/, '');
+                diagnostics.push(diagnostic);
+            }
+            continue;
+        }
+        const pkg = event.Package || '';
+        if (!event.Test) {
+            if (event.Action === 'output' && event.Output) {
+                packageOutput.set(pkg, [...(packageOutput.get(pkg) || []), event.Output]);
+            }
+            else if (event.Action === 'fail') {
+                failedPackages.add(pkg);
+            }
+            continue;
+        }
+        const key = `${pkg}\u0000${event.Test}`;
+        const record = tests.get(key) || { pkg, test: event.Test, output: [], failed: false };
+        tests.set(key, record);
+        if (event.Action === 'output' && event.Output) {
+            record.output.push(event.Output);
+        }
+        else if (event.Action === 'pass') {
+            summary.passed++;
+        }
+        else if (event.Action === 'skip') {
+            summary.skipped++;
+        }
+        else if (event.Action === 'fail') {
+            record.failed = true;
+            summary.failed++;
+        }
+    }
+    const failing = [...tests.values()].filter((record) => record.failed);
+    const dirs = await packageDirs(root, [...new Set(failing.map((record) => record.pkg))]);
+    for (const record of failing) {
+        const hasFailingChild = failing.some((other) => other.pkg === record.pkg && other.test.startsWith(`${record.test}/`));
+        const ownOutput = record.output.filter((text) => !/^(=== |--- |\s*$)/.test(text));
+        if (hasFailingChild && ownOutput.length === 0) {
+            continue;
+        }
+        const dir = dirs.get(record.pkg) || root;
+        let file = '';
+        let lineNumber = 0;
+        const messages = [];
+        const panicAt = ownOutput.findIndex((text) => text.startsWith('panic: '));
+        if (panicAt >= 0) {
+            // Report the panic message at the first frame outside runtime/testing
+            messages.push(ownOutput[panicAt].trim());
+            for (let i = panicAt + 1; i < ownOutput.length && !file; i++) {
+                const position = STACK_POSITION.exec(ownOutput[i].trimEnd());
+                if (position && !RUNTIME_FRAME.test(ownOutput[i - 1].trim())) {
+                    file = relativeFile(position[1], root);
+                    lineNumber = Number(position[2]);
+                    messages.push(`at ${ownOutput[i - 1].trim()}`);
+                }
+            }
+        }
+        else {
+            for (const text of ownOutput) {
+                const match = TEST_LOG_LINE.exec(text.trimEnd());
+                if (match && !file) {
+                    file = relativeFile(path.join(dir, match[1]), root);
+                    lineNumber = Number(match[2]);
+                }
+                messages.push(match ? match[3] : text.trim());
+            }
+        }
+        diagnostics.push({
+            file,
+            line: lineNumber,
+            column: 0,
+            linter: 'test',
+            message: messages.join('\n') || `${record.test} failed`,
+            package: record.pkg,
+            test: record.test
+        });
+    }
+    // Packages that failed without a failing test: panics in init or
+    // TestMain, or setup errors not reported as build output
+    const packagesWithFailures = new Set(diagnostics.map((diagnostic) => diagnostic.package));
+    for (const pkg of failedPackages) {
+        if (packagesWithFailures.has(pkg)) {
+            continue;
+        }
+        const text = (packageOutput.get(pkg) || [])
+            .filter((line) => !/^(FAIL|ok|PASS)\b/.test(line))
+            .join('')
+            .trim();
+        diagnostics.push({
+            file: '',
+            line: 0,
+            column: 0,
+            linter: 'test',
+            message: text || `package ${pkg} failed`,
+            package: pkg
+        });
+    }
+    return { diagnostics, summary };
+}
+/**
+ * Runs go test on a target and returns failing tests, compile errors and
+ * pass/fail/skip counts
+ */
+export async function goTest(params) {
+    let target;
+    try {
+        target = resolveTarget(params.path);
+        const timeout = params.timeout_seconds ?? 600;
+        const args = ['test', '-json', `-timeout=${timeout}s`];
+        if (params.run) {
+            args.push('-run', params.run);
+        }
+        if (params.short) {
+            args.push('-short');
+        }
+        if (params.race) {
+            args.push('-race');
+        }
+        if (params.count) {
+            args.push(`-count=${params.count}`);
+        }
+        args.push(target.pattern);
+        // Leave go test's own -timeout a few seconds to report the stuck test
+        const result = await runCommand('go', args, target.root, timeout + 30);
+        if (result.timedOut) {
+            return buildResult('go_test', target, [], 0, `go test timed out after ${timeout}s`);
+        }
+        const { diagnostics, summary } = await parseTestEvents(result.stdout, target.root);
+        // Setup errors (bad pattern, missing module) go to stderr without events
+        if (result.code !== 0 && diagnostics.length === 0) {
+            const output = (result.stderr || result.stdout).trim();
+            return buildResult('go_test', target, [], 0, `go test exited with code ${result.code}: ${output}`, { summary });
+        }
+        return buildResult('go_test', target, diagnostics, params.max_results ?? 100, undefined, { summary });
+    }
+    catch (error) {
+        return errorResult('go_test', target, error);
+    }
+}
+//# sourceMappingURL=go-test.js.map
\ No newline at end of file
@@ -0,0 +1,30 @@
+import { z } from 'zod';
+import { type Diagnostic } from '../utils/diagnostics.js';
+export declare const goVetSchema: {
+    path: z.ZodOptional<z.ZodString>;
+    analyzers: z.ZodOptional<z.ZodArray<z.ZodString, "many">>;
+    max_results: z.ZodOptional<z.ZodNumber>;
+    timeout_seconds: z.ZodOptional<z.ZodNumber>;
+};
+export interface GoVetParams {
+    path?: string;
+    analyzers?: string[];
+    max_results?: number;
+    timeout_seconds?: number;
+}
+/**
+ * Parses go vet -json output: pretty-printed JSON objects for analyzer
+ * findings, interleaved with "# pkg" headers and plain-text type errors
+ */
+export declare function parseVetOutput(output: string, root: string): Diagnostic[];
+/**
+ * Runs go vet on a target and returns analyzer findings and type errors
+ */
+export declare function goVet(params: GoVetParams): Promise<{
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: import("../utils/diagnostics.js").ToolResult;
+}>;
+//# sourceMappingURL=go-vet.d.ts.map
\ No newline at end of file
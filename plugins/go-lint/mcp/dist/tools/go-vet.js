@@ -0,0 +1,95 @@
+import { z } from 'zod';
+import { runCommand } from '../utils/run.js';
+import { resolveTarget } from '../utils/target.js';
+import { buildResult, errorResult, parsePositionLine, relativeFile } from '../utils/diagnostics.js';
+export const goVetSchema = {
+    path: z.string().optional().describe('Directory or .go file to vet (default: current directory)'),
+    analyzers: z.array(z.string()).optional().describe('Only run these vet analyzers, e.g. ["printf", "copylocks"]'),
+    max_results: z.number().int().positive().optional().describe('Maximum diagnostics to return (default: 100)'),
+    timeout_seconds: z.number().int().positive().optional().describe('Time limit (default: 120)')
+};
+/**
+ * Parses go vet -json output: pretty-printed JSON objects for analyzer
+ * findings, interleaved with "# pkg" headers and plain-text type errors
+ */
+export function parseVetOutput(output, root) {
+    const diagnostics = [];
+    let buffer;
+    for (const line of output.split('\n')) {
+        if (buffer) {
+            buffer.push(line);
+            if (line === '}') {
+                collectReport(JSON.parse(buffer.join('\n')), root, diagnostics);
+                buffer = undefined;
+            }
+            continue;
+        }
+        if (line === '{') {
+            buffer = [line];
+            continue;
+        }
+        if (line.startsWith('#')) {
+            continue;
+        }
+        const diagnostic = parsePositionLine(line.replace(/^vet: /, ''), root, root, 'typecheck');
+        if (diagnostic) {
+            diagnostics.push(diagnostic);
+        }
+    }
+    return diagnostics;
+}
+function collectReport(report, root, diagnostics) {
+    for (const [pkg, analyzers] of Object.entries(report)) {
+        for (const [analyzer, findings] of Object.entries(analyzers)) {
+            if (!Array.isArray(findings)) {
+                continue;
+            }
+            for (const finding of findings) {
+                const position = /^(.*):(\d+):(\d+)$/.exec(finding.posn);
+                if (!position) {
+                    continue;
+                }
+                const edits = finding.suggested_fixes?.flatMap((fix) => fix.edits || []) || [];
+                diagnostics.push({
+                    file: relativeFile(position[1], root),
+                    line: Number(position[2]),
+                    column: Number(position[3]),
+                    linter: analyzer,
+                    message: finding.message,
+                    package: pkg,
+                    ...(edits.length > 0 ? { fix: edits.map((edit) => edit.new || '').join('\n') } : {})
+                });
+            }
+        }
+    }
+}
+/**
+ * Runs go vet on a target and returns analyzer findings and type errors
+ */
+export async function goVet(params) {
+    let target;
+    try {
+        target = resolveTarget(params.path);
+        const args = ['vet', '-json'];
+        for (const analyzer of params.analyzers || []) {
+            args.push(`-${analyzer}`);
+        }
+        args.push(target.pattern);
+        const timeout = params.timeout_seconds ?? 120;
+        const result = await runCommand('go', args, target.root, timeout);
+        if (result.timedOut) {
+            return buildResult('go_vet', target, [], 0, `go vet timed out after ${timeout}s`);
+        }
+        const diagnostics = parseVetOutput(result.stderr + result.stdout, target.root);
+        // A failing exit with nothing parsed is a setup problem (bad pattern,
+        // missing module), not a finding
+        if (result.code !== 0 && diagnostics.length === 0) {
+            return buildResult('go_vet', target, [], 0, `go vet exited with code ${result.code}: ${result.stderr.trim()}`);
+        }
+        return buildResult('go_vet', target, diagnostics, params.max_results ?? 100);
+    }
+    catch (error) {
+        return errorResult('go_vet', target, error);
+    }
+}
+//# sourceMappingURL=go-vet.js.map
\ No newline at end of file
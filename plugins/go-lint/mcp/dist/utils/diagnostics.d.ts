@@ -0,0 +1,130 @@
+import { z } from 'zod';
+import type { Target } from './target.js';
+export interface Diagnostic {
+    file: string;
+    line: number;
+    column: number;
+    linter: string;
+    message: string;
+    fix?: string;
+    package?: string;
+    test?: string;
+}
+export declare const diagnosticSchema: z.ZodObject<{
+    file: z.ZodString;
+    line: z.ZodNumber;
+    column: z.ZodNumber;
+    linter: z.ZodString;
+    message: z.ZodString;
+    fix: z.ZodOptional<z.ZodString>;
+    package: z.ZodOptional<z.ZodString>;
+    test: z.ZodOptional<z.ZodString>;
+}, "strip", z.ZodTypeAny, {
+    file: string;
+    line: number;
+    column: number;
+    linter: string;
+    message: string;
+    fix?: string | undefined;
+    package?: string | undefined;
+    test?: string | undefined;
+}, {
+    file: string;
+    line: number;
+    column: number;
+    linter: string;
+    message: string;
+    fix?: string | undefined;
+    package?: string | undefined;
+    test?: string | undefined;
+}>;
+export declare const resultSchema: {
+    tool: z.ZodString;
+    root: z.ZodString;
+    target: z.ZodString;
+    ok: z.ZodBoolean;
+    diagnostics: z.ZodArray<z.ZodObject<{
+        file: z.ZodString;
+        line: z.ZodNumber;
+        column: z.ZodNumber;
+        linter: z.ZodString;
+        message: z.ZodString;
+        fix: z.ZodOptional<z.ZodString>;
+        package: z.ZodOptional<z.ZodString>;
+        test: z.ZodOptional<z.ZodString>;
+    }, "strip", z.ZodTypeAny, {
+        file: string;
+        line: number;
+        column: number;
+        linter: string;
+        message: string;
+        fix?: string | undefined;
+        package?: string | undefined;
+        test?: string | undefined;
+    }, {
+        file: string;
+        line: number;
+        column: number;
+        linter: string;
+        message: string;
+        fix?: string | undefined;
+        package?: string | undefined;
+        test?: string | undefined;
+    }>, "many">;
+    omitted: z.ZodNumber;
+    summary: z.ZodOptional<z.ZodObject<{
+        passed: z.ZodNumber;
+        failed: z.ZodNumber;
+        skipped: z.ZodNumber;
+    }, "strip", z.ZodTypeAny, {
+        passed: number;
+        failed: number;
+        skipped: number;
+    }, {
+        passed: number;
+        failed: number;
+        skipped: number;
+    }>>;
+    written: z.ZodOptional<z.ZodBoolean>;
+    error: z.ZodOptional<z.ZodString>;
+};
+export interface ToolResult {
+    tool: string;
+    root: string;
+    target: string;
+    ok: boolean;
+    diagnostics: Diagnostic[];
+    omitted: number;
+    error?: string;
+    [key: string]: unknown;
+}
+/**
+ * Parses a compiler-style "file:line:col: message" line
+ */
+export declare function parsePositionLine(line: string, root: string, cwd: string, linter: string): Diagnostic | undefined;
+/**
+ * Makes a reported path relative to the project root
+ */
+export declare function relativeFile(file: string, root: string, cwd?: string): string;
+/**
+ * Sorts and caps diagnostics and wraps them as an MCP tool result with both
+ * text and structured content
+ */
+export declare function buildResult(tool: string, target: Target, diagnostics: Diagnostic[], maxResults: number, error?: string, extra?: Record<string, unknown>): {
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: ToolResult;
+};
+/**
+ * Builds a result for a command that could not run at all
+ */
+export declare function errorResult(tool: string, target: Target | undefined, error: unknown): {
+    content: {
+        type: "text";
+        text: string;
+    }[];
+    structuredContent: ToolResult;
+};
+//# sourceMappingURL=diagnostics.d.ts.map
\ No newline at end of file
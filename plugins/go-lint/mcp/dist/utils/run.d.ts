@@ -0,0 +1,13 @@
+export interface RunResult {
+    stdout: string;
+    stderr: string;
+    code: number;
+    timedOut: boolean;
+}
+/**
+ * Runs a command to completion and captures its output.
+ * Non-zero exit codes resolve normally (linters exit 1 on findings); only a
+ * missing binary rejects.
+ */
+export declare function runCommand(command: string, args: string[], cwd: string, timeoutSeconds: number): Promise<RunResult>;
+//# sourceMappingURL=run.d.ts.map
\ No newline at end of file
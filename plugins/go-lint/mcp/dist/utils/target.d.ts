@@ -0,0 +1,20 @@
+export interface Target {
+    /** Directory holding go.mod, go.work or .git */
+    root: string;
+    /** Package pattern relative to the root ("./...", "./pkg/..." or "./pkg") */
+    pattern: string;
+    /** Directory the pattern covers */
+    dir: string;
+}
+/**
+ * Finds the project root the same way go-lint-common.sh does: the nearest
+ * directory holding go.mod, go.work or .git
+ */
+export declare function findProjectRoot(start: string): string | undefined;
+/**
+ * Resolves a target path to its project root and go package pattern.
+ * Directories cover every package below them; a .go file covers only its
+ * own package.
+ */
+export declare function resolveTarget(target: string | undefined, cwd?: string): Target;
+//# sourceMappingURL=target.d.ts.map
\ No newline at end of file
@@ -0,0 +1,18 @@
+#!/usr/bin/env node
+
+import { startServer } from './server.js';
+
+/**
+ * Main entry point
+ */
+async function main() {
+  try {
+    // Start the MCP server
+    await startServer();
+  } catch (error) {
+    console.error('Failed to start go-lint MCP server:', error);
+    process.exit(1);
+  }
+}
+
+main();
@@ -0,0 +1,101 @@
+import { z } from 'zod';
+import { runCommand } from '../utils/run.js';
+import { resolveTarget, type Target } from '../utils/target.js';
+import { buildResult, errorResult, relativeFile, type Diagnostic } from '../utils/diagnostics.js';
+
+export const goLintSchema = {
+  path: z.string().optional().describe('Directory or .go file to lint (default: current directory)'),
+  fix: z.boolean().optional().describe('Let golangci-lint apply its fixes (default: false)'),
+  linters: z.array(z.string()).optional().describe('Only run these linters'),
+  max_results: z.number().int().positive().optional().describe('Maximum diagnostics to return (default: 100)'),
+  timeout_seconds: z.number().int().positive().optional().describe('Time limit (default: 300)')
+};
+
+export interface GoLintParams {
+  path?: string;
+  fix?: boolean;
+  linters?: string[];
+  max_results?: number;
+  timeout_seconds?: number;
+}
+
+interface GolangciIssue {
+  FromLinter: string;
+  Text: string;
+  Pos: { Filename: string; Line: number; Column: number };
+  Replacement?: { NeedOnlyDelete?: boolean; NewLines?: string[] | null } | null;
+  SuggestedFixes?: Array<{ Message?: string; TextEdits?: Array<{ NewText?: string }> }> | null;
+}
+
+/**
+ * Picks JSON output flags for the installed golangci-lint major version,
+ * matching _gol_build_golangci_output_args
+ */
+async function outputArgs(root: string): Promise<string[]> {
+  const version = await runCommand('golangci-lint', ['--version'], root, 30);
+  const match = /version\s+v?(\d+)\./.exec(version.stdout + version.stderr);
+  const major = match ? Number(match[1]) : 1;
+  return major >= 2 ? ['--output.json.path=stdout', '--show-stats=false'] : ['--out-format=json'];
+}
+
+function issueFix(issue: GolangciIssue): string | undefined {
+  if (issue.Replacement?.NeedOnlyDelete) {
+    return '(delete the line)';
+  }
+  if (issue.Replacement?.NewLines) {
+    return issue.Replacement.NewLines.join('\n');
+  }
+  const edits = issue.SuggestedFixes?.flatMap((fix) => fix.TextEdits || []) || [];
+  if (edits.length > 0) {
+    return edits.map((edit) => edit.NewText || '').join('\n');
+  }
+  return undefined;
+}
+
+/**
+ * Runs golangci-lint on a target and returns its issues as diagnostics
+ */
+export async function goLint(params: GoLintParams) {
+  let target: Target | undefined;
+  try {
+    target = resolveTarget(params.path);
+
+    const args = ['run', ...(await outputArgs(target.root))];
+    if (params.fix) {
+      args.push('--fix');
+    }
+    if (params.linters && params.linters.length > 0) {
+      args.push('--enable-only', params.linters.join(','));
+    }
+    args.push(target.pattern);
+
+    const result = await runCommand('golangci-lint', args, target.root, params.timeout_seconds ?? 300);
+    if (result.timedOut) {
+      return buildResult('go_lint', target, [], 0, `golangci-lint timed out after ${params.timeout_seconds ?? 300}s`);
+    }
+
+    // v2 prints the JSON report followed by a text summary on the same stream
+    const json = result.stdout.slice(0, result.stdout.lastIndexOf('}') + 1);
+    let report: { Issues?: GolangciIssue[] | null };
+    try {
+      report = JSON.parse(json);
+    } catch {
+      const output = (result.stderr || result.stdout).trim();
+      return buildResult('go_lint', target, [], 0, `golangci-lint exited with code ${result.code}: ${output}`);
+    }
+
+    const root = target.root;
+    const diagnostics: Diagnostic[] = (report.Issues || []).map((issue) => ({
+      file: relativeFile(issue.Pos.Filename, root),
+      line: issue.Pos.Line,
+      column: issue.Pos.Column,
+      linter: issue.FromLinter,
+      message: issue.Text,
+      ...(issueFix(issue) !== undefined ? { fix: issueFix(issue) } : {})
+    }));
+
+    return buildResult('go_lint', target, diagnostics, params.max_results ?? 100);
+  } catch (error) {
+    return errorResult('go_lint', target, error);
+  }
+}
@@ -0,0 +1,121 @@
+import { z } from 'zod';
+import { runCommand } from '../utils/run.js';
+import { resolveTarget, type Target } from '../utils/target.js';
+import { buildResult, errorResult, parsePositionLine, relativeFile, type Diagnostic } from '../utils/diagnostics.js';
+
+export const goVetSchema = {
+  path: z.string().optional().describe('Directory or .go file to vet (default: current directory)'),
+  analyzers: z.array(z.string()).optional().describe('Only run these vet analyzers, e.g. ["printf", "copylocks"]'),
+  max_results: z.number().int().positive().optional().describe('Maximum diagnostics to return (default: 100)'),
+  timeout_seconds: z.number().int().positive().optional().describe('Time limit (default: 120)')
+};
+
+export interface GoVetParams {
+  path?: string;
+  analyzers?: string[];
+  max_results?: number;
+  timeout_seconds?: number;
+}
+
+interface VetFinding {
+  posn: string;
+  message: string;
+  suggested_fixes?: Array<{ message?: string; edits?: Array<{ new?: string }> }>;
+}
+
+// go vet -json prints { "pkg": { "analyzer": [findings] } } objects
+type VetReport = Record<string, Record<string, VetFinding[] | { error: string }>>;
+
+/**
+ * Parses go vet -json output: pretty-printed JSON objects for analyzer
+ * findings, interleaved with "# pkg" headers and plain-text type errors
+ */
+export function parseVetOutput(output: string, root: string): Diagnostic[] {
+  const diagnostics: Diagnostic[] = [];
+  let buffer: string[] | undefined;
+
+  for (const line of output.split('\n')) {
+    if (buffer) {
+      buffer.push(line);
+      if (line === '}') {
+        collectReport(JSON.parse(buffer.join('\n')) as VetReport, root, diagnostics);
+        buffer = undefined;
+      }
+      continue;
+    }
+    if (line === '{') {
+      buffer = [line];
+      continue;
+    }
+    if (line.startsWith('#')) {
+      continue;
+    }
+
+    const diagnostic = parsePositionLine(line.replace(/^vet: /, ''), root, root, 'typecheck');
+    if (diagnostic) {
+      diagnostics.push(diagnostic);
+    }
+  }
+
+  return diagnostics;
+}
+
+function collectReport(report: VetReport, root: string, diagnostics: Diagnostic[]) {
+  for (const [pkg, analyzers] of Object.entries(report)) {
+    for (const [analyzer, findings] of Object.entries(analyzers)) {
+      if (!Array.isArray(findings)) {
+        continue;
+      }
+      for (const finding of findings) {
+        const position = /^(.*):(\d+):(\d+)$/.exec(finding.posn);
+        if (!position) {
+          continue;
+        }
+        const edits = finding.suggested_fixes?.flatMap((fix) => fix.edits || []) || [];
+        diagnostics.push({
+          file: relativeFile(position[1], root),
+          line: Number(position[2]),
+          column: Number(position[3]),
+          linter: analyzer,
+          message: finding.message,
+          package: pkg,
+          ...(edits.length > 0 ? { fix: edits.map((edit) => edit.new || '').join('\n') } : {})
+        });
+      }
+    }
+  }
+}
+
+/**
+ * Runs go vet on a target and returns analyzer findings and type errors
+ */
+export async function goVet(params: GoVetParams) {
+  let target: Target | undefined;
+  try {
+    target = resolveTarget(params.path);
+
+    const args = ['vet', '-json'];
+    for (const analyzer of params.analyzers || []) {
+      args.push(`-${analyzer}`);
+    }
+    args.push(target.pattern);
+
+    const timeout = params.timeout_seconds ?? 120;
+    const result = await runCommand('go', args, target.root, timeout);
+    if (result.timedOut) {
+      return buildResult('go_vet', target, [], 0, `go vet timed out after ${timeout}s`);
+    }
+
+    const diagnostics = parseVetOutput(result.stderr + result.stdout, target.root);
+
+    // A failing exit with nothing parsed is a setup problem (bad pattern,
+    // missing module), not a finding
+    if (result.code !== 0 && diagnostics.length === 0) {
+      return buildResult('go_vet', target, [], 0, `go vet exited with code ${result.code}: ${result.stderr.trim()}`);
+    }
+
+    return buildResult('go_vet', target, diagnostics, params.max_results ?? 100);
+  } catch (error) {
+    return errorResult('go_vet', target, error);
+  }
+}
@@ -0,0 +1,128 @@
+import path from 'path';
+import { z } from 'zod';
+import type { Target } from './target.js';
+
+export interface Diagnostic {
+  file: string;
+  line: number;
+  column: number;
+  linter: string;
+  message: string;
+  fix?: string;
+  package?: string;
+  test?: string;
+}
+
+export const diagnosticSchema = z.object({
+  file: z.string().describe('File path relative to the project root'),
+  line: z.number(),
+  column: z.number(),
+  linter: z.string().describe('Linter, analyzer or source of the diagnostic'),
+  message: z.string(),
+  fix: z.string().optional().describe('Suggested replacement text or diff, when available'),
+  package: z.string().optional(),
+  test: z.string().optional()
+});
+
+export const resultSchema = {
+  tool: z.string(),
+  root: z.string().describe('Project root the command ran in'),
+  target: z.string().describe('Package pattern or path that was checked'),
+  ok: z.boolean().describe('True when the command ran and reported nothing'),
+  diagnostics: z.array(diagnosticSchema),
+  omitted: z.number().describe('Diagnostics dropped by max_results'),
+  summary: z.object({
+    passed: z.number(),
+    failed: z.number(),
+    skipped: z.number()
+  }).optional().describe('Test counts (go_test only)'),
+  written: z.boolean().optional().describe('Whether files were rewritten (go_format only)'),
+  error: z.string().optional().describe('Why the command could not run or finish')
+};
+
+export interface ToolResult {
+  tool: string;
+  root: string;
+  target: string;
+  ok: boolean;
+  diagnostics: Diagnostic[];
+  omitted: number;
+  error?: string;
+  [key: string]: unknown;
+}
+
+// "path/file.go:12:3: message" as printed by the go toolchain
+const POSITION_LINE = /^(.+?\.go):(\d+)(?::(\d+))?: (.*)$/;
+
+/**
+ * Parses a compiler-style "file:line:col: message" line
+ */
+export function parsePositionLine(line: string, root: string, cwd: string, linter: string): Diagnostic | undefined {
+  const match = POSITION_LINE.exec(line.trim());
+  if (!match) {
+    return undefined;
+  }
+  return {
+    file: relativeFile(match[1], root, cwd),
+    line: Number(match[2]),
+    column: Number(match[3] || 0),
+    linter,
+    message: match[4]
+  };
+}
+
+/**
+ * Makes a reported path relative to the project root
+ */
+export function relativeFile(file: string, root: string, cwd: string = root): string {
+  const absolute = path.resolve(cwd, file);
+  const rel = path.relative(root, absolute);
+  return rel.startsWith('..') ? absolute : rel;
+}
+
+/**
+ * Sorts and caps diagnostics and wraps them as an MCP tool result with both
+ * text and structured content
+ */
+export function buildResult(
+  tool: string,
+  target: Target,
+  diagnostics: Diagnostic[],
+  maxResults: number,
+  error?: string,
+  extra: Record<string, unknown> = {}
+) {
+  const sorted = [...diagnostics].sort((a, b) =>
+    a.file.localeCompare(b.file) || a.line - b.line || a.column - b.column
+  );
+  const kept = sorted.slice(0, maxResults);
+
+  const result: ToolResult = {
+    tool,
+    root: target.root,
+    target: target.pattern,
+    ok: !error && sorted.length === 0,
+    diagnostics: kept,
+    omitted: sorted.length - kept.length,
+    ...extra
+  };
+  if (error) {
+    result.error = error;
+  }
+
+  return {
+    content: [{
+      type: 'text' as const,
+      text: JSON.stringify(result, null, 2)
+    }],
+    structuredContent: result
+  };
+}
+
+/**
+ * Builds a result for a command that could not run at all
+ */
+export function errorResult(tool: string, target: Target | undefined, error: unknown) {
+  const message = error instanceof Error ? error.message : String(error);
+  return buildResult(tool, target || { root: '', pattern: '', dir: '' }, [], 0, message);
+}
@@ -0,0 +1,58 @@
+import { spawn } from 'child_process';
+
+export interface RunResult {
+  stdout: string;
+  stderr: string;
+  code: number;
+  timedOut: boolean;
+}
+
+/**
+ * Runs a command to completion and captures its output.
+ * Non-zero exit codes resolve normally (linters exit 1 on findings); only a
+ * missing binary rejects.
+ */
+export async function runCommand(
+  command: string,
+  args: string[],
+  cwd: string,
+  timeoutSeconds: number
+): Promise<RunResult> {
+  return new Promise((resolve, reject) => {
+    const child = spawn(command, args, {
+      cwd,
+      stdio: ['ignore', 'pipe', 'pipe']
+    });
+
+    let stdout = '';
+    let stderr = '';
+    let timedOut = false;
+
+    const timer = setTimeout(() => {
+      timedOut = true;
+      child.kill('SIGKILL');
+    }, timeoutSeconds * 1000);
+
+    child.stdout.on('data', (data) => {
+      stdout += data.toString();
+    });
+
+    child.stderr.on('data', (data) => {
+      stderr += data.toString();
+    });
+
+    child.on('close', (code) => {
+      clearTimeout(timer);
+      resolve({ stdout, stderr, code: code ?? 1, timedOut });
+    });
+
+    child.on('error', (error: NodeJS.ErrnoException) => {
+      clearTimeout(timer);
+      if (error.code === 'ENOENT') {
+        reject(new Error(`${command} is not installed or not on PATH`));
+      } else {
+        reject(new Error(`Failed to spawn ${command}: ${error.message}`));
+      }
+    });
+  });
+}
@@ -0,0 +1,59 @@
+import { existsSync, statSync } from 'fs';
+import path from 'path';
+
+export interface Target {
+  /** Directory holding go.mod, go.work or .git */
+  root: string;
+  /** Package pattern relative to the root ("./...", "./pkg/..." or "./pkg") */
+  pattern: string;
+  /** Directory the pattern covers */
+  dir: string;
+}
+
+/**
+ * Finds the project root the same way go-lint-common.sh does: the nearest
+ * directory holding go.mod, go.work or .git
+ */
+export function findProjectRoot(start: string): string | undefined {
+  let dir = start;
+  while (dir !== path.dirname(dir)) {
+    for (const marker of ['go.mod', 'go.work', '.git']) {
+      if (existsSync(path.join(dir, marker))) {
+        return dir;
+      }
+    }
+    dir = path.dirname(dir);
+  }
+  return undefined;
+}
+
+/**
+ * Resolves a target path to its project root and go package pattern.
+ * Directories cover every package below them; a .go file covers only its
+ * own package.
+ */
+export function resolveTarget(target: string | undefined, cwd?: string): Target {
+  const base = cwd || process.cwd();
+  const absolute = path.resolve(base, target || '.');
+
+  if (!existsSync(absolute)) {
+    throw new Error(`Target does not exist: ${absolute}`);
+  }
+
+  const isFile = statSync(absolute).isFile();
+  const dir = isFile ? path.dirname(absolute) : absolute;
+  const root = findProjectRoot(dir);
+  if (!root) {
+    throw new Error(`Could not find Go project root (no go.mod, go.work, or .git found) from ${dir}`);
+  }
+
+  const rel = path.relative(root, dir);
+  let pattern: string;
+  if (rel === '') {
+    pattern = isFile ? '.' : './...';
+  } else {
+    pattern = isFile ? `./${rel}` : `./${rel}/...`;
+  }
+
+  return { root, pattern, dir };
+}
@@ -0,0 +1,166 @@
+#!/usr/bin/env bash
+# go-lint analyzer helper for go-lint plugin
+# This file should be sourced after go-lint-common.sh, not executed directly
+#
+# The analyzers live in a small stdlib-only Go module (../analyzers). The
+# binary is built on first use and cached per source checksum, so edits to the
+# analyzers rebuild it automatically.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+_GOL_ANALYZERS_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")/../analyzers" && pwd)"
+
+# Time limits (seconds) for building the analyzers, running them on a file,
+# and running them on a whole project
+_GOL_ANALYZERS_BUILD_TIMEOUT=20
+_GOL_ANALYZERS_RUN_TIMEOUT=10
+_GOL_ANALYZERS_PROJECT_TIMEOUT=60
+
+# Directory holding cached helper binaries
+# Returns: cache directory path
+_gol_cache_dir() {
+    echo "${GO_LINT_CACHE_DIR:-${XDG_CACHE_HOME:-$HOME/.cache}/go-lint}"
+}
+
+# Build the go-lint-analyze binary into its cache path
+# Args: binary path
+# Returns: 1 if the build failed
+_gol_build_analyzer_binary() {
+    local binary="${1}"
+
+    # Build to a temporary name so concurrent hooks never run a partial binary
+    local tmp_binary="$binary.tmp.$$"
+    (
+        cd "$_GOL_ANALYZERS_DIR" && GOWORK=off GOFLAGS=-mod=mod CGO_ENABLED=0 \
+            _gol_run_with_timeout "$_GOL_ANALYZERS_BUILD_TIMEOUT" go build -o "$tmp_binary" . >/dev/null 2>&1
+    ) || true
+
+    if [[ -x "$tmp_binary" ]] && mv -f "$tmp_binary" "$binary" 2>/dev/null; then
+        return 0
+    fi
+    rm -f "$tmp_binary"
+    return 1
+}
+
+# Build (or reuse) the go-lint-analyze binary
+# Args: build mode: "wait" (default) builds a missing binary before
+#       returning; "background" starts the build for later calls and returns
+#       without a binary, for the edit hook, which cannot fit a cold build in
+#       its time limit
+# Returns: binary path, or empty string if it is not available
+_gol_analyzer_binary() {
+    local mode="${1:-wait}"
+
+    local checksum
+    checksum=$(cat "$_GOL_ANALYZERS_DIR"/go.mod "$_GOL_ANALYZERS_DIR"/*.go 2>/dev/null | cksum | cut -d' ' -f1)
+
+    local cache_dir
+    cache_dir=$(_gol_cache_dir)
+    local binary="$cache_dir/go-lint-analyze-$checksum"
+
+    if [[ -x "$binary" ]]; then
+        echo "$binary"
+        return 0
+    fi
+
+    if ! command -v go &>/dev/null || ! mkdir -p "$cache_dir" 2>/dev/null; then
+        echo ""
+        return 1
+    fi
+
+    if [[ "$mode" == "background" ]]; then
+        # One build at a time; a lock left by a killed build expires
+        find "$binary.lock" -maxdepth 0 -mmin +2 -exec rmdir {} \; 2>/dev/null || true
+        if mkdir "$binary.lock" 2>/dev/null; then
+            (
+                _GOL_DEADLINE=""
+                _gol_build_analyzer_binary "$binary" || true
+                rmdir "$binary.lock"
+            ) </dev/null >/dev/null 2>&1 &
+        fi
+        echo ""
+        return 1
+    fi
+
+    if _gol_build_analyzer_binary "$binary"; then
+        echo "$binary"
+        return 0
+    fi
+    echo ""
+    return 1
+}
+
+# Run the go-lint analyzers on a file
+# Args: project_root, path relative to the project root, build mode (see
+#       _gol_analyzer_binary)
+# Returns: "path:line:col: message (check)" lines, or empty string
+_gol_run_analyzers() {
+    local project_root="${1}"
+    local rel_path="${2}"
+    local mode="${3:-wait}"
+
+    local binary
+    binary=$(_gol_analyzer_binary "$mode") || true
+    if [[ -z "$binary" ]]; then
+        return 0
+    fi
+
+    (
+        cd "$project_root" && _gol_run_with_timeout "$_GOL_ANALYZERS_RUN_TIMEOUT" \
+            "$binary" -config .go-lint.json "$rel_path" 2>/dev/null
+    ) || true
+}
+
+# Count the findings of checks with the "security" severity
+# Args: analyzer_output
+# Returns: count
+_gol_count_security_findings() {
+    echo "${1}" | grep -c '^[^:]*:[0-9]*:[0-9]*: security: ' || true
+}
+
+# Count the findings of checks with the "warning" severity
+# Args: analyzer_output
+# Returns: count
+_gol_count_warning_findings() {
+    echo "${1}" | grep -c '^[^:]*:[0-9]*:[0-9]*: warning: ' || true
+}
+
+# Run go-lint checks on every Go file under a directory
+# vendor, testdata and hidden or _-prefixed directories are skipped, as the
+# go command does
+# Args: project_root, rel_dir (relative to the project root), checks (names
+#       or packs, comma-separated)
+# Returns: "path:line:col: message (check)" lines, or empty string
+_gol_run_analyzer_checks() {
+    local project_root="${1}"
+    local rel_dir="${2}"
+    local checks="${3}"
+
+    local binary
+    binary=$(_gol_analyzer_binary) || true
+    if [[ -z "$binary" ]]; then
+        return 0
+    fi
+
+    local files=()
+    local file
+    while IFS= read -r file; do
+        files+=("${file#./}")
+    done < <(
+        cd "$project_root" && find "$rel_dir" \
+            \( -type d \( -name vendor -o -name testdata -o -name '.?*' -o -name '_*' \) -prune \) \
+            -o \( -type f -name '*.go' -print \) 2>/dev/null | sort
+    )
+    if [[ ${#files[@]} -eq 0 ]]; then
+        return 0
+    fi
+
+    (
+        cd "$project_root" && _gol_run_with_timeout "$_GOL_ANALYZERS_PROJECT_TIMEOUT" \
+            "$binary" -config .go-lint.json -checks "$checks" "${files[@]}" 2>/dev/null
+    ) || true
+}
@@ -0,0 +1,366 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Binary size and dependency weight report for Go main packages
+# Usage: go-lint-binsize.sh [directory] [--base <ref>]
+#
+# Builds every main package under the target, attributes symbol sizes from
+# `go tool nm -size` to packages and modules, and compares both against the
+# same packages built from a git base ref (default HEAD).
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+
+# Entries listed per binary for modules and packages
+BINSIZE_MAX_MODULES=10
+BINSIZE_MAX_PACKAGES=10
+
+# New dependencies at least this large (bytes) are flagged as heavy
+BINSIZE_HEAVY_BYTES=102400
+
+# Parse arguments
+TARGET_DIR="."
+BASE_REF="HEAD"
+while [[ $# -gt 0 ]]; do
+    case "$1" in
+        --base)
+            BASE_REF="${2:-HEAD}"
+            shift
+            ;;
+        --base=*) BASE_REF="${1#--base=}" ;;
+        *) TARGET_DIR="$1" ;;
+    esac
+    shift
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Binary Size Report Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go; then
+    echo "## Go Binary Size Report Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Binary Size Report Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+BUILD_TARGET="$_gol_PACKAGE_PATTERN"
+
+WORK_DIR=$(mktemp -d)
+trap 'rm -rf "$WORK_DIR"' EXIT
+
+# Format a byte count for humans
+# Args: bytes
+human_size() {
+    awk -v n="${1}" 'BEGIN {
+        if (n < 1024) printf "%d B", n
+        else if (n < 1048576) printf "%.1f KB", n / 1024
+        else printf "%.1f MB", n / 1048576
+    }'
+}
+
+# Format a size change, e.g. "+1.2 KB" or "-300 B"
+# Args: delta_bytes
+human_delta() {
+    local delta="${1}"
+    if [[ "$delta" -lt 0 ]]; then
+        echo "-$(human_size $((-delta)))"
+    else
+        echo "+$(human_size "$delta")"
+    fi
+}
+
+# Build a main package and attribute its symbols to packages and modules
+# Args: project_root, import_path, output_prefix
+# Writes: <prefix>.size (bytes), <prefix>.modules and <prefix>.packages
+#         ("name<TAB>bytes", largest first), <prefix>.log (build output)
+# Returns: 1 if the package does not build
+measure_binary() {
+    local root="${1}"
+    local import_path="${2}"
+    local prefix="${3}"
+
+    if ! (cd "$root" && go build -o "$prefix.bin" "$import_path") > "$prefix.log" 2>&1; then
+        return 1
+    fi
+    wc -c < "$prefix.bin" | tr -d ' ' > "$prefix.size"
+
+    # Map every linked package to its module ("std" for the standard library)
+    (
+        cd "$root" && go list -deps -f '{{.ImportPath}}{{"\t"}}{{with .Module}}{{.Path}}{{else}}std{{end}}' "$import_path"
+    ) > "$prefix.deps" 2>/dev/null || true
+
+    # Symbol names look like "example.com/mod/pkg.(*T).Method"; the package is
+    # everything up to the first dot after the last slash, with dots in the
+    # last path element escaped as %2e. Type and runtime metadata ("type:*",
+    # "go:...") cannot be attributed and are grouped separately.
+    (cd "$root" && go tool nm -size "$prefix.bin") 2>/dev/null | awk -F'\t' -v main_pkg="$import_path" \
+        -v modules="$prefix.modules.raw" -v packages="$prefix.packages.raw" '
+        FILENAME == ARGV[1] {
+            module_of[$1] = $2
+            next
+        }
+
+        {
+            if (!match($0, /^ *[0-9a-f]* +[0-9]+ [A-Za-z] /)) {
+                next
+            }
+            header = substr($0, 1, RLENGTH)
+            name = substr($0, RLENGTH + 1)
+            count = split(header, parts, " ")
+            size = parts[count - 1]
+            if (size == 0 || parts[count] == "U") {
+                next
+            }
+
+            if (name ~ /^(go|type):/) {
+                pkg = "(go metadata)"
+                module = pkg
+            } else {
+                prefix = name
+                sub(/[( \[].*$/, "", prefix)
+                slash = 0
+                for (i = length(prefix); i > 0; i--) {
+                    if (substr(prefix, i, 1) == "/") {
+                        slash = i
+                        break
+                    }
+                }
+                rest = substr(prefix, slash + 1)
+                dot = index(rest, ".")
+                pkg = substr(prefix, 1, slash) (dot ? substr(rest, 1, dot - 1) : rest)
+                gsub(/%2e/, ".", pkg)
+                if (pkg == "main") {
+                    pkg = main_pkg
+                }
+
+                if (pkg in module_of) {
+                    module = module_of[pkg]
+                } else if (pkg !~ /^[^\/]*\./) {
+                    module = "std"
+                } else {
+                    module = "(other)"
+                }
+            }
+
+            package_bytes[pkg] += size
+            module_bytes[module] += size
+        }
+
+        END {
+            for (pkg in package_bytes) {
+                printf "%s\t%d\n", pkg, package_bytes[pkg] > packages
+            }
+            for (module in module_bytes) {
+                printf "%s\t%d\n", module, module_bytes[module] > modules
+            }
+        }
+    ' "$prefix.deps" -
+
+    sort -t$'\t' -k2,2nr -k1,1 "$prefix.modules.raw" > "$prefix.modules" 2>/dev/null || : > "$prefix.modules"
+    sort -t$'\t' -k2,2nr -k1,1 "$prefix.packages.raw" > "$prefix.packages" 2>/dev/null || : > "$prefix.packages"
+    rm -f "$prefix.bin" "$prefix.modules.raw" "$prefix.packages.raw"
+    return 0
+}
+
+# Print the largest entries of a weights file with deltas against the base
+# Args: head_weights, base_weights (may not exist), max_entries
+print_weights() {
+    local head_file="${1}"
+    local base_file="${2}"
+    local max="${3}"
+
+    local name bytes base_bytes note
+    head -n "$max" "$head_file" | while IFS=$'\t' read -r name bytes; do
+        note=""
+        if [[ -f "$base_file" ]]; then
+            base_bytes=$(awk -F'\t' -v name="$name" '$1 == name { print $2 }' "$base_file")
+            if [[ -z "$base_bytes" ]]; then
+                note=" (new)"
+            elif [[ "$bytes" -ne "$base_bytes" ]]; then
+                note=" ($(human_delta $((bytes - base_bytes))))"
+            fi
+        fi
+        echo "- $name: $(human_size "$bytes")$note"
+    done
+}
+
+# Find main packages under the target
+MAIN_PACKAGES=$(
+    cd "$PROJECT_ROOT" && go list -f '{{if eq .Name "main"}}{{.ImportPath}}{{end}}' "$BUILD_TARGET" 2>"$WORK_DIR/list.log"
+) || {
+    echo "## Go Binary Size Report Failed"
+    echo ""
+    echo "go list failed:"
+    echo '```'
+    cat "$WORK_DIR/list.log"
+    echo '```'
+    exit 1
+}
+MAIN_PACKAGES=$(echo "$MAIN_PACKAGES" | grep . || true)
+
+if [[ -z "$MAIN_PACKAGES" ]]; then
+    echo "## Go Binary Size Report"
+    echo ""
+    echo "No main packages found."
+    echo ""
+    echo "**Target:** $BUILD_TARGET"
+    echo "**Project root:** $PROJECT_ROOT"
+    exit 0
+fi
+
+# Export the base ref into a scratch tree; the same project root inside it is
+# where the base binaries are built
+BASE_ROOT=""
+BASE_NOTE=""
+if ! command -v git &>/dev/null || ! git -C "$PROJECT_ROOT" rev-parse --is-inside-work-tree &>/dev/null; then
+    BASE_NOTE="not a git repository, no base to compare against"
+elif ! BASE_COMMIT=$(git -C "$PROJECT_ROOT" rev-parse --short --verify --quiet "$BASE_REF^{commit}"); then
+    BASE_NOTE="unknown git ref: $BASE_REF"
+else
+    PROJECT_PREFIX=$(git -C "$PROJECT_ROOT" rev-parse --show-prefix)
+    mkdir -p "$WORK_DIR/base"
+    if git -C "$PROJECT_ROOT" archive --format=tar "$BASE_COMMIT" | tar -xf - -C "$WORK_DIR/base" 2>/dev/null; then
+        BASE_ROOT="$WORK_DIR/base/$PROJECT_PREFIX"
+        BASE_NOTE="$BASE_REF ($BASE_COMMIT)"
+    else
+        BASE_NOTE="could not export $BASE_REF"
+    fi
+fi
+
+# Build and measure every binary
+TOTAL_SIZE=0
+TOTAL_BASE=0
+BINARY_COUNT=0
+FAILED=()
+FAILED_LOGS=()
+index=0
+while IFS= read -r pkg; do
+    index=$((index + 1))
+    if ! measure_binary "$PROJECT_ROOT" "$pkg" "$WORK_DIR/head-$index"; then
+        FAILED+=("$pkg")
+        FAILED_LOGS+=("$WORK_DIR/head-$index.log")
+        continue
+    fi
+    BINARY_COUNT=$((BINARY_COUNT + 1))
+    TOTAL_SIZE=$((TOTAL_SIZE + $(cat "$WORK_DIR/head-$index.size")))
+
+    if [[ -n "$BASE_ROOT" && -d "$BASE_ROOT" ]] \
+        && measure_binary "$BASE_ROOT" "$pkg" "$WORK_DIR/base-$index"; then
+        TOTAL_BASE=$((TOTAL_BASE + $(cat "$WORK_DIR/base-$index.size")))
+    fi
+done <<< "$MAIN_PACKAGES"
+
+if [[ $BINARY_COUNT -eq 0 ]]; then
+    echo "## Go Binary Size Report Failed"
+    echo ""
+    echo "No main package could be built:"
+    echo '```'
+    cat "$WORK_DIR"/head-*.log
+    echo '```'
+    exit 1
+fi
+
+# Generate markdown report
+echo "## Go Binary Size Report"
+echo ""
+echo "**Target:** $BUILD_TARGET"
+echo "**Project root:** $PROJECT_ROOT"
+echo "**Base:** $BASE_NOTE"
+echo ""
+echo "### Summary"
+echo ""
+echo "- **Binaries:** $BINARY_COUNT"
+if [[ $TOTAL_BASE -gt 0 ]]; then
+    echo "- **Total size:** $(human_size $TOTAL_SIZE) ($(human_delta $((TOTAL_SIZE - TOTAL_BASE))))"
+else
+    echo "- **Total size:** $(human_size $TOTAL_SIZE)"
+fi
+if [[ ${#FAILED[@]} -gt 0 ]]; then
+    echo "- **Build failures:** ${FAILED[*]}"
+fi
+echo ""
+
+# New modules across all binaries, largest first
+NEW_DEPS=""
+index=0
+while IFS= read -r pkg; do
+    index=$((index + 1))
+    head_prefix="$WORK_DIR/head-$index"
+    base_prefix="$WORK_DIR/base-$index"
+    if [[ ! -f "$head_prefix.size" ]]; then
+        continue
+    fi
+
+    size=$(cat "$head_prefix.size")
+    echo "### $pkg"
+    echo ""
+    if [[ -f "$base_prefix.size" ]]; then
+        base_size=$(cat "$base_prefix.size")
+        echo "**Size:** $(human_size "$size") ($(human_delta $((size - base_size))) from $(human_size "$base_size"))"
+    elif [[ -n "$BASE_ROOT" ]]; then
+        echo "**Size:** $(human_size "$size") (not buildable at base)"
+    else
+        echo "**Size:** $(human_size "$size")"
+    fi
+    echo ""
+    echo "**Modules:**"
+    echo ""
+    print_weights "$head_prefix.modules" "$base_prefix.modules" "$BINSIZE_MAX_MODULES"
+    echo ""
+    echo "**Packages:**"
+    echo ""
+    print_weights "$head_prefix.packages" "$base_prefix.packages" "$BINSIZE_MAX_PACKAGES"
+    echo ""
+
+    if [[ -f "$base_prefix.modules" ]]; then
+        NEW_DEPS+=$(awk -F'\t' -v pkg="$pkg" '
+            FILENAME == ARGV[1] { in_base[$1] = 1; next }
+            !($1 in in_base) && $1 !~ /^\(/ { printf "%s\t%s\t%d\n", $1, pkg, $2 }
+        ' "$base_prefix.modules" "$head_prefix.modules")$'\n'
+    fi
+done <<< "$MAIN_PACKAGES"
+
+NEW_DEPS=$(echo "$NEW_DEPS" | grep . | sort -t$'\t' -k3,3nr -k1,1 || true)
+if [[ -n "$NEW_DEPS" ]]; then
+    echo "### New Dependencies"
+    echo ""
+    while IFS=$'\t' read -r module pkg bytes; do
+        if [[ "$bytes" -ge $BINSIZE_HEAVY_BYTES ]]; then
+            echo "- **$module** adds $(human_size "$bytes") to $pkg (heavy)"
+        else
+            echo "- $module adds $(human_size "$bytes") to $pkg"
+        fi
+    done <<< "$NEW_DEPS"
+    echo ""
+fi
+
+if [[ ${#FAILED[@]} -gt 0 ]]; then
+    echo "### Build Failures"
+    echo ""
+    echo '```'
+    cat "${FAILED_LOGS[@]}"
+    echo '```'
+    exit 1
+fi
+
+exit 0
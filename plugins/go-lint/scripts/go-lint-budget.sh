@@ -0,0 +1,238 @@
+#!/usr/bin/env bash
+# Output budget for go-lint hook diagnostics
+# This file should be sourced after go-lint-common.sh and go-lint-analyzers.sh,
+# not executed directly
+#
+# One edit can break a whole package and produce hundreds of type errors. When
+# the diagnostics for a file exceed the budget, the hook reports the most
+# useful ones (type errors before vet findings and security findings before
+# other go-lint analyzer findings, then closest to the edited lines first),
+# folds repeated messages into one line, and writes the full output to a log
+# file whose path it includes instead.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Default budget in bytes (roughly 1000 tokens)
+_GOL_BUDGET_DEFAULT=4000
+
+# Full logs kept in the cache directory
+_GOL_BUDGET_MAX_LOGS=20
+
+# Output budget for a project
+# GO_LINT_OUTPUT_BUDGET takes precedence over output.budget in .go-lint.json
+# Args: project_root (may be empty)
+# Returns: budget in bytes
+_gol_output_budget() {
+    local budget="${GO_LINT_OUTPUT_BUDGET:-}"
+    if [[ -z "$budget" && -n "${1}" ]]; then
+        budget=$(_gol_project_config "${1}" '.output.budget' "")
+    fi
+    if [[ "$budget" =~ ^[1-9][0-9]*$ ]]; then
+        echo "$budget"
+    else
+        echo "$_GOL_BUDGET_DEFAULT"
+    fi
+}
+
+# Lines touched by an Edit, MultiEdit or Write, from the line numbers of the
+# patch in the tool response. Without a patch, every line of each new text is
+# matched in sequence against the file (after goimports has formatted it).
+# All the edits of a MultiEdit are covered
+# Args: hook_input (JSON), file_path
+# Returns: "start<TAB>end", or empty string when the edited lines cannot be
+#          placed (a Write that created the file, or new text not found)
+_gol_edit_region() {
+    local input="${1}"
+    local file_path="${2}"
+
+    # "+" lines are new lines, and "-" lines mark where lines were removed
+    local region
+    region=$(echo "$input" | jq -r '
+        [.tool_response | objects | .structuredPatch // [] | .[]
+         | reduce .lines[] as $line ({n: .newStart, at: []};
+             if ($line | startswith("+")) then .at += [.n] | .n += 1
+             elif ($line | startswith("-")) then .at += [.n]
+             elif ($line | startswith("\\")) then .
+             else .n += 1
+             end)
+         | .at[]]
+        | if length > 0 then "\(min)\t\(max)" else empty end
+    ' 2>/dev/null || true)
+    if [[ -n "$region" ]]; then
+        echo "$region"
+        return 0
+    fi
+
+    local new_strings
+    new_strings=$(echo "$input" | jq -c '.tool_input | .new_string // empty, (.edits // [] | .[].new_string)' 2>/dev/null || true)
+    if [[ -z "$new_strings" ]]; then
+        return 0
+    fi
+
+    local new_string start end
+    while IFS= read -r new_string; do
+        region=$(echo "$new_string" | jq -r '.' | _gol_find_lines "$file_path")
+        if [[ -z "$region" ]]; then
+            continue
+        fi
+        if [[ -z "${start:-}" || ${region%%$'\t'*} -lt $start ]]; then
+            start=${region%%$'\t'*}
+        fi
+        if [[ -z "${end:-}" || ${region##*$'\t'} -gt $end ]]; then
+            end=${region##*$'\t'}
+        fi
+    done <<< "$new_strings"
+
+    if [[ -n "${start:-}" ]]; then
+        printf '%s\t%s\n' "$start" "$end"
+    fi
+}
+
+# Find where text on stdin appears in a file, comparing non-blank lines with
+# surrounding whitespace trimmed, all of them in sequence
+# Args: file_path
+# Returns: "start<TAB>end", or empty string unless the text appears exactly
+#          once (a lone "}" or "return nil, err" cannot be placed)
+_gol_find_lines() {
+    awk '
+        function trim(s) { sub(/^[ \t]+/, "", s); sub(/[ \t]+$/, "", s); return s }
+        FNR == NR { if (trim($0) != "") needle[++count] = trim($0); next }
+        trim($0) != "" { line[++lines] = trim($0); number[lines] = FNR }
+        END {
+            if (count == 0) exit
+            for (i = 1; i + count - 1 <= lines; i++) {
+                for (j = 1; j <= count && line[i + j - 1] == needle[j]; j++) {}
+                if (j > count) {
+                    matches++
+                    region = number[i] "\t" number[i + count - 1]
+                }
+            }
+            if (matches == 1) print region
+        }
+    ' - "${1}"
+}
+
+# Write diagnostics to a log file in the cache directory, keeping only the
+# newest _GOL_BUDGET_MAX_LOGS logs
+# Args: name (used in the file name), text
+# Returns: log path, or empty string if it cannot be written
+_gol_budget_write_log() {
+    local name="${1}"
+    local text="${2}"
+    local log_dir
+    log_dir="$(_gol_cache_dir)/logs"
+
+    if ! mkdir -p "$log_dir" 2>/dev/null; then
+        return 0
+    fi
+
+    # Timestamps first, so names sort by age
+    local log_file
+    log_file="$log_dir/$(date +%Y%m%d-%H%M%S)-$$-$name.log"
+    if ! printf '%s\n' "$text" > "$log_file" 2>/dev/null; then
+        return 0
+    fi
+
+    find "$log_dir" -maxdepth 1 -name '*.log' | sort -r \
+        | awk -v keep="$_GOL_BUDGET_MAX_LOGS" 'NR > keep' | while IFS= read -r old; do
+        rm -f "$old"
+    done
+    echo "$log_file"
+}
+
+# Fit a file's diagnostics into the output budget
+# Output within the budget is returned unchanged. Otherwise diagnostics are
+# ordered by severity (type errors, vet and security findings, analyzer
+# findings) and then by distance from the edited lines, repeats of a message
+# are folded into the first one, and the full output goes to a log file.
+# Args: vet_issues, analyzer_issues, budget (bytes), log_name,
+#       edit_start, edit_end (optional)
+# Returns: diagnostics text
+_gol_budget_diagnostics() {
+    local vet_issues="${1}"
+    local analyzer_issues="${2}"
+    local budget="${3}"
+    local log_name="${4}"
+    local edit_start="${5:-}"
+    local edit_end="${6:-}"
+
+    local issues
+    issues=$(printf '%s\n%s' "$vet_issues" "$analyzer_issues" | grep -v '^$' || true)
+    if [[ ${#issues} -le $budget ]]; then
+        echo "$issues"
+        return 0
+    fi
+
+    local total log_file
+    total=$(echo "$issues" | grep -c "^" || true)
+    log_file=$(_gol_budget_write_log "$log_name" "$issues")
+
+    # "severity<TAB>distance<TAB>line<TAB>order<TAB>message<TAB>diagnostic"
+    {
+        echo "$vet_issues" | awk '
+            NF { print (index($0, "vet: ") == 1 ? 0 : 1) "\t" $0 }
+        '
+        echo "$analyzer_issues" | awk '
+            NF { print ($0 ~ /^[^:]*:[0-9]+:[0-9]+: security: / ? 1 : 2) "\t" $0 }
+        '
+    } | awk -F'\t' -v start="$edit_start" -v end="$edit_end" '
+        {
+            severity = $1
+            diagnostic = substr($0, 3)
+            location = diagnostic
+            sub(/^vet: /, "", location)
+            line = 0
+            message = location
+            if (match(location, /^[^:]+:[0-9]+(:[0-9]+)?: /)) {
+                message = substr(location, RLENGTH + 1)
+                split(location, parts, ":")
+                line = parts[2] + 0
+            }
+            distance = 0
+            if (start != "" && line < start) distance = start - line
+            if (end != "" && line > end) distance = line - end
+            print severity "\t" distance "\t" line "\t" NR "\t" message "\t" diagnostic
+        }
+    ' | sort -t$'\t' -k1,1n -k2,2n -k3,3n -k4,4n | awk -F'\t' \
+        -v budget="$budget" -v total="$total" -v log_file="$log_file" '
+        {
+            message = $5
+            if (message in repeats) {
+                repeats[message]++
+                next
+            }
+            repeats[message] = 0
+            order[++count] = message
+            entry = $6
+            for (i = 7; i <= NF; i++) entry = entry "\t" $i
+            diagnostic[message] = entry
+        }
+        END {
+            # Leave room for the trailer
+            limit = budget - 200
+            used = 0
+            shown = 0
+            for (i = 1; i <= count; i++) {
+                message = order[i]
+                entry = diagnostic[message]
+                if (repeats[message] > 0) {
+                    entry = entry "\n  (" repeats[message] " more of: " message ")"
+                }
+                if (i > 1 && used + length(entry) + 1 > limit) break
+                print entry
+                used += length(entry) + 1
+                shown += 1 + repeats[message]
+            }
+            if (shown < total) {
+                print "... " (total - shown) " more issue(s) not shown"
+            }
+            if (log_file != "") {
+                print "Full output (" total " issues): " log_file
+            }
+        }
+    '
+}
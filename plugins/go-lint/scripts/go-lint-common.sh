@@ -69,6 +69,55 @@ _gol_check_required_tools() {
     return 0
 }
 
+# Deadline (in $SECONDS) shared by every stage of a hook run; empty for none
+_GOL_DEADLINE=""
+
+# Start a deadline for the rest of the run, so stages with their own time
+# limits cannot add up past the hook's timeout
+# Args: seconds
+_gol_start_deadline() {
+    _GOL_DEADLINE=$((SECONDS + ${1}))
+}
+
+# Cap a time limit by what is left before the deadline
+# Args: seconds
+# Returns: the smaller of seconds and the time left (0 once it has passed)
+_gol_deadline_limit() {
+    local seconds="${1}"
+
+    if [[ -n "$_GOL_DEADLINE" ]]; then
+        local remaining=$((_GOL_DEADLINE - SECONDS))
+        if [[ $remaining -lt 0 ]]; then
+            remaining=0
+        fi
+        if [[ $remaining -lt $seconds ]]; then
+            seconds=$remaining
+        fi
+    fi
+    echo "$seconds"
+}
+
+# Run a command with a time limit when `timeout` is available
+# The limit is capped by the deadline; once it has passed the command is not
+# run at all
+# Args: seconds, command...
+# Returns: command exit code (124 if the limit was hit)
+_gol_run_with_timeout() {
+    local seconds
+    seconds=$(_gol_deadline_limit "${1}")
+    shift
+
+    if [[ $seconds -le 0 ]]; then
+        return 124
+    fi
+
+    if command -v timeout &>/dev/null; then
+        timeout "${seconds}s" "$@"
+    else
+        "$@"
+    fi
+}
+
 # Find Go project root by walking up directory tree
 # Args: start_dir
 # Returns: project root path or empty string
@@ -118,14 +167,36 @@ _gol_get_relative_path() {
     fi
 
     # Try using Python if available
+    # Paths are passed as arguments so quotes and spaces survive intact
     if command -v python3 &>/dev/null; then
-        python3 -c "import os.path; print(os.path.relpath('$target', '$base'))" 2>/dev/null && return 0
+        python3 -c 'import os.path, sys; print(os.path.relpath(sys.argv[1], sys.argv[2]))' \
+            "$target" "$base" 2>/dev/null && return 0
     fi
 
     # Fallback: just return the target path
     echo "$target"
 }
 
+# Filter go vet output down to diagnostics for a single file
+# go vet prefixes paths with "./" on older toolchains and for type errors
+# (which are also prefixed with "vet: "), but not for analyzer findings
+# on Go 1.24+, so both forms are accepted
+# Args: vet_output, rel_path (relative to the directory go vet ran in)
+# Returns: matching lines, unmodified
+_gol_filter_vet_output() {
+    local vet_output="${1}"
+    local rel_path="${2#./}"
+    local line stripped
+
+    while IFS= read -r line; do
+        stripped="${line#vet: }"
+        stripped="${stripped#./}"
+        if [[ "$stripped" == "$rel_path:"* ]]; then
+            printf '%s\n' "$line"
+        fi
+    done <<< "$vet_output"
+}
+
 # Get absolute path
 # Args: path
 # Returns: absolute path
@@ -139,7 +210,7 @@ _gol_get_absolute_path() {
 
     # Try using Python if available
     if command -v python3 &>/dev/null; then
-        python3 -c "import os.path; print(os.path.abspath('$path'))" 2>/dev/null && return 0
+        python3 -c 'import os.path, sys; print(os.path.abspath(sys.argv[1]))' "$path" 2>/dev/null && return 0
     fi
 
     # Fallback: use cd and pwd
@@ -152,6 +223,32 @@ _gol_get_absolute_path() {
     fi
 }
 
+# Resolve a target directory to its project root and Go package pattern
+# Args: target_dir (absolute)
+# Sets: _gol_PROJECT_ROOT, _gol_REL_TARGET ("." for the root) and
+#       _gol_PACKAGE_PATTERN ("./..." or "./rel/...")
+# Returns: 1 if no project root is found
+_gol_resolve_target() {
+    local target_dir="${1}"
+
+    _gol_PROJECT_ROOT=$(_gol_find_project_root "$target_dir" || true)
+    _gol_REL_TARGET=""
+    _gol_PACKAGE_PATTERN=""
+
+    if [[ -z "$_gol_PROJECT_ROOT" ]]; then
+        return 1
+    fi
+
+    _gol_REL_TARGET=$(_gol_get_relative_path "$_gol_PROJECT_ROOT" "$target_dir")
+    if [[ "$_gol_REL_TARGET" == "." || "$_gol_REL_TARGET" == "$target_dir" ]]; then
+        _gol_REL_TARGET="."
+        _gol_PACKAGE_PATTERN="./..."
+    else
+        _gol_PACKAGE_PATTERN="./$_gol_REL_TARGET/..."
+    fi
+    return 0
+}
+
 # Detect golangci-lint config file
 # Args: project_root
 # Returns: config file path or empty string
@@ -162,6 +259,7 @@ _gol_detect_golangci_config() {
         ".golangci.yml"
         ".golangci.yaml"
         ".golangci.json"
+        ".golangci.toml"
     )
 
     for config_file in "${config_files[@]}"; do
@@ -175,6 +273,33 @@ _gol_detect_golangci_config() {
     return 1
 }
 
+# Detect the major version of the installed golangci-lint
+# Returns: major version number (defaults to 1 if it cannot be parsed)
+_gol_golangci_major_version() {
+    local version_output
+    version_output=$(golangci-lint --version 2>/dev/null || echo "")
+
+    if [[ "$version_output" =~ version[[:space:]]+v?([0-9]+)\. ]]; then
+        echo "${BASH_REMATCH[1]}"
+    else
+        echo "1"
+    fi
+}
+
+# Build golangci-lint JSON output arguments
+# v2 removed --out-format in favour of per-format output paths
+# Args: major_version
+# Returns: output arguments string
+_gol_build_golangci_output_args() {
+    local major_version="${1:-1}"
+
+    if [[ "$major_version" -ge 2 ]]; then
+        echo "--output.json.path=stdout --show-stats=false"
+    else
+        echo "--out-format=json"
+    fi
+}
+
 # Build golangci-lint config arguments
 # Args: project_root
 # Returns: config arguments string
@@ -190,3 +315,25 @@ _gol_build_golangci_config_args() {
         echo ""
     fi
 }
+
+# Read a setting from the project's .go-lint.json
+# Args: project_root, jq_filter, default
+# Returns: the value (strings raw, arrays/objects as compact JSON), or default
+#          when the file, the key or jq is missing
+_gol_project_config() {
+    local project_root="${1}"
+    local filter="${2}"
+    local default="${3:-}"
+    local config_file="$project_root/.go-lint.json"
+
+    local value=""
+    if [[ -f "$config_file" ]] && command -v jq &>/dev/null; then
+        value=$(jq -rc "($filter) | if . == null then empty else . end" "$config_file" 2>/dev/null || echo "")
+    fi
+
+    if [[ -n "$value" ]]; then
+        echo "$value"
+    else
+        echo "$default"
+    fi
+}
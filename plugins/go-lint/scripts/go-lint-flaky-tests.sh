@@ -0,0 +1,286 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Detect flaky Go tests by running them repeatedly
+# Usage: go-lint-flaky-tests.sh [directory] [--count N] [--cpu 1,2,4] [--race] [--run regexp]
+#
+# Runs the tests under the target with go test -json -count=N -shuffle=on and
+# each GOMAXPROCS value in --cpu (optionally with -race), and reports tests
+# that both passed and failed, with the failure message of every failed run.
+# Flaky tests are recorded in .go-lint-flaky.json (see go-lint-flaky.sh).
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-gocmd.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-flaky.sh"
+
+# Time limit for all runs (seconds) and failed runs listed per test
+FLAKY_TIMEOUT="${GO_LINT_FLAKY_TIMEOUT:-900}"
+FLAKY_MAX_FAILURES=5
+
+# Parse arguments
+TARGET_DIR="."
+COUNT=5
+CPU_LIST="1,2,4"
+RACE=false
+RUN_PATTERN=""
+while [[ $# -gt 0 ]]; do
+    case "$1" in
+        --count)
+            COUNT="${2:-5}"
+            shift
+            ;;
+        --count=*) COUNT="${1#--count=}" ;;
+        --cpu)
+            CPU_LIST="${2:-1,2,4}"
+            shift
+            ;;
+        --cpu=*) CPU_LIST="${1#--cpu=}" ;;
+        --race) RACE=true ;;
+        --run)
+            RUN_PATTERN="${2:-}"
+            shift
+            ;;
+        --run=*) RUN_PATTERN="${1#--run=}" ;;
+        *) TARGET_DIR="$1" ;;
+    esac
+    shift
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+if ! [[ "$COUNT" =~ ^[1-9][0-9]*$ && "$CPU_LIST" =~ ^[1-9][0-9]*(,[1-9][0-9]*)*$ ]]; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    echo "--count needs a positive number and --cpu a comma-separated list of GOMAXPROCS values (got --count $COUNT --cpu $CPU_LIST)."
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go jq; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+TEST_TARGET="$_gol_PACKAGE_PATTERN"
+
+TEST_FLAGS=(-json "-count=$COUNT" "-cpu=$CPU_LIST" -shuffle=on)
+if [[ "$RACE" == true ]]; then
+    TEST_FLAGS+=(-race)
+fi
+if [[ -n "$RUN_PATTERN" ]]; then
+    TEST_FLAGS+=(-run "$RUN_PATTERN")
+fi
+CPU_COUNT=$(echo "$CPU_LIST" | tr ',' '\n' | grep -c .)
+RUNS_PER_TEST=$((COUNT * CPU_COUNT))
+
+TEST_EXIT=0
+TEST_OUTPUT=$(
+    cd "$PROJECT_ROOT" && _gol_run_with_timeout "$FLAKY_TIMEOUT" go test "${TEST_FLAGS[@]}" "$TEST_TARGET" 2>&1
+) || TEST_EXIT=$?
+
+# Tally pass/fail per test from the event stream. Runs of a test are numbered
+# in order; go test repeats -count runs for each -cpu value in turn, so run k
+# used GOMAXPROCS cpus[(k-1)/count]. Parents of failing subtests are dropped.
+# Prints "result<TAB>status<TAB>package<TAB>test<TAB>passed<TAB>failed",
+# "failure<TAB>package<TAB>test<TAB>run<TAB>procs<TAB>message" and
+# "seed<TAB>package<TAB>seed" lines
+RESULTS=$(echo "$TEST_OUTPUT" | jq -R -r '
+    fromjson? | select(type == "object" and .Package != null)
+    | [.Action, .Package, (.Test // ""), (.Output // "")] | @tsv
+' | awk -F'\t' -v count="$COUNT" -v cpu_list="$CPU_LIST" '
+    function clean(s) {
+        gsub(/\\n/, "", s)
+        gsub(/\\t/, " ", s)
+        gsub(/\\\\/, "\\", s)
+        sub(/^ +/, "", s)
+        return s
+    }
+    BEGIN { split(cpu_list, cpus, ",") }
+    $1 == "output" && $3 == "" && $4 ~ /^-test\.shuffle [0-9]+/ {
+        seed = clean($4)
+        sub(/^-test\.shuffle /, "", seed)
+        print "seed\t" $2 "\t" seed
+        next
+    }
+    $3 == "" { next }
+    {
+        key = $2 "\t" $3
+    }
+    $1 == "run" {
+        runs[key]++
+        message[key] = ""
+        if (!(key in seen)) {
+            seen[key] = 1
+            order[++test_count] = key
+        }
+        next
+    }
+    $1 == "output" && message[key] == "" && ($4 ~ /^ +[^ ]+\.go:[0-9]+: / || $4 ~ /^panic: /) {
+        message[key] = clean($4)
+        next
+    }
+    $1 == "pass" { passed[key]++; next }
+    $1 == "fail" {
+        failed[key]++
+        run = runs[key]
+        procs = cpus[int((run - 1) / count) + 1]
+        print "failure\t" key "\t" run "\t" procs "\t" (message[key] != "" ? message[key] : "failed without a message")
+        next
+    }
+    END {
+        for (i = 1; i <= test_count; i++) {
+            key = order[i]
+            if (!(key in failed)) continue
+            for (j = 1; j <= test_count; j++) {
+                if (index(order[j], key "/") == 1 && (order[j] in failed)) {
+                    parent[key] = 1
+                    break
+                }
+            }
+        }
+        for (i = 1; i <= test_count; i++) {
+            key = order[i]
+            status = "stable"
+            if (key in failed) status = (key in passed) ? "flaky" : "failing"
+            if (key in parent) status = "parent"
+            print "result\t" status "\t" key "\t" (passed[key] + 0) "\t" (failed[key] + 0)
+        }
+    }
+')
+
+TEST_COUNT=$(echo "$RESULTS" | awk -F'\t' '$1 == "result" && $2 != "parent"' | grep -c . || true)
+
+if [[ $TEST_EXIT -eq 124 ]]; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    echo "go test did not finish within ${FLAKY_TIMEOUT}s (set GO_LINT_FLAKY_TIMEOUT to allow more, or narrow the run with --run)."
+    exit 1
+fi
+
+if [[ $TEST_COUNT -eq 0 && $TEST_EXIT -ne 0 ]]; then
+    echo "## Go Flaky Test Detection Failed"
+    echo ""
+    SUMMARY=$(_gol_summarize_go_output "test" "$TEST_OUTPUT" "$PROJECT_ROOT")
+    echo "${SUMMARY:-$(echo "$TEST_OUTPUT" | tail -n 20)}"
+    exit 1
+fi
+
+FLAKY=$(echo "$RESULTS" | awk -F'\t' '$1 == "result" && $2 == "flaky" { print $3 "\t" $4 "\t" $5 "\t" $6 }' | sort)
+FAILING=$(echo "$RESULTS" | awk -F'\t' '$1 == "result" && $2 == "failing" { print $3 "\t" $4 }' | sort)
+FLAKY_COUNT=$(echo "$FLAKY" | grep -c . || true)
+FAILING_COUNT=$(echo "$FAILING" | grep -c . || true)
+
+RUN_FLAGS="-count=$COUNT -cpu=$CPU_LIST -shuffle=on"
+if [[ "$RACE" == true ]]; then
+    RUN_FLAGS="$RUN_FLAGS -race"
+fi
+
+echo "## Go Flaky Test Report"
+echo ""
+echo "**Target:** $TEST_TARGET"
+echo "**Project root:** $PROJECT_ROOT"
+echo "**Runs:** $RUNS_PER_TEST per test ($RUN_FLAGS${RUN_PATTERN:+ -run $RUN_PATTERN})"
+echo ""
+
+echo "### Summary"
+echo ""
+echo "- **Tests run:** $TEST_COUNT"
+echo "- **Flaky:** $FLAKY_COUNT"
+echo "- **Failing in every run:** $FAILING_COUNT"
+echo "- **Stable:** $((TEST_COUNT - FLAKY_COUNT - FAILING_COUNT))"
+echo ""
+
+if [[ $TEST_COUNT -eq 0 ]]; then
+    echo "No tests matched the target."
+    exit 0
+fi
+
+if [[ $FLAKY_COUNT -gt 0 ]]; then
+    echo "### Flaky Tests"
+    echo ""
+    awk -F'\t' -v max="$FLAKY_MAX_FAILURES" '
+        FILENAME == ARGV[1] {
+            if ($1 != "failure") next
+            key = $2 "\t" $3
+            shown = ++failure_count[key]
+            if (shown <= max) {
+                failures[key] = failures[key] "  - run " $4 " (GOMAXPROCS=" $5 "): " $6 "\n"
+            } else if (shown == max + 1) {
+                failures[key] = failures[key] "  - ...\n"
+            }
+            next
+        }
+        {
+            key = $1 "\t" $2
+            print "- **" $1 " " $2 "** failed " $4 " of " ($3 + $4) " runs"
+            printf "%s", failures[key]
+        }
+    ' <(echo "$RESULTS") <(echo "$FLAKY")
+    echo ""
+fi
+
+if [[ $FAILING_COUNT -gt 0 ]]; then
+    echo "### Failing in Every Run"
+    echo ""
+    awk -F'\t' '
+        FILENAME == ARGV[1] {
+            if ($1 == "failure" && !(($2 "\t" $3) in message)) message[$2 "\t" $3] = $6
+            next
+        }
+        { print "- **" $1 " " $2 "**: " message[$1 "\t" $2] }
+    ' <(echo "$RESULTS") <(echo "$FAILING")
+    echo ""
+    echo "These fail consistently, so they are regular failures rather than flaky tests."
+    echo ""
+fi
+
+SEEDS=$(echo "$RESULTS" | awk -F'\t' '$1 == "seed" && !seen[$2]++ { print "- " $2 ": -shuffle=" $3 }')
+if [[ -n "$SEEDS" && $((FLAKY_COUNT + FAILING_COUNT)) -gt 0 ]]; then
+    echo "### Shuffle Seeds"
+    echo ""
+    echo "$SEEDS"
+    echo ""
+fi
+
+if [[ $FLAKY_COUNT -eq 0 ]]; then
+    echo "No test changed its outcome across $RUNS_PER_TEST runs."
+    exit 0
+fi
+
+# First failure message of each flaky test goes into the record
+RECORD=$(awk -F'\t' '
+    FILENAME == ARGV[1] {
+        if ($1 == "failure" && !(($2 "\t" $3) in message)) message[$2 "\t" $3] = $6
+        next
+    }
+    { print $1 "\t" $2 "\t" $3 "\t" $4 "\t" message[$1 "\t" $2] }
+' <(echo "$RESULTS") <(echo "$FLAKY"))
+
+if _gol_flaky_record "$PROJECT_ROOT" "$RECORD"; then
+    echo "Recorded $FLAKY_COUNT flaky test(s) in $_GOL_FLAKY_FILE. Test failure summaries mark their failures as known flaky and the Stop hook does not block on them; remove an entry once the test is fixed."
+else
+    echo "Could not record the flaky tests in $PROJECT_ROOT/$_GOL_FLAKY_FILE."
+fi
@@ -0,0 +1,143 @@
+#!/usr/bin/env bash
+# Known-flaky tests for go-lint plugin
+# This file should be sourced after go-lint-common.sh, not executed directly
+#
+# /go-lint:flaky records tests whose outcome changes between repeated runs in
+# .go-lint-flaky.json at the project root. Test failure summaries mark those
+# tests as known flaky, and the Stop hook does not block when they are the only
+# failures, so a flaky test is not "fixed" on the strength of one failed run.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Known-flaky test file, relative to the project root
+_GOL_FLAKY_FILE=".go-lint-flaky.json"
+
+# List known-flaky tests
+# Args: project_root
+# Returns: "package<TAB>test" lines, or empty string
+_gol_flaky_known() {
+    local flaky_file="${1}/$_GOL_FLAKY_FILE"
+
+    if [[ ! -f "$flaky_file" ]]; then
+        return 0
+    fi
+    jq -r '.tests[]? | [.package, .test] | @tsv' "$flaky_file" 2>/dev/null || true
+}
+
+# Add or update known-flaky tests
+# Entries are keyed by package and test; other entries are kept
+# Args: project_root, results ("package<TAB>test<TAB>passed<TAB>failed<TAB>message" lines)
+# Returns: 1 if the file cannot be written
+_gol_flaky_record() {
+    local project_root="${1}"
+    local results="${2}"
+    local flaky_file="$project_root/$_GOL_FLAKY_FILE"
+
+    local existing='{}'
+    if [[ -f "$flaky_file" ]] && jq -e 'type == "object"' "$flaky_file" &>/dev/null; then
+        existing=$(cat "$flaky_file")
+    fi
+
+    local entries
+    entries=$(echo "$results" | jq -R -s '
+        split("\n") | map(select(. != "") | split("\t") | {
+            package: .[0],
+            test: .[1],
+            passed: (.[2] | tonumber),
+            failed: (.[3] | tonumber),
+            message: .[4]
+        })
+    ')
+
+    echo "$existing" | jq --argjson new "$entries" --arg date "$(date +%Y-%m-%d)" '
+        .tests = ((.tests // [])
+            | map(select(. as $old | $new | all(.package != $old.package or .test != $old.test)))
+            | . + ($new | map(. + {last_seen: $date}))
+            | sort_by(.package, .test))
+    ' > "$flaky_file.tmp" && mv "$flaky_file.tmp" "$flaky_file"
+}
+
+# Mark known-flaky tests in a go test summary (see _gol_summarize_go_output)
+# Failed tests and panics of a known test or its subtests get " (known flaky)"
+# after the test name
+# Args: summary, project_root
+# Returns: summary text
+_gol_flaky_annotate() {
+    local summary="${1}"
+    local project_root="${2}"
+
+    local known
+    known=$(_gol_flaky_known "$project_root")
+    if [[ -z "$known" ]]; then
+        echo "$summary"
+        return 0
+    fi
+
+    awk -F'\t' '
+        function top(name) {
+            sub(/\/.*/, "", name)
+            return name
+        }
+        FILENAME == ARGV[1] {
+            if ($1 != "") {
+                known[$1 "\t" top($2)] = 1
+                known_test[top($2)] = 1
+            }
+            next
+        }
+        /^[A-Z][a-z ]*:$/ {
+            section = $0
+            print
+            next
+        }
+        (section == "Failed tests:" || section == "Panics:") && /^- / {
+            head = substr($0, 3)
+            rest = ""
+            i = index(head, ": ")
+            if (i > 0) {
+                rest = substr(head, i)
+                head = substr(head, 1, i - 1)
+            }
+            n = split(head, words, " ")
+            test = top(words[n])
+            if ((n == 2 && (words[1] "\t" test) in known) || (n == 1 && test in known_test)) {
+                print "- " head " (known flaky)" rest
+                next
+            }
+        }
+        { print }
+    ' <(echo "$known") <(echo "$summary")
+}
+
+# Check whether known-flaky tests are the only failures in a summary
+# Args: annotated_summary (see _gol_flaky_annotate)
+# Returns: 0 if every failed test and panic is known flaky and nothing else
+#          failed to build
+_gol_flaky_all_known() {
+    echo "${1}" | awk '
+        /^[A-Z][a-z ]*:$/ {
+            section = $0
+            if (section == "Errors:" || section == "Build errors:") other = 1
+            next
+        }
+        (section == "Failed tests:" || section == "Panics:") && /^- / {
+            if ($0 ~ / \(known flaky\)(: |$)/) flaky++
+            else other = 1
+        }
+        END { exit (flaky > 0 && !other) ? 0 : 1 }
+    '
+}
+
+# Explain the known-flaky marks in a summary
+# Args: annotated_summary
+# Returns: note text, or empty string if no test is marked
+_gol_flaky_note() {
+    if [[ "${1}" != *"(known flaky)"* ]]; then
+        return 0
+    fi
+    echo "Tests marked (known flaky) had inconsistent outcomes in repeated /go-lint:flaky runs and are listed in $_GOL_FLAKY_FILE. Rerun them before changing code or tests; one failure is not evidence of a bug."
+}
@@ -0,0 +1,330 @@
+#!/usr/bin/env bash
+# go generate support for go-lint plugin
+# This file should be sourced after go-lint-common.sh, not executed directly
+#
+# Maps an edited file to the go generate runs that consume it:
+# - //go:generate directives in the file's own package that live in the file,
+#   mention it by name, or name one of its types (stringer -type=Color)
+# - "generate.inputs" in .go-lint.json, mapping globs relative to the project
+#   root to packages, for inputs that sit outside any Go package:
+#     {"generate": {"run": true, "inputs": {"api/*.proto": "./gen/api"}}}
+#
+# By default the hook only reports that generated code may be stale; with
+# "generate.run" set (or GO_LINT_GENERATE=1) it runs go generate and reports
+# the files it changed.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Default time limit for a go generate run (seconds), below the 30s hook timeout
+_GOL_GENERATE_TIMEOUT=15
+
+# Check if a Go file was produced by a generator
+# Args: file_path
+# Returns: 0 if the file carries a "Code generated ... DO NOT EDIT." header
+_gol_is_generated_file() {
+    head -n 20 "${1}" 2>/dev/null | grep -qE '^// Code generated .* DO NOT EDIT\.$'
+}
+
+# List type names declared at the top level of a Go file
+# Args: file_path
+# Returns: comma-separated type names
+_gol_declared_types() {
+    awk '
+        /^type[ \t]*\(/ { in_block = 1; next }
+        in_block && /^\)/ { in_block = 0; next }
+        in_block && /^\t[A-Za-z_][A-Za-z0-9_]*[ \t]/ {
+            name = $1
+            names = names (names == "" ? "" : ",") name
+            next
+        }
+        /^type[ \t]+[A-Za-z_][A-Za-z0-9_]*/ {
+            names = names (names == "" ? "" : ",") $2
+        }
+        END { print names }
+    ' "${1}" 2>/dev/null || echo ""
+}
+
+# Find //go:generate directives in a package that consume an edited file
+# Args: package_dir, edited_file
+# Returns: one "file<TAB>line<TAB>command" entry per matching directive
+_gol_generate_directives() {
+    local package_dir="${1}"
+    local edited_file="${2}"
+
+    local go_files=()
+    local go_file
+    for go_file in "$package_dir"/*.go; do
+        [[ -f "$go_file" ]] && go_files+=("$go_file")
+    done
+
+    if [[ ${#go_files[@]} -eq 0 ]]; then
+        return 0
+    fi
+
+    local types=""
+    if [[ "$edited_file" == *.go ]]; then
+        types=$(_gol_declared_types "$edited_file")
+    fi
+
+    awk -v edited="$(basename "$edited_file")" -v types="$types" '
+        function mentions(command, name,   rest, at, before, after) {
+            rest = command
+            while ((at = index(rest, name)) > 0) {
+                before = at == 1 ? " " : substr(rest, at - 1, 1)
+                after = substr(rest, at + length(name), 1)
+                if (before ~ /[ \t=\/"'"'"',:]/ && (after == "" || after ~ /[ \t"'"'"',:]/)) {
+                    return 1
+                }
+                rest = substr(rest, at + length(name))
+            }
+            return 0
+        }
+        function names_type(command,   count, i, parts, list, names, j) {
+            count = split(command, parts, /[ \t]+/)
+            for (i = 1; i <= count; i++) {
+                list = ""
+                if (parts[i] ~ /^--?type=/) {
+                    list = parts[i]
+                    sub(/^--?type=/, "", list)
+                } else if (parts[i] ~ /^--?type$/ && i < count) {
+                    list = parts[i + 1]
+                }
+                gsub(/["'"'"']/, "", list)
+                split(list, names, ",")
+                for (j in names) {
+                    if (names[j] != "" && ("," types ",") ~ ("," names[j] ",")) {
+                        return 1
+                    }
+                }
+            }
+            return 0
+        }
+        /^\/\/go:generate[ \t]/ {
+            command = $0
+            sub(/^\/\/go:generate[ \t]+/, "", command)
+            file = FILENAME
+            sub(/^.*\//, "", file)
+            if (file == edited || mentions(command, edited) || (types != "" && names_type(command))) {
+                print file "\t" FNR "\t" command
+            }
+        }
+    ' "${go_files[@]}" 2>/dev/null || true
+}
+
+# Find packages mapped to an input file in .go-lint.json generate.inputs
+# Args: project_root, path relative to the project root
+# Returns: package patterns relative to the project root, one per line
+_gol_generate_mapped_packages() {
+    local project_root="${1}"
+    local rel_path="${2}"
+
+    local inputs
+    inputs=$(_gol_project_config "$project_root" '.generate.inputs' '{}')
+
+    local glob packages
+    while IFS=$'\t' read -r glob packages; do
+        [[ -z "$glob" ]] && continue
+        # Unquoted right-hand side: glob match, where * also crosses directories
+        # shellcheck disable=SC2053
+        if [[ "$rel_path" == $glob ]]; then
+            echo "$packages" | tr ',' '\n'
+        fi
+    done < <(echo "$inputs" | jq -r '
+        to_entries[]
+        | [.key, (.value | if type == "array" then join(",") else . end)]
+        | @tsv
+    ' 2>/dev/null || true)
+}
+
+# Record checksums of the files directly inside some directories
+# Args: directories...
+# Returns: "checksum size path" lines
+_gol_snapshot_files() {
+    local dir file
+    for dir in "$@"; do
+        for file in "$dir"/*; do
+            [[ -f "$file" ]] && cksum "$file"
+        done
+    done 2>/dev/null || true
+}
+
+# List files written after a marker whose content differs from a snapshot
+# Args: project_root, marker_file, snapshot
+# Returns: paths relative to the project root, one per line
+_gol_changed_files() {
+    local project_root="${1}"
+    local marker="${2}"
+    local snapshot="${3}"
+
+    local file current
+    while IFS= read -r file; do
+        [[ "$file" == "$marker" ]] && continue
+        current=$(cksum "$file" 2>/dev/null || echo "")
+        if [[ -n "$current" ]] && grep -qxF "$current" <<< "$snapshot"; then
+            continue
+        fi
+        echo "${file#"$project_root"/}"
+    done < <(find "$project_root" -type d -name .git -prune -o -type f -newer "$marker" -print 2>/dev/null | sort)
+}
+
+# Work out which go generate runs an edited file feeds and either run them or
+# describe them
+# Args: file_path (absolute), project_root
+# Sets: _gol_GENERATE_REASON and _gol_GENERATE_CONTEXT (empty if the file is
+#       not a generator input)
+# Returns: 0 if there is something to report
+_gol_check_generate() {
+    local file_path="${1}"
+    local project_root="${2}"
+
+    _gol_GENERATE_REASON=""
+    _gol_GENERATE_CONTEXT=""
+
+    if [[ "$file_path" == *.go ]] && _gol_is_generated_file "$file_path"; then
+        return 1
+    fi
+
+    local package_dir rel_path rel_package
+    package_dir=$(dirname "$file_path")
+    rel_path=$(_gol_get_relative_path "$project_root" "$file_path")
+    rel_package=$(dirname "$rel_path")
+
+    local directives mapped
+    directives=$(_gol_generate_directives "$package_dir" "$file_path")
+    mapped=$(_gol_generate_mapped_packages "$project_root" "$rel_path")
+
+    if [[ -z "$directives" && -z "$mapped" ]]; then
+        return 1
+    fi
+
+    # Each run is "dir<TAB>args" with args relative to dir
+    local runs=()
+    local sources=""
+    if [[ -n "$directives" ]]; then
+        local directive_files
+        directive_files=$(echo "$directives" | cut -f1 | awk '!seen[$0]++' | paste -sd' ' -)
+        runs+=("$package_dir"$'\t'"$directive_files")
+        sources=$(echo "$directives" | awk -F'\t' -v dir="$rel_package" '{
+            path = (dir == "." ? "" : dir "/") $1
+            print "- " path ":" $2 ": //go:generate " $3
+        }')
+    fi
+
+    local package
+    while IFS= read -r package; do
+        [[ -z "$package" ]] && continue
+        runs+=("$project_root"$'\t'"$package")
+        sources="${sources:+$sources
+}- .go-lint.json generate.inputs: $package"
+    done <<< "$mapped"
+
+    local commands="" run run_dir run_args display
+    for run in "${runs[@]}"; do
+        run_dir="${run%%$'\t'*}"
+        run_args="${run#*$'\t'}"
+        if [[ "$run_dir" == "$project_root" ]]; then
+            display="go generate $run_args"
+        else
+            display="(cd $rel_package && go generate $run_args)"
+            [[ "$rel_package" == "." ]] && display="go generate $run_args"
+        fi
+        commands="${commands:+$commands
+}$display"
+    done
+
+    local run_enabled="${GO_LINT_GENERATE:-}"
+    if [[ -z "$run_enabled" ]]; then
+        run_enabled=$(_gol_project_config "$project_root" '.generate.run' 'false')
+    fi
+
+    if [[ "$run_enabled" != "true" && "$run_enabled" != "1" ]]; then
+        _gol_GENERATE_REASON="$rel_path is a go generate input; generated code may be stale"
+        _gol_GENERATE_CONTEXT="Generators consuming $rel_path:
+$sources
+
+Regenerate with:
+$commands
+
+Set \"generate\": {\"run\": true} in .go-lint.json to run go generate automatically after edits."
+        return 0
+    fi
+
+    if ! command -v go &>/dev/null; then
+        _gol_GENERATE_REASON="go generate skipped: go not installed"
+        _gol_GENERATE_CONTEXT="$rel_path feeds:
+$sources"
+        return 0
+    fi
+
+    local timeout
+    timeout=$(_gol_deadline_limit "$(_gol_project_config "$project_root" '.generate.timeout' "$_GOL_GENERATE_TIMEOUT")")
+
+    # Snapshot the packages being regenerated so rewrites with identical
+    # content are not reported as changes
+    local snapshot_dirs=("$package_dir")
+    local marker snapshot
+    for run in "${runs[@]}"; do
+        if [[ "${run%%$'\t'*}" == "$project_root" ]]; then
+            run_args="${run#*$'\t'}"
+            snapshot_dirs+=("$project_root/${run_args#./}")
+        fi
+    done
+    snapshot=$(_gol_snapshot_files "${snapshot_dirs[@]}")
+    marker=$(mktemp)
+
+    local failures="" output exit_code
+    for run in "${runs[@]}"; do
+        run_dir="${run%%$'\t'*}"
+        run_args="${run#*$'\t'}"
+        exit_code=0
+        # shellcheck disable=SC2086
+        output=$(
+            cd "$run_dir" && _gol_run_with_timeout "$timeout" go generate $run_args 2>&1
+        ) || exit_code=$?
+
+        if [[ $exit_code -eq 124 ]]; then
+            failures="${failures:+$failures
+}go generate $run_args timed out after ${timeout}s"
+        elif [[ $exit_code -ne 0 ]]; then
+            failures="${failures:+$failures
+}go generate $run_args failed (exit $exit_code):
+$output"
+        fi
+    done
+
+    local changed
+    changed=$(_gol_changed_files "$project_root" "$marker" "$snapshot")
+    rm -f "$marker"
+
+    local changed_list="none"
+    local changed_count=0
+    if [[ -n "$changed" ]]; then
+        changed_list=$(echo "$changed" | sed 's/^/- /')
+        changed_count=$(echo "$changed" | grep -c "^")
+    fi
+
+    # Generated code was already up to date
+    if [[ -z "$failures" && -z "$changed" ]]; then
+        return 1
+    fi
+
+    if [[ -n "$failures" ]]; then
+        _gol_GENERATE_REASON="go generate failed for $rel_path"
+        _gol_GENERATE_CONTEXT="$failures
+
+Changed files:
+$changed_list"
+    else
+        _gol_GENERATE_REASON="go generate updated $changed_count file(s) after editing $rel_path"
+        _gol_GENERATE_CONTEXT="Ran:
+$commands
+
+Changed files:
+$changed_list"
+    fi
+    return 0
+}
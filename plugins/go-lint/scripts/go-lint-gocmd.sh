@@ -0,0 +1,288 @@
+#!/usr/bin/env bash
+# go build / go test / go vet output summaries for go-lint plugin
+# This file should be sourced after go-lint-common.sh, not executed directly
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Maximum entries listed per summary section
+_GOL_GOCMD_MAX_ENTRIES=10
+
+# Detect which go subcommand a shell command runs
+# Matches "go build", "go test" and "go vet" anywhere in a pipeline or chain
+# Args: command
+# Returns: "build", "test" or "vet", or empty string
+_gol_go_subcommand() {
+    local command="${1}"
+
+    if [[ "$command" =~ (^|[[:space:]\;\&\|\(])go[[:space:]]+(build|test|vet)([[:space:]]|$) ]]; then
+        echo "${BASH_REMATCH[2]}"
+    fi
+}
+
+# Extract the combined output of a Bash tool call
+# Args: hook_input_json
+# Returns: stdout followed by stderr
+_gol_parse_tool_output() {
+    local input="${1}"
+
+    echo "$input" | jq -r '
+        .tool_response
+        | if type == "string" then .
+          elif type == "object" then [(.stdout // ""), (.stderr // "")] | map(select(. != "")) | join("\n")
+          else ""
+          end
+    ' 2>/dev/null || echo ""
+}
+
+# Convert go test -json event streams back to plain test output
+# Plain output is passed through unchanged
+# Args: output
+_gol_decode_test_json() {
+    local output="${1}"
+
+    if [[ "$output" =~ ^[[:space:]]*\{\"(Time|Action|ImportPath)\" ]]; then
+        echo "$output" | jq -rj 'select(type == "object" and .Output != null) | .Output' 2>/dev/null \
+            || echo "$output"
+    else
+        echo "$output"
+    fi
+}
+
+# Summarise go build / test / vet output
+# Keeps compiler and vet errors, failing tests with their first assertion,
+# panics with the first non-runtime frame, and failing packages; drops
+# passing packages and test chatter
+# Args: subcommand, output, [cwd to strip from panic locations]
+# Returns: summary text, or empty string if nothing failed
+_gol_summarize_go_output() {
+    local subcommand="${1}"
+    local output="${2}"
+    local cwd="${3:-}"
+
+    _gol_decode_test_json "$output" | awk \
+        -v label="go $subcommand" \
+        -v max="$_GOL_GOCMD_MAX_ENTRIES" \
+        -v cwd="$cwd" '
+        function trim(s) {
+            sub(/^[ \t]+/, "", s)
+            sub(/[ \t]+$/, "", s)
+            return s
+        }
+        function relative(path) {
+            if (cwd != "" && index(path, cwd "/") == 1) {
+                return substr(path, length(cwd) + 2)
+            }
+            return path
+        }
+        function add_failed_test(name) {
+            if (!(name in failed)) {
+                failed[name] = 1
+                failed_order[++failed_count] = name
+                pending[++pending_count] = name
+            }
+        }
+        function section(title, items, count,   i) {
+            if (count == 0) {
+                return
+            }
+            print ""
+            print title ":"
+            for (i = 1; i <= count && i <= max; i++) {
+                print items[i]
+            }
+            if (count > max) {
+                print "- ... and " (count - max) " more"
+            }
+        }
+        {
+            line = $0
+            sub(/\r$/, "", line)
+        }
+
+        # Panic stack traces: find the first frame outside runtime/testing
+        in_trace {
+            if (line ~ /^\t/) {
+                if (panic_location[panic_count] == "" && frame_func != "" &&
+                    frame_func !~ /^((runtime|testing)\.|panic$|created by )/) {
+                    location = trim(line)
+                    sub(/ \+0x[0-9a-f]+$/, "", location)
+                    panic_location[panic_count] = relative(location) " (" frame_func ")"
+                }
+                next
+            }
+            if (line ~ /^(goroutine [0-9]+ |$)/) {
+                next
+            }
+            if (line !~ /^(FAIL|ok  |exit status|\?   )/) {
+                frame_func = line
+                sub(/\(.*$/, "", frame_func)
+                next
+            }
+            in_trace = 0
+        }
+
+        # Package results
+        /^ok  \t/ {
+            passed_packages++
+            pending_count = 0
+            next
+        }
+        /^\?   \t/ {
+            next
+        }
+        /^FAIL\t/ {
+            split(line, parts, "\t")
+            failed_packages[++failed_package_count] = "- " parts[2]
+            package_name = parts[2]
+            sub(/ \[.*\]$/, "", package_name)
+            for (i = 1; i <= pending_count; i++) {
+                test_package[pending[i]] = package_name
+            }
+            for (i = panic_pending; i <= panic_count; i++) {
+                if (panic_package[i] == "") {
+                    panic_package[i] = package_name
+                }
+            }
+            panic_pending = panic_count + 1
+            pending_count = 0
+            context = ""
+            next
+        }
+
+        # Test progress markers set the context for following log lines
+        /^=== (RUN|CONT|NAME|PAUSE) / {
+            context = trim(substr(line, 11))
+            next
+        }
+        /^[ \t]*--- FAIL: / {
+            name = trim(line)
+            sub(/^--- FAIL: /, "", name)
+            sub(/ \([0-9.]+s\)$/, "", name)
+            add_failed_test(name)
+            context = name
+            next
+        }
+        /^[ \t]*--- (PASS|SKIP): / {
+            context = ""
+            next
+        }
+
+        # Panics
+        /^panic: / {
+            message = substr(line, 8)
+            sub(/ \[recovered.*\]$/, "", message)
+            panic_message[++panic_count] = message
+            panic_test[panic_count] = context
+            panic_location[panic_count] = ""
+            if (context != "") {
+                panicked[context] = 1
+                add_failed_test(context)
+            }
+            in_trace = 1
+            frame_func = ""
+            next
+        }
+
+        # Test log lines: "    file_test.go:12: message"
+        /^[ \t]+[^ \t]+\.go:[0-9]+: / {
+            if (context != "" && !(context in first_assertion)) {
+                first_assertion[context] = trim(line)
+            }
+            next
+        }
+
+        # Compiler and vet diagnostics: "pkg/file.go:12:5: message"
+        /^(vet: )?[^ \t#][^ \t]*\.go:[0-9]+(:[0-9]+)?: / {
+            build_errors[++build_error_count] = "- " line
+            next
+        }
+
+        # go command errors (module resolution, bad patterns, ...)
+        /^go: / && !/^go: (downloading|finding|extracting) / {
+            go_errors[++go_error_count] = "- " line
+            next
+        }
+
+        END {
+            # Parents of failed subtests only fail because of them
+            for (i = 1; i <= failed_count; i++) {
+                name = failed_order[i]
+                if (name in first_assertion || name in panicked) {
+                    shown[name] = 1
+                    continue
+                }
+                shown[name] = 1
+                for (j = 1; j <= failed_count; j++) {
+                    if (index(failed_order[j], name "/") == 1) {
+                        delete shown[name]
+                        break
+                    }
+                }
+            }
+
+            test_count = 0
+            for (i = 1; i <= failed_count; i++) {
+                name = failed_order[i]
+                if (!(name in shown)) {
+                    continue
+                }
+                entry = "- " (test_package[name] != "" ? test_package[name] " " : "") name
+                if (name in first_assertion) {
+                    entry = entry ": " first_assertion[name]
+                } else if (name in panicked) {
+                    entry = entry ": panicked (see Panics)"
+                }
+                tests[++test_count] = entry
+            }
+
+            for (i = 1; i <= panic_count; i++) {
+                entry = "- " (panic_package[i] != "" ? panic_package[i] " " : "")
+                entry = entry (panic_test[i] != "" ? panic_test[i] ": " : "") panic_message[i]
+                if (panic_location[i] != "") {
+                    entry = entry "\n  at " panic_location[i]
+                }
+                panics[i] = entry
+            }
+
+            if (test_count + panic_count + build_error_count + go_error_count + failed_package_count == 0) {
+                exit
+            }
+
+            headline = label ":"
+            separator = " "
+            if (test_count > 0) {
+                headline = headline separator test_count " failed test(s)"
+                separator = ", "
+            }
+            if (panic_count > 0) {
+                headline = headline separator panic_count " panic(s)"
+                separator = ", "
+            }
+            if (build_error_count > 0) {
+                headline = headline separator build_error_count " build/vet error(s)"
+                separator = ", "
+            }
+            if (go_error_count > 0) {
+                headline = headline separator go_error_count " go command error(s)"
+                separator = ", "
+            }
+            if (failed_package_count > 0) {
+                headline = headline " in " failed_package_count " failing package(s)"
+            }
+            if (passed_packages > 0) {
+                headline = headline "; " passed_packages " package(s) passed"
+            }
+            print headline
+
+            section("Errors", go_errors, go_error_count)
+            section("Build errors", build_errors, build_error_count)
+            section("Failed tests", tests, test_count)
+            section("Panics", panics, panic_count)
+            section("Failing packages", failed_packages, failed_package_count)
+        }
+    '
+}
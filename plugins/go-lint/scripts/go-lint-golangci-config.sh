@@ -0,0 +1,362 @@
+#!/usr/bin/env bash
+# golangci-lint config checks for go-lint plugin
+# This file should be sourced after go-lint-common.sh, not executed directly
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Time limit for golangci-lint config verify (seconds)
+_GOL_CONFIG_VERIFY_TIMEOUT="${GO_LINT_VET_TIMEOUT:-20}"
+
+# v1-only keys that golangci-lint v2 rejects, as "path|replacement"
+_GOL_V1_ONLY_KEYS=(
+    "linters-settings|moved to linters.settings"
+    "linters.disable-all|replaced by linters.default: none"
+    "linters.enable-all|replaced by linters.default: all"
+    "linters.fast|replaced by linters.default: fast"
+    "linters.presets|removed, use linters.default and linters.enable"
+    "issues.exclude|moved to linters.exclusions.rules[].text"
+    "issues.exclude-rules|moved to linters.exclusions.rules"
+    "issues.exclude-dirs|moved to linters.exclusions.paths"
+    "issues.exclude-files|moved to linters.exclusions.paths"
+    "issues.exclude-generated|moved to linters.exclusions.generated"
+    "issues.exclude-use-default|replaced by linters.exclusions.presets"
+    "issues.exclude-case-sensitive|removed"
+    "run.skip-dirs|moved to linters.exclusions.paths"
+    "run.skip-files|moved to linters.exclusions.paths"
+    "run.skip-dirs-use-default|removed"
+    "run.show-stats|moved to output.show-stats"
+    "output.format|replaced by output.formats.<format>.path"
+    "output.uniq-by-line|moved to issues.uniq-by-line"
+    "output.sort-results|removed, results are always sorted"
+)
+
+# Linter names v2 no longer accepts in linters.enable/disable, as "name|replacement"
+_GOL_V1_ONLY_LINTERS=(
+    "gosimple|merged into staticcheck"
+    "stylecheck|merged into staticcheck"
+    "typecheck|not a linter, type errors are always reported"
+    "gofmt|is a formatter, move it to formatters.enable"
+    "gofumpt|is a formatter, move it to formatters.enable"
+    "goimports|is a formatter, move it to formatters.enable"
+    "gci|is a formatter, move it to formatters.enable"
+    "golines|is a formatter, move it to formatters.enable"
+    "tenv|replaced by usetesting"
+    "exportloopref|removed, use copyloopvar"
+)
+
+# Check if a path is a golangci-lint config file
+# Args: file_path
+# Returns: 0 for .golangci.{yml,yaml,json,toml}
+_gol_is_golangci_config() {
+    case "$(basename "${1}")" in
+        .golangci.yml|.golangci.yaml|.golangci.json|.golangci.toml) return 0 ;;
+        *) return 1 ;;
+    esac
+}
+
+# Index a YAML file by dotted key path
+# List items are indexed by position ("linters.enable.0")
+# Args: yaml_file
+# Returns: "line<TAB>path<TAB>value" per key or list item
+_gol_yaml_index() {
+    awk '
+        function current_path(   i, p) {
+            p = ""
+            for (i = 1; i <= depth; i++) {
+                p = (p == "" ? keys[i] : p "." keys[i])
+            }
+            return p
+        }
+        function clean_value(v) {
+            sub(/[ \t]+#.*$/, "", v)
+            sub(/[ \t]+$/, "", v)
+            gsub(/^["'\'']|["'\'']$/, "", v)
+            return v
+        }
+        {
+            line = $0
+            sub(/\r$/, "", line)
+            if (line ~ /^[ \t]*(#.*)?$/ || line ~ /^(---|\.\.\.)/) {
+                next
+            }
+
+            match(line, /^ */)
+            indent = RLENGTH
+            rest = substr(line, indent + 1)
+
+            # List item: "- value" or "- key: value"
+            if (rest ~ /^-( |$)/) {
+                while (depth > 0 && (indents[depth] > indent || (indents[depth] == indent && items[depth]))) {
+                    depth--
+                }
+                parent = current_path()
+                n = counts[parent] + 0
+                counts[parent] = n + 1
+                depth++
+                keys[depth] = n
+                indents[depth] = indent
+                items[depth] = 1
+
+                item = substr(rest, 2)
+                sub(/^ +/, "", item)
+                print NR "\t" current_path() "\t" clean_value(item)
+
+                if (item !~ /^["'\'']?[^ "'\'':]+["'\'']?[ \t]*:( |$)/) {
+                    next
+                }
+                indent = indent + 2
+                rest = item
+            }
+
+            # Mapping key: "key: value"
+            if (rest ~ /^["'\'']?[^ "'\'':#]+["'\'']?[ \t]*:( |$)/) {
+                key = rest
+                sub(/[ \t]*:( .*)?$/, "", key)
+                gsub(/["'\'']/, "", key)
+                value = rest
+                sub(/^[^:]*:[ \t]*/, "", value)
+
+                while (depth > 0 && indents[depth] >= indent) {
+                    depth--
+                }
+                depth++
+                keys[depth] = key
+                indents[depth] = indent
+                items[depth] = 0
+                print NR "\t" current_path() "\t" clean_value(value)
+            }
+        }
+    ' "${1}"
+}
+
+# Find the line of a dotted key path in a golangci-lint config
+# Falls back to the closest ancestor, then to the first mention of the last key
+# Args: config_file, dotted_path
+# Returns: line number or empty string
+_gol_locate_config_key() {
+    local config_file="${1}"
+    local key_path="${2}"
+
+    if [[ "$config_file" == *.yml || "$config_file" == *.yaml ]]; then
+        local index
+        index=$(_gol_yaml_index "$config_file")
+
+        local candidate="$key_path"
+        local line
+        while [[ -n "$candidate" ]]; do
+            line=$(echo "$index" | awk -F'\t' -v p="$candidate" '$2 == p { print $1; exit }')
+            if [[ -n "$line" ]]; then
+                echo "$line"
+                return 0
+            fi
+            if [[ "$candidate" != *.* ]]; then
+                break
+            fi
+            candidate="${candidate%.*}"
+        done
+    fi
+
+    # JSON/TOML (or unmatched YAML): first line mentioning the last non-index key
+    local segment last_key=""
+    local IFS='.'
+    for segment in $key_path; do
+        if [[ ! "$segment" =~ ^[0-9]+$ ]]; then
+            last_key="$segment"
+        fi
+    done
+    unset IFS
+
+    if [[ -n "$last_key" ]]; then
+        grep -n -F -- "$last_key" "$config_file" | head -1 | cut -d: -f1
+    fi
+}
+
+# Read a scalar value at a dotted key path (YAML and JSON configs)
+# Args: config_file, dotted_path
+# Returns: value or empty string
+_gol_config_value() {
+    local config_file="${1}"
+    local key_path="${2}"
+
+    case "$config_file" in
+        *.yml|*.yaml)
+            _gol_yaml_index "$config_file" | awk -F'\t' -v p="$key_path" '$2 == p { print $3; exit }'
+            ;;
+        *.json)
+            jq -r --arg p "$key_path" '
+                getpath($p | split(".") | map(tonumber? // .))
+                | select(type == "string" or type == "number" or type == "boolean")
+            ' "$config_file" 2>/dev/null || true
+            ;;
+    esac
+}
+
+# Read the config version ("2" for v2 configs, empty for v1)
+# Args: config_file
+_gol_golangci_config_version() {
+    local config_file="${1}"
+
+    # Only top-level keys count; JSON nests everything one level in
+    local pattern='^"?version"?[[:space:]]*[:=]'
+    if [[ "$config_file" == *.json ]]; then
+        pattern='^[[:space:]]*"version"[[:space:]]*:'
+    fi
+
+    grep -E -m1 "$pattern" "$config_file" 2>/dev/null \
+        | sed -E 's/^[^:=]*[:=][[:space:]]*//; s/[",[:space:]]//g; s/#.*$//' \
+        | tr -d "'" || true
+}
+
+# Report v1-only keys and linter names in a v2 YAML config
+# Args: config_file
+# Returns: "line: message" per finding
+_gol_scan_v1_only_keys() {
+    local config_file="${1}"
+
+    if [[ "$config_file" != *.yml && "$config_file" != *.yaml ]]; then
+        return 0
+    fi
+
+    local index
+    index=$(_gol_yaml_index "$config_file")
+
+    local entry key_path replacement line
+    for entry in "${_GOL_V1_ONLY_KEYS[@]}"; do
+        key_path="${entry%%|*}"
+        replacement="${entry#*|}"
+        line=$(echo "$index" | awk -F'\t' -v p="$key_path" '$2 == p { print $1; exit }')
+        if [[ -n "$line" ]]; then
+            echo "$line: '$key_path' is not valid in a v2 config ($replacement)"
+        fi
+    done
+
+    local name value
+    while IFS=$'\t' read -r line key_path value; do
+        for entry in "${_GOL_V1_ONLY_LINTERS[@]}"; do
+            name="${entry%%|*}"
+            replacement="${entry#*|}"
+            if [[ "$value" == "$name" ]]; then
+                echo "$line: linter '$name' $replacement"
+            fi
+        done
+    done < <(echo "$index" | awk -F'\t' '$2 ~ /^linters\.(enable|disable)\.[0-9]+$/')
+}
+
+# Turn golangci-lint config verify output into "line: message" findings
+# Args: config_file, verify_output
+_gol_parse_config_verify_output() {
+    local config_file="${1}"
+    local verify_output="${2}"
+
+    local line key_path message location value
+    while IFS= read -r line; do
+        key_path=""
+        message=""
+
+        # jsonschema: "linters.enable.0" does not validate with "/properties/...": message
+        if [[ "$line" =~ ^jsonschema:\ \"([^\"]*)\"\ does\ not\ validate\ with\ \"[^\"]*\":\ (.*)$ ]]; then
+            key_path="${BASH_REMATCH[1]}"
+            message="${BASH_REMATCH[2]}"
+        # - at '/linters/enable/0': message
+        elif [[ "$line" =~ ^[[:space:]]*-\ at\ \'([^\']*)\':\ (.*)$ ]]; then
+            key_path="${BASH_REMATCH[1]#/}"
+            key_path="${key_path//\//.}"
+            message="${BASH_REMATCH[2]}"
+        else
+            continue
+        fi
+
+        # Point additional-property errors at the offending key
+        if [[ "$message" =~ additional\ propert(y|ies)\ \'([^\']+)\' ]]; then
+            key_path="${key_path:+$key_path.}${BASH_REMATCH[2]}"
+        fi
+
+        location=$(_gol_locate_config_key "$config_file" "$key_path")
+
+        # Replace the full enum listing with something readable
+        if [[ "$message" == "value must be one of"* ]]; then
+            value=$(_gol_config_value "$config_file" "$key_path")
+            if [[ "$key_path" =~ ^(linters|formatters)\.(enable|disable)\. ]]; then
+                message="unknown linter${value:+ '$value'}"
+            else
+                message="invalid value${value:+ '$value'}"
+            fi
+        fi
+
+        echo "${location:-?}: ${key_path:-(root)}: $message"
+    done <<< "$verify_output"
+}
+
+# Validate an edited golangci-lint config file
+# Uses golangci-lint config verify when installed, plus an offline scan for
+# v1-only keys in v2 configs
+# Args: config_file
+# Exits via _gol_safe_exit when there is something to report
+_gol_check_golangci_config() {
+    local config_file="${1}"
+    local config_dir config_name
+    config_dir=$(dirname "$config_file")
+    config_name=$(basename "$config_file")
+
+    local findings=""
+    local notes=""
+
+    if command -v golangci-lint &>/dev/null; then
+        local verify_exit=0
+        local verify_output
+        verify_output=$(
+            cd "$config_dir" && _gol_run_with_timeout "$_GOL_CONFIG_VERIFY_TIMEOUT" \
+                golangci-lint config verify --config "$config_name" 2>&1
+        ) || verify_exit=$?
+
+        if [[ $verify_exit -ne 0 ]]; then
+            findings=$(_gol_parse_config_verify_output "$config_file" "$verify_output")
+
+            if [[ -z "$findings" ]]; then
+                # Not a schema error (unsupported version, unknown command, ...)
+                if [[ "$verify_output" == *"unsupported version of the configuration"* ]]; then
+                    notes="This is a v1 config but golangci-lint v2 is installed. Run 'golangci-lint migrate' to convert it."
+                elif [[ "$verify_output" == *"unknown command"* ]]; then
+                    notes="golangci-lint config verify is not available (requires v1.58+); only the offline checks ran."
+                    verify_exit=0
+                fi
+                if [[ $verify_exit -ne 0 ]]; then
+                    findings="?: $(echo "$verify_output" | grep -v '^[[:space:]]*$' | head -5 | paste -sd' ' -)"
+                fi
+            fi
+        fi
+    fi
+
+    if [[ "$(_gol_golangci_config_version "$config_file")" == "2" ]]; then
+        local v1_findings
+        v1_findings=$(_gol_scan_v1_only_keys "$config_file")
+        if [[ -n "$v1_findings" ]]; then
+            findings="${findings:+$findings
+}$v1_findings"
+        fi
+    fi
+
+    if [[ -z "$findings" ]]; then
+        if [[ -n "$notes" ]]; then
+            _gol_safe_exit "allow" "golangci-lint config was not fully verified" "PostToolUse" "$notes"
+        fi
+        return 0
+    fi
+
+    # Sort by line number, drop duplicates, and prefix with the file name
+    findings=$(echo "$findings" | sort -t: -k1,1n -s | awk '!seen[$0]++' | sed "s|^|$config_name:|")
+
+    local finding_count
+    finding_count=$(echo "$findings" | grep -c "^")
+
+    _gol_safe_exit "block" \
+        "golangci-lint config has $finding_count problem(s)" \
+        "PostToolUse" \
+        "$findings${notes:+
+
+$notes}"
+}
@@ -0,0 +1,321 @@
+#!/usr/bin/env bash
+# Test impact analysis for go-lint plugin
+# This file should be sourced after go-lint-common.sh and go-lint-analyzers.sh,
+# not executed directly
+#
+# Go files changed in the working tree (git diff HEAD plus untracked files) are
+# mapped to their packages, and every test package whose test binary imports
+# one of them, directly or transitively, is selected. Optionally the selection
+# is narrowed to the TestXxx functions that can reach a changed function in a
+# syntax-level call graph (go-lint-analyze -callgraph).
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Collect changed line ranges of Go files in the working tree
+# Deleted files are listed with the range 0..-1
+# Args: project_root
+# Returns: "file<TAB>start<TAB>end" lines relative to the project root;
+#          1 if the project is not in a git work tree
+_gol_impact_changed_ranges() {
+    local project_root="${1}"
+
+    if ! command -v git &>/dev/null \
+        || ! git -C "$project_root" rev-parse --is-inside-work-tree &>/dev/null; then
+        return 1
+    fi
+
+    {
+        git -C "$project_root" diff -U0 --no-color --no-ext-diff --relative HEAD -- '*.go' 2>/dev/null \
+            | awk '
+                /^--- a\// { old = substr($0, 7) }
+                /^\+\+\+ / {
+                    file = ($2 == "/dev/null") ? "" : substr($0, 7)
+                    if (file == "") { print old "\t0\t-1" }
+                }
+                /^@@ / && file != "" {
+                    split($3, hunk, ",")
+                    start = substr(hunk[1], 2) + 0
+                    count = (2 in hunk) ? hunk[2] + 0 : 1
+                    print file "\t" start "\t" (start + count - 1)
+                }
+            ' || true
+
+        local file lines
+        while IFS= read -r file; do
+            lines=$(wc -l < "$project_root/$file" | tr -d ' ')
+            printf '%s\t1\t%s\n' "$file" "$lines"
+        done < <(git -C "$project_root" ls-files --others --exclude-standard -- '*.go' 2>/dev/null || true)
+    } | grep -v '^vendor/' || true
+}
+
+# Map changed files to the import paths of their packages
+# Args: project_root, changed_ranges
+# Returns: one import path per line
+_gol_impact_changed_packages() {
+    local project_root="${1}"
+    local ranges="${2}"
+
+    local dirs=()
+    local dir
+    while IFS= read -r dir; do
+        if [[ -n "$dir" && -d "$project_root/$dir" ]]; then
+            dirs+=("./$dir")
+        fi
+    done < <(echo "$ranges" | cut -f1 | sed 's|/[^/]*$||; s|^[^/]*\.go$|.|' | sort -u)
+
+    if [[ ${#dirs[@]} -eq 0 ]]; then
+        return 0
+    fi
+
+    (
+        cd "$project_root" && go list -e -f '{{if .Name}}{{.ImportPath}}{{end}}' "${dirs[@]}" 2>/dev/null
+    ) | grep -v '^$' | sort -u || true
+}
+
+# Select the test packages whose test binaries import a changed package
+# Each selection names the shortest import chain to a changed package
+# Args: project_root, package_pattern, changed_packages
+# Returns: "package<TAB>reason" lines
+_gol_impact_select_packages() {
+    local project_root="${1}"
+    local pattern="${2}"
+    local changed="${3}"
+
+    local graph
+    graph=$(
+        cd "$project_root" && go list -e -deps -test \
+            -f '{{if not .Standard}}{{.ImportPath}}{{"\t"}}{{join .Imports " "}}{{end}}' "$pattern" 2>/dev/null
+    ) || true
+
+    # Breadth-first from each test main ("pkg.test") to the nearest changed
+    # package; " [pkg.test]" variants and the external pkg_test package are
+    # shown as the packages they stand for
+    awk -F'\t' '
+        function base(name) {
+            sub(/ \[.*\]$/, "", name)
+            return name
+        }
+        FILENAME == ARGV[1] { if ($0 != "") changed[$0] = 1; next }
+        $1 != "" {
+            nodes[$1] = 1
+            imports[$1] = $2
+            if ($1 ~ /\.test$/) mains[++main_count] = $1
+        }
+        END {
+            for (m = 1; m <= main_count; m++) {
+                main = mains[m]
+                pkg = substr(main, 1, length(main) - 5)
+                if (pkg in changed) {
+                    print pkg "\tchanged package"
+                    continue
+                }
+
+                delete parent
+                delete seen
+                head = 1; tail = 1
+                queue[1] = main; seen[main] = 1
+                found = ""
+                while (head <= tail && found == "") {
+                    node = queue[head++]
+                    n = split(imports[node], deps, " ")
+                    for (i = 1; i <= n; i++) {
+                        dep = deps[i]
+                        if (dep in seen || !(dep in nodes)) continue
+                        seen[dep] = 1
+                        parent[dep] = node
+                        if (base(dep) in changed) { found = dep; break }
+                        queue[++tail] = dep
+                    }
+                }
+                if (found == "") continue
+
+                chain = ""; last = ""; hops = 0
+                for (node = found; node != main; node = parent[node]) {
+                    name = base(node)
+                    if (name == pkg "_test") name = pkg
+                    if (name == last) continue
+                    chain = (chain == "") ? name : name " -> " chain
+                    last = name
+                    hops++
+                }
+                if (hops <= 2) {
+                    print pkg "\timports " base(found)
+                } else {
+                    print pkg "\timports " base(found) " via " chain
+                }
+            }
+        }
+    ' <(echo "$changed") <(echo "$graph") | sort
+}
+
+# Narrow the selection to tests that reach a changed function
+# Args: project_root, changed_ranges
+# Returns: go-lint-analyze -callgraph output ("test<TAB>pkg<TAB>Test<TAB>chain"
+#          and "outside<TAB>position" lines); 1 if the analyzer is unavailable
+_gol_impact_call_graph() {
+    local project_root="${1}"
+    local ranges="${2}"
+
+    local binary
+    binary=$(_gol_analyzer_binary) || true
+    if [[ -z "$binary" ]]; then
+        return 1
+    fi
+
+    local ranges_file
+    ranges_file=$(mktemp)
+    echo "$ranges" > "$ranges_file"
+
+    local exit_code=0
+    (
+        cd "$project_root" \
+            && go list -e -json=ImportPath,Dir,GoFiles,CgoFiles,TestGoFiles,XTestGoFiles ./... 2>/dev/null \
+            | "$binary" -callgraph -root "$project_root" -changes "$ranges_file" 2>/dev/null
+    ) || exit_code=$?
+    rm -f "$ranges_file"
+    return "$exit_code"
+}
+
+# Run the impact analysis for a target
+# Args: project_root, package_pattern, use_call_graph (true/false)
+# Sets: _gol_IMPACT_FILE_COUNT, _gol_IMPACT_CHANGED (import paths),
+#       _gol_IMPACT_SELECTED ("package<TAB>reason"), _gol_IMPACT_TESTS
+#       ("package<TAB>Test<TAB>chain", call graph only), _gol_IMPACT_SKIPPED
+#       (packages no test reaches) and _gol_IMPACT_NARROWING (how tests were
+#       picked)
+# Returns: 1 if the project is not in a git work tree
+_gol_impact_analyze() {
+    local project_root="${1}"
+    local pattern="${2}"
+    local use_call_graph="${3:-false}"
+
+    _gol_IMPACT_FILE_COUNT=0
+    _gol_IMPACT_CHANGED=""
+    _gol_IMPACT_SELECTED=""
+    _gol_IMPACT_TESTS=""
+    _gol_IMPACT_SKIPPED=""
+    _gol_IMPACT_NARROWING="package imports"
+
+    local ranges
+    ranges=$(_gol_impact_changed_ranges "$project_root") || return 1
+    if [[ -z "$ranges" ]]; then
+        return 0
+    fi
+    _gol_IMPACT_FILE_COUNT=$(echo "$ranges" | cut -f1 | sort -u | grep -c "^")
+
+    _gol_IMPACT_CHANGED=$(_gol_impact_changed_packages "$project_root" "$ranges")
+    if [[ -z "$_gol_IMPACT_CHANGED" ]]; then
+        return 0
+    fi
+    _gol_IMPACT_SELECTED=$(_gol_impact_select_packages "$project_root" "$pattern" "$_gol_IMPACT_CHANGED")
+
+    if [[ "$use_call_graph" != true || -z "$_gol_IMPACT_SELECTED" ]]; then
+        return 0
+    fi
+
+    local graph
+    if ! graph=$(_gol_impact_call_graph "$project_root" "$ranges"); then
+        _gol_IMPACT_NARROWING="package imports (call graph unavailable: go-lint-analyze could not be built)"
+        return 0
+    fi
+
+    local outside
+    outside=$(echo "$graph" | awk -F'\t' '$1 == "outside" { print $2 }' | head -n 3 | paste -sd, - | sed 's/,/, /g')
+    if [[ -n "$outside" ]]; then
+        _gol_IMPACT_NARROWING="package imports (call graph skipped: changes outside functions at $outside)"
+        return 0
+    fi
+
+    # Keep selected packages that still have a test reaching the change
+    _gol_IMPACT_NARROWING="call graph"
+    _gol_IMPACT_TESTS=$(awk -F'\t' '
+        FILENAME == ARGV[1] { selected[$1] = 1; next }
+        $1 == "test" && ($2 in selected) { print $2 "\t" $3 "\t" $4 }
+    ' <(echo "$_gol_IMPACT_SELECTED") <(echo "$graph"))
+    _gol_IMPACT_SKIPPED=$(awk -F'\t' '
+        FILENAME == ARGV[1] { if ($1 != "") reached[$1] = 1; next }
+        !($1 in reached) { print $1 }
+    ' <(echo "$_gol_IMPACT_TESTS") <(echo "$_gol_IMPACT_SELECTED"))
+    _gol_IMPACT_SELECTED=$(awk -F'\t' '
+        FILENAME == ARGV[1] { if ($1 != "") reached[$1] = 1; next }
+        $1 in reached
+    ' <(echo "$_gol_IMPACT_TESTS") <(echo "$_gol_IMPACT_SELECTED"))
+}
+
+# Format the selection as a markdown list with the reason for each package
+# and, after call graph narrowing, each test
+# Returns: list lines
+_gol_impact_format_selection() {
+    awk -F'\t' '
+        FILENAME == ARGV[1] {
+            if ($1 == "") next
+            chain = $3
+            if (index(chain, " -> ") == 0) chain = "changed"
+            tests[$1] = tests[$1] "  - `" $2 "`: " chain "\n"
+            next
+        }
+        $1 != "" {
+            print "- **" $1 "** " $2
+            printf "%s", tests[$1]
+        }
+    ' <(echo "$_gol_IMPACT_TESTS") <(echo "$_gol_IMPACT_SELECTED")
+}
+
+# Run the selected tests
+# After call graph narrowing each package runs only its selected tests; the
+# packages share timeout_seconds, and the rest are skipped once it runs out
+# Args: project_root, timeout_seconds
+# Sets: _gol_IMPACT_OUTPUT, _gol_IMPACT_EXIT (124 on timeout)
+_gol_impact_run_tests() {
+    local project_root="${1}"
+    local timeout_seconds="${2}"
+
+    # Within an enclosing deadline, if any, for this call only
+    local limit
+    limit=$(_gol_deadline_limit "$timeout_seconds")
+    local _GOL_DEADLINE=""
+    _gol_start_deadline "$limit"
+
+    _gol_IMPACT_OUTPUT=""
+    _gol_IMPACT_EXIT=0
+
+    local packages=()
+    local pkg
+    while IFS= read -r pkg; do
+        if [[ -n "$pkg" ]]; then
+            packages+=("$pkg")
+        fi
+    done < <(echo "$_gol_IMPACT_SELECTED" | cut -f1)
+
+    if [[ ${#packages[@]} -eq 0 ]]; then
+        return 0
+    fi
+
+    if [[ -z "$_gol_IMPACT_TESTS" ]]; then
+        _gol_IMPACT_OUTPUT=$(
+            cd "$project_root" && _gol_run_with_timeout "$timeout_seconds" go test "${packages[@]}" 2>&1
+        ) || _gol_IMPACT_EXIT=$?
+        return 0
+    fi
+
+    local run_pattern output exit_code
+    for pkg in "${packages[@]}"; do
+        run_pattern=$(echo "$_gol_IMPACT_TESTS" | awk -F'\t' -v pkg="$pkg" '$1 == pkg { print $2 }' | paste -sd'|' -)
+        exit_code=0
+        output=$(
+            cd "$project_root" && _gol_run_with_timeout "$timeout_seconds" go test -run "^($run_pattern)\$" "$pkg" 2>&1
+        ) || exit_code=$?
+        _gol_IMPACT_OUTPUT="${_gol_IMPACT_OUTPUT:+$_gol_IMPACT_OUTPUT
+}$output"
+        if [[ $exit_code -ne 0 && $_gol_IMPACT_EXIT -ne 124 ]]; then
+            _gol_IMPACT_EXIT=$exit_code
+        fi
+        if [[ $exit_code -eq 124 ]]; then
+            break
+        fi
+    done
+}
@@ -0,0 +1,275 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Modernize Go code after raising the go directive
+# Usage: go-lint-modernize.sh [directory] [--apply]
+#
+# Lists the rewrites the modernize analyzers suggest (min/max builtins,
+# slices/maps helpers, range-over-int, any, ...) with a unified diff. With
+# --apply the rewrites are made and go vet runs afterwards. Go 1.26+ ships the
+# analyzers in go fix; older toolchains run their own go fix rewrites, plus the
+# standalone modernize binary when it is installed.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+
+# Maximum rewrites listed and diff lines shown
+MODERNIZE_MAX_ENTRIES=30
+MODERNIZE_MAX_DIFF_LINES=300
+
+# Parse arguments
+TARGET_DIR="."
+APPLY=false
+for arg in "$@"; do
+    case "$arg" in
+        --apply) APPLY=true ;;
+        *) TARGET_DIR="$arg" ;;
+    esac
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Modernize Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go jq; then
+    echo "## Go Modernize Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Modernize Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+FIX_TARGET="$_gol_PACKAGE_PATTERN"
+
+# Pick the fixers: go fix runs the modernize analyzers from Go 1.26 on. Older
+# go fix only has its own rewrites, so the modernize binary runs after it when
+# installed
+GO_VERSION=$(cd "$PROJECT_ROOT" && go env GOVERSION 2>/dev/null || echo "")
+GO_MINOR=$(echo "$GO_VERSION" | sed -n 's/^go1\.\([0-9]*\).*/\1/p')
+LEGACY_FIX=false
+VETTOOL=""
+if [[ -n "$GO_MINOR" && "$GO_MINOR" -ge 26 ]]; then
+    FIXER="go fix"
+    VETTOOL=$(cd "$PROJECT_ROOT" && go tool -n fix 2>/dev/null || echo "")
+else
+    LEGACY_FIX=true
+    FIXER="go fix"
+    if command -v modernize &>/dev/null; then
+        FIXER="go fix and modernize"
+        VETTOOL=$(command -v modernize)
+    fi
+fi
+
+# Directory holding the target packages, for go tool fix
+FIX_DIR="${FIX_TARGET%/...}"
+
+# Run the fixers on the target
+# Args: mode ("diff" or "apply")
+# Returns: fixer output; the exit code is ignored since -diff exits 1 on changes
+run_fixer() {
+    local mode="${1}"
+    (
+        cd "$PROJECT_ROOT" || exit 1
+        if [[ "$LEGACY_FIX" == false ]]; then
+            case "$mode" in
+                diff) go fix -diff "$FIX_TARGET" ;;
+                apply) go fix "$FIX_TARGET" ;;
+            esac
+            exit 0
+        fi
+        # go fix has no dry run of its own before Go 1.26
+        case "$mode" in
+            diff) go tool fix -diff "$FIX_DIR" 2>/dev/null ;;
+            apply) go fix "$FIX_TARGET" ;;
+        esac
+        if [[ -n "$VETTOOL" ]]; then
+            case "$mode" in
+                diff) modernize -diff "$FIX_TARGET" ;;
+                apply) modernize -fix "$FIX_TARGET" ;;
+            esac
+        fi
+    ) 2>&1 || true
+}
+
+# Files older go fix would rewrite, as "file<TAB>go fix<TAB>fixes"; it reports
+# "file: fixed name..." on stderr
+LEGACY_REWRITES=""
+LEGACY_ERRORS=""
+if [[ "$LEGACY_FIX" == true ]]; then
+    LEGACY_OUTPUT=$(cd "$PROJECT_ROOT" && go tool fix -diff "$FIX_DIR" 2>&1 >/dev/null) || true
+    LEGACY_REWRITES=$(echo "$LEGACY_OUTPUT" | sed -n 's|^\./||; s|^\(.*\.go\): fixed \(.*\)$|\1\tgo fix\t\2|p')
+    LEGACY_ERRORS=$(echo "$LEGACY_OUTPUT" | grep -v -e '^$' -e '\.go: fixed ' || true)
+fi
+
+# List suggested rewrites as "file:line:col<TAB>analyzer<TAB>message" through
+# go vet -json with the modernize analyzers as vet tool
+VET_RAN=false
+VET_OUTPUT=""
+if [[ "$LEGACY_FIX" == false || -n "$VETTOOL" ]]; then
+    VET_RAN=true
+    VET_OUTPUT=$(
+        cd "$PROJECT_ROOT" && go vet -json -vettool="$VETTOOL" "$FIX_TARGET" 2>&1
+    ) || true
+fi
+
+# go vet -json prints pretty JSON objects between "# pkg" headers and plain
+# text errors; keep the JSON and remember the rest
+VET_ERRORS=$(echo "$VET_OUTPUT" | awk '
+    /^\{$/ { depth = 1; next }
+    depth && /^\}$/ { depth = 0; next }
+    depth { next }
+    /^#/ || /^$/ || /^\{\}$/ { next }
+    { print }
+')
+REWRITES=$(echo "$VET_OUTPUT" | awk '
+    /^\{$/ { depth = 1 }
+    depth { print }
+    depth && /^\}$/ { depth = 0 }
+' | jq -rs --arg root "$PROJECT_ROOT/" '
+    [.[] | to_entries[] | .value | to_entries[]
+     | .key as $analyzer | (.value | arrays)[]
+     | {posn: (.posn | ltrimstr($root)), analyzer: $analyzer, message}]
+    | unique_by(.posn, .analyzer)
+    | sort_by(.posn | split(":") | [.[0], (.[1] | tonumber), (.[2] | tonumber)])[]
+    | "\(.posn)\t\(.analyzer)\t\(.message)"
+' 2>/dev/null || echo "")
+
+# A fixer that ran and printed errors without suggesting anything failed;
+# report a failure only when every fixer that ran did
+VET_FAILED=false
+if [[ -z "$REWRITES" && -n "$VET_ERRORS" ]]; then
+    VET_FAILED=true
+fi
+LEGACY_FAILED=false
+if [[ -z "$LEGACY_REWRITES" && -n "$LEGACY_ERRORS" ]]; then
+    LEGACY_FAILED=true
+fi
+if [[ "$LEGACY_FIX" == "$LEGACY_FAILED" && "$VET_RAN" == "$VET_FAILED" ]]; then
+    echo "## Go Modernize Failed"
+    echo ""
+    echo "$FIXER could not analyze the target:"
+    echo '```'
+    printf '%s\n%s\n' "$LEGACY_ERRORS" "$VET_ERRORS" | grep -v '^$'
+    echo '```'
+    exit 1
+fi
+REWRITES=$(printf '%s\n%s' "$LEGACY_REWRITES" "$REWRITES" | grep -v '^$' || true)
+
+GO_DIRECTIVE=$(awk '$1 == "go" { print $2; exit }' "$PROJECT_ROOT/go.mod" 2>/dev/null || echo "")
+
+echo "## Go Modernize Report"
+echo ""
+echo "**Target:** $FIX_TARGET"
+echo "**Project root:** $PROJECT_ROOT"
+if [[ -n "$GO_DIRECTIVE" ]]; then
+    echo "**Go directive:** $GO_DIRECTIVE"
+fi
+echo "**Fixer:** $FIXER (${GO_VERSION:-unknown Go version})"
+if [[ "$APPLY" == true ]]; then
+    echo "**Mode:** apply"
+else
+    echo "**Mode:** dry run (pass --apply to rewrite files)"
+fi
+echo ""
+
+# Older toolchains without modernize only get go fix's own rewrites
+if [[ "$LEGACY_FIX" == true && -z "$VETTOOL" ]]; then
+    echo "${GO_VERSION:-This Go toolchain} has no modernize analyzers in go fix (Go 1.26+) and modernize is not installed, so only go fix's own rewrites are listed. Install modernize for the rest:"
+    echo ""
+    echo '```bash'
+    echo "go install golang.org/x/tools/gopls/internal/analysis/modernize/cmd/modernize@latest"
+    echo '```'
+    echo ""
+fi
+
+if [[ "$VET_FAILED" == true ]]; then
+    echo "modernize could not analyze the target, so only go fix's own rewrites are listed:"
+    echo '```'
+    echo "$VET_ERRORS"
+    echo '```'
+    echo ""
+fi
+
+if [[ -z "$REWRITES" ]]; then
+    echo "No modernizations suggested for this go directive."
+    exit 0
+fi
+
+REWRITE_COUNT=$(echo "$REWRITES" | grep -c "^")
+FILE_COUNT=$(echo "$REWRITES" | cut -f1 | cut -d: -f1 | sort -u | grep -c "^")
+
+echo "### Summary"
+echo ""
+echo "- **Rewrites:** $REWRITE_COUNT"
+echo "- **Files:** $FILE_COUNT"
+echo ""
+
+echo "### Rewrites"
+echo ""
+echo "$REWRITES" | head -n "$MODERNIZE_MAX_ENTRIES" | awk -F'\t' '{ print "- **" $1 "** [" $2 "] " $3 }'
+echo ""
+if [[ $REWRITE_COUNT -gt $MODERNIZE_MAX_ENTRIES ]]; then
+    echo "_...and $((REWRITE_COUNT - MODERNIZE_MAX_ENTRIES)) more_"
+    echo ""
+fi
+
+if [[ "$APPLY" == false ]]; then
+    DIFF=$(run_fixer diff)
+    DIFF_LINES=$(echo "$DIFF" | grep -c "^" || true)
+
+    echo "### Diff"
+    echo ""
+    echo '```diff'
+    echo "$DIFF" | head -n "$MODERNIZE_MAX_DIFF_LINES" | sed "s|$PROJECT_ROOT/||g"
+    echo '```'
+    echo ""
+    if [[ $DIFF_LINES -gt $MODERNIZE_MAX_DIFF_LINES ]]; then
+        echo "_...diff truncated, $((DIFF_LINES - MODERNIZE_MAX_DIFF_LINES)) more lines_"
+        echo ""
+    fi
+    exit 0
+fi
+
+FIX_OUTPUT=$(run_fixer apply)
+if [[ -n "$FIX_OUTPUT" ]]; then
+    echo "### Fixer Output"
+    echo ""
+    echo '```'
+    echo "$FIX_OUTPUT"
+    echo '```'
+    echo ""
+fi
+
+# The rewrites must leave the code compiling and vet-clean
+VET_EXIT=0
+POST_VET=$(cd "$PROJECT_ROOT" && go vet "$FIX_TARGET" 2>&1) || VET_EXIT=$?
+
+echo "### go vet"
+echo ""
+if [[ $VET_EXIT -eq 0 ]]; then
+    echo "No issues found after applying the rewrites."
+    exit 0
+fi
+
+echo '```'
+echo "$POST_VET"
+echo '```'
+exit 1
@@ -0,0 +1,286 @@
+#!/usr/bin/env bash
+# Module file checks for go-lint plugin (go.mod, go.work, go.sum)
+# This file should be sourced after go-lint-common.sh, not executed directly
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Time limit for go commands run against module files (seconds)
+_GOL_MODFILE_TIMEOUT="${GO_LINT_VET_TIMEOUT:-20}"
+
+# Check if a path is a module file handled by this library
+# Args: file_path
+# Returns: 0 for go.mod, go.work or go.sum
+_gol_is_module_file() {
+    case "$(basename "${1}")" in
+        go.mod|go.work|go.sum) return 0 ;;
+        *) return 1 ;;
+    esac
+}
+
+# Dispatch checks for an edited module file
+# Args: file_path, hook_input (JSON)
+# Exits via _gol_safe_exit when there is something to report
+_gol_check_module_file() {
+    local file_path="${1}"
+    local input="${2}"
+
+    case "$(basename "$file_path")" in
+        go.mod) _gol_check_go_mod "$file_path" "$input" ;;
+        go.work) _gol_check_go_work "$file_path" ;;
+        go.sum) _gol_warn_go_sum "$file_path" ;;
+    esac
+}
+
+# Normalise and validate go.mod, check direct requirements are cached, and
+# review loops and modernize rewrites affected by a go directive the edit
+# changed
+# Args: go_mod_path, hook_input (JSON)
+_gol_check_go_mod() {
+    local go_mod="${1}"
+    local input="${2}"
+    local mod_dir
+    mod_dir=$(dirname "$go_mod")
+
+    # go mod edit -fmt rewrites the file in canonical form and fails on syntax errors
+    local fmt_exit=0
+    local fmt_output
+    fmt_output=$(
+        cd "$mod_dir" && go mod edit -fmt go.mod 2>&1
+    ) || fmt_exit=$?
+
+    if [[ $fmt_exit -ne 0 ]]; then
+        _gol_safe_exit "block" \
+            "go.mod has syntax errors" \
+            "PostToolUse" \
+            "$fmt_output"
+    fi
+
+    # Resolve the module graph offline; -e records per-module errors instead of failing
+    local list_json
+    list_json=$(
+        cd "$mod_dir" && GOFLAGS=-mod=mod GOPROXY=off \
+            _gol_run_with_timeout "$_GOL_MODFILE_TIMEOUT" go list -m -e -json all 2>/dev/null
+    ) || true
+
+    # Only direct requirements are reported; indirect ones follow from them
+    local missing
+    missing=$(echo "$list_json" | jq -rs '
+        map(select((.Main | not) and (.Indirect | not) and .Error != null))
+        | map("- \(.Path) \(.Version // ""): \(.Error.Err)")
+        | join("\n")
+    ' 2>/dev/null || echo "")
+
+    # Missing modules are reported on their own, or added to the go
+    # directive report below
+    local missing_reason="" missing_note=""
+    if [[ -n "$missing" ]]; then
+        local missing_count
+        missing_count=$(echo "$missing" | grep -c "^")
+        missing_reason="go.mod requires $missing_count module(s) missing from the module cache"
+        missing_note="Not available offline (GOPROXY=off):
+$missing
+
+Run 'go mod download' or 'go mod tidy' to fetch them."
+    fi
+
+    local previous current
+    previous=$(_gol_previous_go_directive "$input")
+    current=$(_gol_go_directive < "$go_mod")
+    if [[ -z "$previous" || -z "$current" || "$previous" == "$current" ]]; then
+        if [[ -n "$missing_note" ]]; then
+            _gol_safe_exit "allow" "$missing_reason" "PostToolUse" "$missing_note"
+        fi
+        return 0
+    fi
+
+    local modernize_hint="Preview the modernize rewrites with '/go-lint:modernize $mod_dir', then apply them with '/go-lint:modernize $mod_dir --apply'."
+    local raised=false
+    if _gol_go_version_less "$previous" "$current"; then
+        raised=true
+    fi
+
+    # Crossing 1.22 switches loop variables between per-loop and per-iteration
+    local previous_per_loop=false current_per_loop=false
+    if _gol_go_version_less "$previous" 1.22; then
+        previous_per_loop=true
+    fi
+    if _gol_go_version_less "$current" 1.22; then
+        current_per_loop=true
+    fi
+
+    if [[ "$previous_per_loop" != "$current_per_loop" ]]; then
+        local loops
+        loops=$(_gol_loopvar_changes "$mod_dir")
+
+        if [[ -n "$loops" ]]; then
+            local loop_count semantics
+            loop_count=$(echo "$loops" | grep -c "^")
+            if [[ "$raised" == true ]]; then
+                semantics="From Go 1.22 every iteration gets its own copy of the loop variable."
+            else
+                semantics="Before Go 1.22 all iterations share one loop variable."
+            fi
+
+            local context="$semantics These loops capture their variable in a closure or take its address, so closures, goroutines and pointers created in them may see different values:
+$loops
+
+Check that each loop keeps its intended behaviour, or restore the go directive to $previous. List them again with 'go build -gcflags=-d=loopvar=2 ./...'."
+            if [[ "$raised" == true ]]; then
+                context="$context
+
+$modernize_hint"
+            fi
+            if [[ -n "$missing_note" ]]; then
+                context="$context
+
+$missing_note"
+            fi
+
+            _gol_safe_exit "block" \
+                "go directive change from $previous to $current changes loop variable semantics in $loop_count loop(s)" \
+                "PostToolUse" \
+                "$context"
+        fi
+    fi
+
+    # A raised go directive unlocks newer language features and library APIs
+    if [[ "$raised" == true ]]; then
+        _gol_safe_exit "allow" \
+            "go directive raised from $previous to $current" \
+            "PostToolUse" \
+            "Code in this module can now use Go $current features (min/max builtins, slices and maps helpers, range over int, any, ...).
+
+$modernize_hint${missing_note:+
+
+$missing_note}"
+    fi
+
+    if [[ -n "$missing_note" ]]; then
+        _gol_safe_exit "allow" "$missing_reason" "PostToolUse" "$missing_note"
+    fi
+}
+
+# List loops whose variable is captured or addressed, as reported by the
+# compiler's loopvar debug output (the loops whose behaviour differs between
+# per-loop and per-iteration variables); test files are not built
+# Args: mod_dir
+# Returns: "- file:line:col: loop variable x (heap-allocated)" lines, or empty string
+_gol_loopvar_changes() {
+    local mod_dir="${1}"
+
+    (
+        cd "$mod_dir" && GOFLAGS=-mod=mod GOPROXY=off \
+            _gol_run_with_timeout "$_GOL_MODFILE_TIMEOUT" go build -o /dev/null -gcflags=-d=loopvar=2 ./... 2>&1
+    ) | sed -n 's/^\(.*\.go:[0-9]*:[0-9]*\): loop variable \([^ ]*\) now per-iteration, \(.*\)$/- \1: loop variable \2 (\3)/p' \
+        | sort -u -t: -k1,1 -k2,2n -k3,3n || true
+}
+
+# Read the go directive from go.mod content on stdin
+# Returns: version such as 1.22 or 1.22.3, or empty string
+_gol_go_directive() {
+    awk '$1 == "go" { print $2; exit }'
+}
+
+# go directive of a go.mod before the edit, taken from the original file in
+# the tool response, or else from the go line the edit replaced
+# Args: hook_input (JSON)
+# Returns: version, or empty string when the edit did not touch the go line
+_gol_previous_go_directive() {
+    local input="${1}"
+
+    local original
+    original=$(echo "$input" | jq -r '
+        .tool_response | objects | .originalFile // .originalFileContents // empty
+    ' 2>/dev/null || true)
+    if [[ -n "$original" ]]; then
+        echo "$original" | _gol_go_directive
+        return 0
+    fi
+
+    # Removed lines of the patch, or the text an Edit or MultiEdit replaced
+    echo "$input" | jq -r '
+        (.tool_response | objects | .structuredPatch // [] | .[].lines[] | select(startswith("-")) | .[1:]),
+        (.tool_input | .old_string // empty, (.edits // [] | .[].old_string))
+    ' 2>/dev/null | _gol_go_directive || true
+}
+
+# Compare two Go versions numerically (1.9 < 1.21 < 1.21.1)
+# Args: version_a, version_b
+# Returns: 0 if version_a is older than version_b
+_gol_go_version_less() {
+    awk -v a="${1}" -v b="${2}" 'BEGIN {
+        na = split(a, pa, ".")
+        nb = split(b, pb, ".")
+        n = na > nb ? na : nb
+        for (i = 1; i <= n; i++) {
+            if (pa[i] + 0 < pb[i] + 0) exit 0
+            if (pa[i] + 0 > pb[i] + 0) exit 1
+        }
+        exit 1
+    }'
+}
+
+# Normalise and validate go.work, then check every use directive has a go.mod
+# Args: go_work_path
+_gol_check_go_work() {
+    local go_work="${1}"
+    local work_dir
+    work_dir=$(dirname "$go_work")
+
+    local fmt_exit=0
+    local fmt_output
+    fmt_output=$(
+        cd "$work_dir" && go work edit -fmt go.work 2>&1
+    ) || fmt_exit=$?
+
+    if [[ $fmt_exit -ne 0 ]]; then
+        _gol_safe_exit "block" \
+            "go.work has syntax errors" \
+            "PostToolUse" \
+            "$fmt_output"
+    fi
+
+    local work_json
+    work_json=$(cd "$work_dir" && go work edit -json go.work 2>/dev/null) || work_json=""
+
+    local missing=""
+    local disk_path module_dir
+    while IFS= read -r disk_path; do
+        [[ -z "$disk_path" ]] && continue
+
+        if [[ "$disk_path" == /* ]]; then
+            module_dir="$disk_path"
+        else
+            module_dir="$work_dir/$disk_path"
+        fi
+
+        if [[ ! -f "$module_dir/go.mod" ]]; then
+            missing="${missing:+$missing
+}- $disk_path"
+        fi
+    done < <(echo "$work_json" | jq -r '.Use // [] | .[].DiskPath' 2>/dev/null || true)
+
+    if [[ -n "$missing" ]]; then
+        local missing_count
+        missing_count=$(echo "$missing" | grep -c "^")
+
+        _gol_safe_exit "block" \
+            "go.work uses $missing_count directory(ies) without a go.mod" \
+            "PostToolUse" \
+            "use directives pointing at directories with no go.mod:
+$missing"
+    fi
+}
+
+# go.sum is owned by the go command; nudge towards regenerating it instead
+# Args: go_sum_path
+_gol_warn_go_sum() {
+    _gol_safe_exit "allow" \
+        "go.sum was edited directly" \
+        "PostToolUse" \
+        "go.sum is maintained by the go command and hand edits are easy to get wrong. Run 'go mod tidy' to regenerate it, then 'go mod verify' to check the cached modules."
+}
@@ -0,0 +1,268 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Mutation testing for a Go package
+# Usage: go-lint-mutate.sh [package-directory] [--all]
+#
+# Mutates the package's non-test files one change at a time (conditional
+# boundaries, negated conditions, arithmetic swaps, removed error returns; see
+# go-lint-analyze -mutate) and runs the package tests against each mutant in
+# parallel through go test -overlay, so the working tree is never modified.
+# Mutants no test detects are reported. By default only functions changed in
+# the working tree are mutated; --all mutates every function.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-impact.sh"
+
+# Time limit for one test run (seconds), parallel test runs, and the most
+# mutants tested
+MUTATE_TIMEOUT="${GO_LINT_MUTATE_TIMEOUT:-60}"
+MUTATE_JOBS="${GO_LINT_MUTATE_JOBS:-$(getconf _NPROCESSORS_ONLN 2>/dev/null || echo 4)}"
+MUTATE_MAX="${GO_LINT_MUTATE_MAX:-100}"
+
+# Parse arguments
+TARGET_DIR="."
+ALL_FUNCTIONS=false
+for arg in "$@"; do
+    case "$arg" in
+        --all) ALL_FUNCTIONS=true ;;
+        *) TARGET_DIR="$arg" ;;
+    esac
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go jq; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root; the target is a single package, not a pattern
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+PACKAGE="./$_gol_REL_TARGET"
+if [[ "$_gol_REL_TARGET" == "." ]]; then
+    PACKAGE="."
+fi
+
+PACKAGE_INFO=$(
+    cd "$PROJECT_ROOT" && go list -f '{{len .TestGoFiles}} {{len .XTestGoFiles}}{{range .GoFiles}}{{"\n"}}{{$.Dir}}/{{.}}{{end}}' "$PACKAGE" 2>&1
+) || {
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "$PACKAGE is not a Go package:"
+    echo ""
+    echo '```'
+    echo "$PACKAGE_INFO"
+    echo '```'
+    exit 1
+}
+
+SOURCE_FILES=()
+while IFS= read -r file; do
+    if [[ -n "$file" ]]; then
+        SOURCE_FILES+=("$file")
+    fi
+done < <(echo "$PACKAGE_INFO" | tail -n +2)
+
+if [[ "$(echo "$PACKAGE_INFO" | head -n 1)" == "0 0" ]]; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "$PACKAGE has no tests, so every mutant would survive."
+    exit 1
+fi
+
+if [[ ${#SOURCE_FILES[@]} -eq 0 ]]; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "$PACKAGE has no non-test Go files to mutate."
+    exit 1
+fi
+
+BINARY=$(_gol_analyzer_binary) || true
+if [[ -z "$BINARY" ]]; then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "Could not build go-lint-analyze from $PLUGIN_ROOT/analyzers."
+    exit 1
+fi
+
+WORK_DIR=$(mktemp -d)
+trap 'rm -rf "$WORK_DIR"' EXIT
+
+# Limit mutations to changed functions unless --all (or without git)
+SCOPE="all functions"
+CHANGES_ARGS=()
+if [[ "$ALL_FUNCTIONS" != true ]]; then
+    if RANGES=$(_gol_impact_changed_ranges "$PROJECT_ROOT"); then
+        echo "$RANGES" > "$WORK_DIR/ranges.tsv"
+        CHANGES_ARGS=(-changes "$WORK_DIR/ranges.tsv")
+        SCOPE="changed functions"
+    else
+        SCOPE="all functions ($PROJECT_ROOT is not in a git work tree)"
+    fi
+fi
+
+mkdir -p "$WORK_DIR/mutants"
+if ! MUTANTS=$("$BINARY" -mutate -root "$PROJECT_ROOT" ${CHANGES_ARGS[@]+"${CHANGES_ARGS[@]}"} \
+    -out "$WORK_DIR/mutants" "${SOURCE_FILES[@]}" 2>&1); then
+    echo "## Go Mutation Testing Failed"
+    echo ""
+    echo "Could not generate mutants:"
+    echo ""
+    echo '```'
+    echo "$MUTANTS"
+    echo '```'
+    exit 1
+fi
+
+FUNC_COUNT=$(echo "$MUTANTS" | grep -c '^func' || true)
+MUTANT_LINES=$(echo "$MUTANTS" | grep '^mutant' || true)
+MUTANT_COUNT=$(echo "$MUTANT_LINES" | grep -c . || true)
+
+echo "## Go Mutation Report"
+echo ""
+echo "**Package:** $PACKAGE"
+echo "**Project root:** $PROJECT_ROOT"
+echo "**Scope:** $SCOPE"
+echo ""
+
+if [[ $FUNC_COUNT -eq 0 ]]; then
+    echo "No functions in $PACKAGE changed in the working tree. Pass --all to mutate every function."
+    exit 0
+fi
+
+if [[ $MUTANT_COUNT -eq 0 ]]; then
+    echo "The $FUNC_COUNT function(s) in scope have no conditions, arithmetic or error returns to mutate."
+    exit 0
+fi
+
+# Mutants only count against a passing baseline
+BASELINE_EXIT=0
+BASELINE_OUTPUT=$(
+    cd "$PROJECT_ROOT" && _gol_run_with_timeout "$MUTATE_TIMEOUT" \
+        go test -count=1 -vet=off -timeout "${MUTATE_TIMEOUT}s" "$PACKAGE" 2>&1
+) || BASELINE_EXIT=$?
+if [[ $BASELINE_EXIT -ne 0 ]]; then
+    echo "### Baseline"
+    echo ""
+    if [[ $BASELINE_EXIT -eq 124 ]]; then
+        echo "The tests of $PACKAGE did not finish within ${MUTATE_TIMEOUT}s (set GO_LINT_MUTATE_TIMEOUT to allow more)."
+    else
+        echo "The tests of $PACKAGE fail without mutations; fix them first."
+        echo ""
+        echo '```'
+        echo "$BASELINE_OUTPUT" | tail -n 20
+        echo '```'
+    fi
+    exit 1
+fi
+
+# Test one mutant and record killed, survived, timeout or invalid
+# Args: id, file (relative to the project root)
+# Writes: <work>/<id>.status
+test_mutant() {
+    local id="${1}"
+    local file="${2}"
+
+    jq -n --arg target "$PROJECT_ROOT/$file" --arg source "$WORK_DIR/mutants/$id.go" \
+        '{Replace: {($target): $source}}' > "$WORK_DIR/$id.overlay.json"
+
+    local exit_code=0
+    local output
+    output=$(
+        cd "$PROJECT_ROOT" && _gol_run_with_timeout "$MUTATE_TIMEOUT" \
+            go test -count=1 -vet=off -failfast -timeout "${MUTATE_TIMEOUT}s" \
+            -overlay "$WORK_DIR/$id.overlay.json" "$PACKAGE" 2>&1
+    ) || exit_code=$?
+
+    local status="killed"
+    if [[ $exit_code -eq 0 ]]; then
+        status="survived"
+    elif [[ $exit_code -eq 124 ]] || echo "$output" | grep -q '^panic: test timed out'; then
+        status="timeout"
+    elif echo "$output" | grep -q '\[build failed\]\|\[setup failed\]'; then
+        status="invalid"
+    fi
+    echo "$status" > "$WORK_DIR/$id.status"
+}
+
+# Run the mutants in batches of MUTATE_JOBS
+TESTED_LINES=$(echo "$MUTANT_LINES" | head -n "$MUTATE_MAX")
+RUNNING=0
+while IFS=$'\t' read -r _ id position _; do
+    test_mutant "$id" "${position%:*:*}" &
+    RUNNING=$((RUNNING + 1))
+    if [[ $RUNNING -ge $MUTATE_JOBS ]]; then
+        wait
+        RUNNING=0
+    fi
+done <<< "$TESTED_LINES"
+wait
+
+RESULTS=$(while IFS=$'\t' read -r _ id rest; do
+    printf '%s\t%s\n' "$(cat "$WORK_DIR/$id.status" 2>/dev/null || echo invalid)" "$rest"
+done <<< "$TESTED_LINES")
+
+# Count the results with a status
+# Args: status
+count_status() {
+    echo "$RESULTS" | awk -F'\t' -v status="${1}" '$1 == status { n++ } END { print n + 0 }'
+}
+KILLED=$(count_status killed)
+SURVIVED=$(count_status survived)
+TIMED_OUT=$(count_status timeout)
+INVALID=$(count_status invalid)
+TESTED=$(echo "$TESTED_LINES" | grep -c .)
+VALID=$((TESTED - INVALID))
+
+echo "### Summary"
+echo ""
+echo "- **Functions:** $FUNC_COUNT"
+echo "- **Mutants:** $TESTED"
+echo "- **Killed:** $KILLED"
+echo "- **Survived:** $SURVIVED"
+echo "- **Timed out:** $TIMED_OUT"
+echo "- **Did not compile:** $INVALID"
+if [[ $VALID -gt 0 ]]; then
+    echo "- **Mutation score:** $(((KILLED + TIMED_OUT) * 100 / VALID))%"
+fi
+echo ""
+
+if [[ $MUTANT_COUNT -gt $TESTED ]]; then
+    echo "Only the first $TESTED of $MUTANT_COUNT mutants were tested (set GO_LINT_MUTATE_MAX to test more)."
+    echo ""
+fi
+
+if [[ $SURVIVED -eq 0 ]]; then
+    echo "Every compiling mutant was detected by the tests of $PACKAGE."
+    exit 0
+fi
+
+echo "### Surviving Mutants"
+echo ""
+echo "$RESULTS" | awk -F'\t' '$1 == "survived" { printf "- **%s** %s: `%s` → `%s`\n", $2, $3, $4, $5 }'
+echo ""
+echo "No test fails when the code changes like this. Add a test that pins the behavior, or ignore the mutant if the change is equivalent."
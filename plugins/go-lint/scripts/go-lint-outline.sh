@@ -0,0 +1,180 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Outline the Go packages under a directory
+# Usage: go-lint-outline.sh [directory] [--depth N] [--exported] [--match REGEXP]
+#                           [--tests] [--no-docs]
+#
+# Prints every package's constants, variables, functions and types, with
+# constructors and methods grouped under their type, the module types that
+# implement each interface, and the first sentence of each doc comment, as one
+# short line per declaration (see analyzers/outline.go). Reading the outline is
+# much cheaper than reading the files, so Claude can find the API it needs
+# before opening any of them.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+
+# Time limit for listing and outlining the module (seconds)
+OUTLINE_TIMEOUT="${GO_LINT_OUTLINE_TIMEOUT:-60}"
+
+# Maximum lines of outline printed
+OUTLINE_MAX_LINES="${GO_LINT_OUTLINE_MAX_LINES:-400}"
+
+# Print a failure report and exit
+# Args: message lines...
+fail() {
+    echo "## Go Outline Failed"
+    echo ""
+    printf '%s\n' "$@"
+    exit 1
+}
+
+# Parse arguments; unset options fall back to .go-lint.json below
+TARGET_DIR="."
+DEPTH=""
+EXPORTED=""
+MATCH=""
+TESTS=false
+DOCS=""
+while [[ $# -gt 0 ]]; do
+    case "$1" in
+        --depth)
+            [[ $# -ge 2 ]] || fail "--depth needs a number"
+            DEPTH="$2"
+            shift
+            ;;
+        --depth=*) DEPTH="${1#--depth=}" ;;
+        --exported) EXPORTED=true ;;
+        --match)
+            [[ $# -ge 2 ]] || fail "--match needs a regular expression"
+            MATCH="$2"
+            shift
+            ;;
+        --match=*) MATCH="${1#--match=}" ;;
+        --tests) TESTS=true ;;
+        --no-docs) DOCS=false ;;
+        -*) fail "Unknown option: $1" \
+            "Usage: /go-lint:outline [directory] [--depth N] [--exported] [--match REGEXP] [--tests] [--no-docs]" ;;
+        *) TARGET_DIR="$1" ;;
+    esac
+    shift
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    fail "Target directory does not exist: $TARGET_DIR"
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go; then
+    fail "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+fi
+
+# Find project root
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    fail "Could not find Go project root (no go.mod, go.work, or .git found)" \
+        "Searched from: $TARGET_DIR"
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+
+# Project defaults
+if [[ -z "$DEPTH" ]]; then
+    DEPTH=$(_gol_project_config "$PROJECT_ROOT" '.outline.depth' -1)
+fi
+if [[ -z "$EXPORTED" ]]; then
+    EXPORTED=$(_gol_project_config "$PROJECT_ROOT" '.outline.exported' false)
+fi
+if [[ -z "$DOCS" ]]; then
+    DOCS=$(_gol_project_config "$PROJECT_ROOT" '.outline.docs' true)
+fi
+if [[ ! "$DEPTH" =~ ^-?[0-9]+$ ]]; then
+    fail "--depth must be a number, got: $DEPTH"
+fi
+
+BINARY=$(_gol_analyzer_binary) || true
+if [[ -z "$BINARY" ]]; then
+    fail "Could not build the go-lint analyzers in $_GOL_ANALYZERS_DIR"
+fi
+
+ARGS=(-outline -root "$PROJECT_ROOT" -target "$_gol_REL_TARGET" -depth "$DEPTH" "-exported=$EXPORTED" "-tests=$TESTS" "-docs=$DOCS")
+if [[ -n "$MATCH" ]]; then
+    ARGS+=(-match "$MATCH")
+fi
+
+# The whole module is listed so implementers outside the target are found
+OUTLINE_EXIT=0
+OUTLINE=$(
+    cd "$PROJECT_ROOT" \
+        && _gol_run_with_timeout "$OUTLINE_TIMEOUT" go list -e -json=ImportPath,Dir,GoFiles,CgoFiles,TestGoFiles,XTestGoFiles ./... 2>/dev/null \
+        | _gol_run_with_timeout "$OUTLINE_TIMEOUT" "$BINARY" "${ARGS[@]}" 2>&1
+) || OUTLINE_EXIT=$?
+if [[ $OUTLINE_EXIT -eq 124 ]]; then
+    fail "The outline did not finish within ${OUTLINE_TIMEOUT}s (override with GO_LINT_OUTLINE_TIMEOUT)"
+fi
+if [[ $OUTLINE_EXIT -ne 0 ]]; then
+    fail "$OUTLINE"
+fi
+
+FILTERS=()
+if [[ "$DEPTH" -ge 0 ]]; then
+    FILTERS+=("depth $DEPTH")
+fi
+if [[ "$EXPORTED" == "true" ]]; then
+    FILTERS+=("exported only")
+fi
+if [[ -n "$MATCH" ]]; then
+    FILTERS+=("names matching \`$MATCH\`")
+fi
+if [[ "$TESTS" == "true" ]]; then
+    FILTERS+=("with _test.go files")
+fi
+
+echo "## Go Outline"
+echo ""
+echo "**Target:** $_gol_PACKAGE_PATTERN"
+echo "**Project root:** $PROJECT_ROOT"
+if [[ ${#FILTERS[@]} -gt 0 ]]; then
+    FILTER_TEXT=$(printf '%s, ' "${FILTERS[@]}")
+    echo "**Filters:** ${FILTER_TEXT%, }"
+fi
+echo ""
+
+if [[ -z "$OUTLINE" ]]; then
+    echo "No declarations found under the target."
+    exit 0
+fi
+
+PACKAGE_COUNT=$(echo "$OUTLINE" | grep -c '^### ')
+LINE_COUNT=$(echo "$OUTLINE" | grep -c '^')
+echo "**Packages:** $PACKAGE_COUNT"
+echo ""
+
+if [[ $LINE_COUNT -le $OUTLINE_MAX_LINES ]]; then
+    echo "$OUTLINE"
+    exit 0
+fi
+
+# Cut the outline before the first package that does not fit; a first
+# package longer than the limit is cut inside its code block
+echo "$OUTLINE" | awk -v max="$OUTLINE_MAX_LINES" '
+    { lines[NR] = $0 }
+    /^### / && NR > 1 && NR <= max + 1 { cut = NR - 1 }
+    END {
+        if (cut == 0) cut = max
+        while (cut > 1 && lines[cut] == "") cut--
+        for (i = 1; i <= cut; i++) {
+            if (lines[i] ~ /^```/) open = !open
+            print lines[i]
+        }
+        if (open) print "```"
+    }
+'
+echo ""
+echo "Outline cut at the $OUTLINE_MAX_LINES-line limit ($LINE_COUNT lines in full, see GO_LINT_OUTLINE_MAX_LINES). Narrow it with a subdirectory, --depth, --exported or --match."
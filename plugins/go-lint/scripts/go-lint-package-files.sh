@@ -0,0 +1,164 @@
+#!/usr/bin/env bash
+# Non-Go package files for go-lint plugin (assembly, cgo sources, .syso)
+# This file should be sourced after go-lint-common.sh and go-lint-budget.sh,
+# not executed directly
+#
+# Assembly (.s), C/C++ sources and headers, and .syso objects next to .go files
+# are part of that Go package. go vet checks them through the package: asmdecl
+# compares assembly with the Go declarations it implements, cgo compiles the C
+# files, and cgocall checks the Go side of calls into C. An edit to one of
+# them vets the package and reports the diagnostics for the edited file.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Time limit for go vet on the package (seconds)
+_GOL_PACKAGE_FILE_TIMEOUT="${GO_LINT_VET_TIMEOUT:-20}"
+
+# go vet diagnostics on the Go side of cgo, reported for C file edits
+_GOL_CGO_VET_PATTERN='possibly passing Go type with embedded pointer to C|could not determine what C\.[A-Za-z_0-9]* refers to'
+
+# Check if a file is a non-Go file that the go command builds into a package
+# Args: file_path
+# Returns: 0 if it has a package file extension and sits next to .go files
+_gol_is_package_file() {
+    local file_path="${1}"
+
+    case "$file_path" in
+        *.s | *.S | *.sx | *.c | *.h | *.cc | *.cpp | *.cxx | *.hh | *.hpp | *.hxx | *.m | *.syso) ;;
+        *) return 1 ;;
+    esac
+
+    local go_file
+    for go_file in "$(dirname "$file_path")"/*.go; do
+        if [[ -f "$go_file" ]]; then
+            return 0
+        fi
+    done
+    return 1
+}
+
+# GOOS/GOARCH that a file name restricts the file to (name_GOOS_GOARCH.s,
+# name_GOARCH.s, name_GOOS.c), so assembly for another architecture is vetted
+# against that architecture
+# Args: file_name
+# Returns: "GOOS=... GOARCH=..." assignments (either may be missing), or empty
+#          string when the name has no constraint
+_gol_package_file_target() {
+    local name="${1%%.*}"
+
+    if [[ "$name" != *_* ]]; then
+        return 0
+    fi
+    name="${name#*_}"
+
+    local targets
+    targets=$(go tool dist list 2>/dev/null) || return 0
+
+    echo "$name" | awk -F'_' '
+        FILENAME == ARGV[1] {
+            split($0, pair, "/")
+            os[pair[1]] = 1
+            arch[pair[2]] = 1
+            next
+        }
+        {
+            n = split("_" $0, parts, "_")
+            if (n >= 3 && (parts[n - 1] in os) && (parts[n] in arch)) {
+                print "GOOS=" parts[n - 1] " GOARCH=" parts[n]
+            } else if (parts[n] in os) {
+                print "GOOS=" parts[n]
+            } else if (parts[n] in arch) {
+                print "GOARCH=" parts[n]
+            }
+        }
+    ' <(echo "$targets") -
+}
+
+# Filter go vet output down to diagnostics for a non-Go package file
+# go vet reports assembly with paths relative to the directory it ran in, but
+# the C compiler reports paths relative to the package directory. C file edits
+# also get the cgo diagnostics from the package's Go files.
+# Args: vet_output, rel_path (relative to the directory go vet ran in)
+# Returns: matching lines, unmodified
+_gol_filter_package_file_output() {
+    local vet_output="${1}"
+    local rel_path="${2#./}"
+    local file_name
+    file_name=$(basename "$rel_path")
+
+    # Diagnostics only; "file.c: In function ..." lines are compiler context
+    echo "$vet_output" | REL_PATH="$rel_path" FILE_NAME="$file_name" awk '
+        function at(line, path) {
+            return index(line, path ":") == 1 && substr(line, length(path) + 2) ~ /^[0-9]+:/
+        }
+        {
+            line = $0
+            sub(/^vet: /, "", line)
+            sub(/^\.\//, "", line)
+        }
+        at(line, ENVIRON["REL_PATH"]) || at(line, ENVIRON["FILE_NAME"])
+    '
+
+    case "$file_name" in
+        *.s | *.S | *.sx | *.syso) ;;
+        *) echo "$vet_output" | grep -E "^(vet: )?[^ :]+\.go:[0-9]+(:[0-9]+)?: ($_GOL_CGO_VET_PATTERN)" || true ;;
+    esac
+}
+
+# Vet the package of an edited non-Go package file
+# Args: file_path (absolute), project_root
+# Exits via _gol_safe_exit when there is something to report
+_gol_check_package_file() {
+    local file_path="${1}"
+    local project_root="${2}"
+
+    local rel_path rel_pkg_dir file_name
+    rel_path=$(_gol_get_relative_path "$project_root" "$file_path")
+    rel_pkg_dir=$(dirname "$rel_path")
+    file_name=$(basename "$file_path")
+
+    local target
+    target=$(_gol_package_file_target "$file_name")
+
+    local vet_exit=0
+    local vet_output
+    vet_output=$(
+        cd "$project_root" || exit 1
+        if [[ -n "$target" ]]; then
+            # shellcheck disable=SC2086 # target holds separate assignments
+            export $target
+        fi
+        _gol_run_with_timeout "$_GOL_PACKAGE_FILE_TIMEOUT" go vet "./$rel_pkg_dir" 2>&1
+    ) || vet_exit=$?
+
+    if [[ $vet_exit -eq 124 ]]; then
+        _gol_safe_exit "allow" \
+            "go vet timed out after ${_GOL_PACKAGE_FILE_TIMEOUT}s" \
+            "PostToolUse" \
+            "go vet ./$rel_pkg_dir did not finish within ${_GOL_PACKAGE_FILE_TIMEOUT}s; run it manually to check the package"
+    fi
+
+    if [[ $vet_exit -eq 0 ]]; then
+        return 0
+    fi
+
+    local filtered_output
+    filtered_output=$(_gol_filter_package_file_output "$vet_output" "$rel_path")
+    if [[ -z "$filtered_output" ]]; then
+        return 0
+    fi
+
+    local issue_count
+    issue_count=$(echo "$filtered_output" | grep -c "^")
+    filtered_output=$(_gol_budget_diagnostics "$filtered_output" "" \
+        "$(_gol_output_budget "$project_root")" "$file_name")
+
+    _gol_safe_exit "block" \
+        "go vet${target:+ ($target)} found $issue_count issue(s) in file" \
+        "PostToolUse" \
+        "$filtered_output"
+}
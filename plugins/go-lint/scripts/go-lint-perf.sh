@@ -0,0 +1,297 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Escape analysis and inlining report from the Go compiler's -m=2 output
+# Usage: go-lint-perf.sh [directory] [--all]
+#
+# Reports heap escapes, small functions that cannot be inlined and bounds
+# checks the compiler could not eliminate. Only functions in Go files changed
+# in the working tree are reported unless --all is given (or the project is
+# not a git repository).
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+
+# Compiler flags: -m=2 explains inlining and escape decisions, check_bce
+# reports every bounds check left in the generated code
+PERF_GCFLAGS="-m=2 -d=ssa/check_bce/debug=1"
+
+# Functions costing at most this much over the inlining budget count as small
+PERF_NEAR_BUDGET=40
+
+# Maximum entries listed per section
+PERF_MAX_ENTRIES=20
+
+# Parse arguments
+TARGET_DIR="."
+ALL_FILES=false
+for arg in "$@"; do
+    case "$arg" in
+        --all) ALL_FILES=true ;;
+        *) TARGET_DIR="$arg" ;;
+    esac
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Performance Report Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go; then
+    echo "## Go Performance Report Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Performance Report Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+REL_TARGET="$_gol_REL_TARGET"
+BUILD_TARGET="$_gol_PACKAGE_PATTERN"
+
+# Collect the Go files changed in the working tree (tracked and untracked),
+# relative to the project root
+# Returns: one path per line; 1 if the project is not a git work tree
+changed_go_files() {
+    if ! command -v git &>/dev/null \
+        || ! git -C "$PROJECT_ROOT" rev-parse --is-inside-work-tree &>/dev/null; then
+        return 1
+    fi
+
+    {
+        git -C "$PROJECT_ROOT" diff --name-only --relative HEAD -- "$REL_TARGET" 2>/dev/null \
+            || git -C "$PROJECT_ROOT" ls-files -- "$REL_TARGET" 2>/dev/null || true
+        git -C "$PROJECT_ROOT" ls-files --others --exclude-standard -- "$REL_TARGET" 2>/dev/null || true
+    } | grep '\.go$' | grep -v '_test\.go$' | sort -u || true
+}
+
+SCOPE_FILE=$(mktemp)
+BUILD_OUTPUT_FILE=$(mktemp)
+trap 'rm -f "$SCOPE_FILE" "$BUILD_OUTPUT_FILE"' EXIT
+
+SCOPE="all files"
+if [[ "$ALL_FILES" == false ]] && changed_go_files > "$SCOPE_FILE"; then
+    CHANGED_COUNT=$(grep -c . "$SCOPE_FILE" || true)
+    if [[ "$CHANGED_COUNT" -eq 0 ]]; then
+        echo "## Go Performance Report"
+        echo ""
+        echo "No Go files changed under the target. Run with --all to report every file."
+        echo ""
+        echo "**Target:** $BUILD_TARGET"
+        echo "**Project root:** $PROJECT_ROOT"
+        exit 0
+    fi
+    SCOPE="$CHANGED_COUNT changed file(s)"
+else
+    : > "$SCOPE_FILE"
+fi
+
+# Build with the diagnostics enabled; they are written to stderr and replayed
+# from the build cache, and -o /dev/null keeps main packages from writing a
+# binary into the project
+BUILD_EXIT=0
+(
+    cd "$PROJECT_ROOT" && go build -o /dev/null -gcflags="$PERF_GCFLAGS" "$BUILD_TARGET"
+) > "$BUILD_OUTPUT_FILE" 2>&1 || BUILD_EXIT=$?
+
+if [[ $BUILD_EXIT -ne 0 ]]; then
+    echo "## Go Performance Report Failed"
+    echo ""
+    echo "go build exited with code $BUILD_EXIT:"
+    echo '```'
+    # The diagnostics of packages that did compile are noise next to the errors
+    grep -v -E ': (can inline|cannot inline|inlining call to|Found Is|moved to heap|.* escapes to heap|.* does not escape|  )' \
+        "$BUILD_OUTPUT_FILE" || true
+    echo '```'
+    exit 1
+fi
+
+# Extract findings as "category<TAB>file<TAB>line<TAB>col<TAB>function<TAB>detail".
+# The scope file comes first; an empty scope accepts every relative path.
+# Enclosing functions are found by scanning each reported source file for
+# top-level func declarations.
+FINDINGS=$(
+    cd "$PROJECT_ROOT" && awk -v near="$PERF_NEAR_BUDGET" '
+        function load_funcs(file,    line, n, rest, recv, name, star) {
+            loaded[file] = 1
+            n = 0
+            while ((getline line < file) > 0) {
+                n++
+                if (line !~ /^func[ \t]/) {
+                    continue
+                }
+                rest = line
+                sub(/^func[ \t]+/, "", rest)
+                recv = ""
+                if (rest ~ /^\(/) {
+                    recv = substr(rest, 2, index(rest, ")") - 2)
+                    rest = substr(rest, index(rest, ")") + 1)
+                    sub(/^[ \t]+/, "", rest)
+                    sub(/\[.*$/, "", recv)
+                    sub(/^.*[ \t]/, "", recv)
+                    star = (recv ~ /^\*/)
+                    sub(/^\*/, "", recv)
+                    recv = star ? "(*" recv ")" : recv
+                }
+                if (!match(rest, /^[A-Za-z_][A-Za-z0-9_]*/)) {
+                    continue
+                }
+                name = substr(rest, 1, RLENGTH)
+                nfuncs[file]++
+                func_line[file, nfuncs[file]] = n
+                func_name[file, nfuncs[file]] = (recv != "" ? recv "." name : name)
+            }
+            close(file)
+        }
+
+        function enclosing(file, line,    i, name) {
+            if (!(file in loaded)) {
+                load_funcs(file)
+            }
+            name = ""
+            for (i = 1; i <= nfuncs[file]; i++) {
+                if (func_line[file, i] > line) {
+                    break
+                }
+                name = func_name[file, i]
+            }
+            return name
+        }
+
+        function emit(category, name, detail,    key) {
+            key = category SUBSEP file SUBSEP line SUBSEP col SUBSEP detail
+            if (key in seen) {
+                return
+            }
+            seen[key] = 1
+            if (name == "") {
+                name = enclosing(file, line)
+            }
+            printf "%s\t%s\t%d\t%d\t%s\t%s\n", category, file, line, col, name, detail
+        }
+
+        FILENAME == ARGV[1] {
+            if ($0 != "") {
+                scope[$0] = 1
+                scoped = 1
+            }
+            next
+        }
+
+        match($0, /^[^ :]+\.go:[0-9]+:[0-9]+: /) {
+            split(substr($0, 1, RLENGTH - 2), pos, ":")
+            file = pos[1]
+            sub(/^\.\//, "", file)
+            line = pos[2]
+            col = pos[3]
+            msg = substr($0, RLENGTH + 1)
+
+            if (file ~ /^\// || file ~ /_test\.go$/ || (scoped && !(file in scope))) {
+                next
+            }
+
+            if (msg ~ /^moved to heap: /) {
+                emit("escape", "", msg)
+            } else if (msg ~ /escapes to heap$/ && msg !~ /^ /) {
+                emit("escape", "", msg)
+            } else if (msg ~ /^cannot inline /) {
+                name = msg
+                sub(/^cannot inline /, "", name)
+                reason = name
+                sub(/: .*$/, "", name)
+                sub(/^[^:]*: /, "", reason)
+                if (reason ~ /go:noinline/) {
+                    next
+                }
+                if (match(reason, /cost [0-9]+ exceeds budget [0-9]+/)) {
+                    split(substr(reason, RSTART, RLENGTH), cost, " ")
+                    if (cost[2] - cost[5] > near) {
+                        next
+                    }
+                }
+                emit("inline", name, reason)
+            } else if (msg == "Found IsInBounds") {
+                emit("bounds", "", "index bounds check")
+            } else if (msg == "Found IsSliceInBounds") {
+                emit("bounds", "", "slice bounds check")
+            }
+        }
+    ' "$SCOPE_FILE" "$BUILD_OUTPUT_FILE" | sort -t$'\t' -k2,2 -k3,3n -k4,4n
+)
+
+# Count findings in a category
+# Args: category
+count_findings() {
+    if [[ -z "$FINDINGS" ]]; then
+        echo 0
+        return
+    fi
+    echo "$FINDINGS" | awk -F'\t' -v category="$1" '$1 == category { n++ } END { print n + 0 }'
+}
+
+# Print one report section
+# Args: category, heading, count
+print_section() {
+    local category="${1}"
+    local heading="${2}"
+    local count="${3}"
+
+    if [[ "$count" -eq 0 ]]; then
+        return
+    fi
+
+    echo "### $heading"
+    echo ""
+    echo "$FINDINGS" | awk -F'\t' -v category="$category" -v max="$PERF_MAX_ENTRIES" '
+        $1 == category && ++n <= max {
+            where = ($5 != "" ? " `" $5 "`" : "")
+            print "- **" $2 ":" $3 ":" $4 "**" where " " $6
+        }
+    '
+    echo ""
+
+    if [[ "$count" -gt $PERF_MAX_ENTRIES ]]; then
+        echo "_...and $((count - PERF_MAX_ENTRIES)) more_"
+        echo ""
+    fi
+}
+
+ESCAPE_COUNT=$(count_findings escape)
+INLINE_COUNT=$(count_findings inline)
+BOUNDS_COUNT=$(count_findings bounds)
+
+# Generate markdown report
+echo "## Go Performance Report"
+echo ""
+echo "**Target:** $BUILD_TARGET"
+echo "**Project root:** $PROJECT_ROOT"
+echo "**Scope:** $SCOPE"
+echo ""
+echo "### Summary"
+echo ""
+echo "- **Heap escapes:** $ESCAPE_COUNT"
+echo "- **Not inlined:** $INLINE_COUNT"
+echo "- **Bounds checks:** $BOUNDS_COUNT"
+echo ""
+
+print_section escape "Heap Escapes" "$ESCAPE_COUNT"
+print_section inline "Not Inlined" "$INLINE_COUNT"
+print_section bounds "Bounds Checks" "$BOUNDS_COUNT"
+
+exit 0
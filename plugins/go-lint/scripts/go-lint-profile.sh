@@ -0,0 +1,256 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Summarise a pprof profile and map its hot spots to module source
+# Usage: go-lint-profile.sh <profile> [--base <profile>] [--top N] [--sample <index>]
+#
+# Reads a CPU, heap or other pprof profile with go tool pprof and lists the top
+# functions by flat and cumulative cost, then the lines of the current module
+# (found from the working directory) with the highest cumulative cost, each
+# with a source snippet. With --base the report shows the difference between
+# the two profiles (profile minus base) instead.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+
+# Time limit per go tool pprof call (seconds), module lines shown, and source
+# lines around each of them
+PROFILE_TIMEOUT=60
+PROFILE_MAX_LINES=5
+PROFILE_CONTEXT_LINES=2
+
+# Parse arguments
+PROFILE=""
+BASE=""
+TOP=15
+SAMPLE_INDEX=""
+while [[ $# -gt 0 ]]; do
+    case "$1" in
+        --base)
+            BASE="${2:-}"
+            shift
+            ;;
+        --base=*) BASE="${1#--base=}" ;;
+        --top)
+            TOP="${2:-15}"
+            shift
+            ;;
+        --top=*) TOP="${1#--top=}" ;;
+        --sample)
+            SAMPLE_INDEX="${2:-}"
+            shift
+            ;;
+        --sample=*) SAMPLE_INDEX="${1#--sample=}" ;;
+        *) PROFILE="$1" ;;
+    esac
+    shift
+done
+
+if [[ -z "$PROFILE" ]]; then
+    echo "## Go Profile Report Failed"
+    echo ""
+    echo "Usage: /go-lint:profile <file.pprof> [--base <base.pprof>] [--top N] [--sample <index>]"
+    exit 1
+fi
+
+for file in "$PROFILE" ${BASE:+"$BASE"}; do
+    if [[ ! -f "$file" ]]; then
+        echo "## Go Profile Report Failed"
+        echo ""
+        echo "Profile does not exist: $file"
+        exit 1
+    fi
+done
+
+if ! [[ "$TOP" =~ ^[1-9][0-9]*$ ]]; then
+    echo "## Go Profile Report Failed"
+    echo ""
+    echo "--top needs a positive number (got $TOP)."
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go; then
+    echo "## Go Profile Report Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+PPROF_FLAGS=()
+if [[ -n "$SAMPLE_INDEX" ]]; then
+    PPROF_FLAGS+=("-sample_index=$SAMPLE_INDEX")
+fi
+if [[ -n "$BASE" ]]; then
+    PPROF_FLAGS+=("-diff_base=$BASE")
+fi
+
+# Run go tool pprof -top with extra flags
+# Args: pprof flags...
+# Sets: PPROF_OUTPUT; exits with the pprof error if the profile cannot be read
+run_pprof() {
+    local exit_code=0
+    PPROF_OUTPUT=$(_gol_run_with_timeout "$PROFILE_TIMEOUT" \
+        go tool pprof -top ${PPROF_FLAGS[@]+"${PPROF_FLAGS[@]}"} "$@" "$PROFILE" 2>&1) || exit_code=$?
+    if [[ $exit_code -ne 0 ]]; then
+        echo "## Go Profile Report Failed"
+        echo ""
+        echo "go tool pprof could not read the profile:"
+        echo ""
+        echo '```'
+        echo "$PPROF_OUTPUT"
+        echo '```'
+        exit 1
+    fi
+}
+
+# Render pprof -top rows as a markdown table
+# Args: pprof_output
+print_top_table() {
+    echo "| Flat | Flat% | Cum | Cum% | Function |"
+    echo "|------|-------|-----|------|----------|"
+    echo "${1}" | awk '
+        /^ *flat  *flat%/ { rows = 1; next }
+        rows && NF >= 6 {
+            name = $6
+            for (i = 7; i <= NF; i++) name = name " " $i
+            printf "| %s | %s | %s | %s | `%s` |\n", $1, $2, $4, $5, name
+        }
+    '
+}
+
+# Find a profiled source file in the project
+# Profiles record absolute paths from the machine that built the binary, so
+# leading directories are dropped until the rest exists under the project root
+# Args: path
+# Returns: path relative to the project root; 1 if not found
+project_file() {
+    local path="${1}"
+
+    if [[ "$path" == "$PROJECT_ROOT/"* ]]; then
+        echo "${path#"$PROJECT_ROOT/"}"
+        return 0
+    fi
+    while [[ "$path" == */* ]]; do
+        path="${path#*/}"
+        if [[ -n "$path" && -f "$PROJECT_ROOT/$path" ]]; then
+            echo "$path"
+            return 0
+        fi
+    done
+    return 1
+}
+
+# Run pprof up front so a bad profile fails before any output
+run_pprof "-nodecount=$TOP"
+FLAT_OUTPUT="$PPROF_OUTPUT"
+run_pprof -cum "-nodecount=$TOP"
+CUM_OUTPUT="$PPROF_OUTPUT"
+run_pprof -cum -lines -nodecount=1000
+LINES_OUTPUT="$PPROF_OUTPUT"
+
+PROFILE_TYPE=$(echo "$FLAT_OUTPUT" | sed -n 's/^Type: //p' | head -n 1)
+TOTAL=$(echo "$FLAT_OUTPUT" | sed -n 's/.* of \(.*\) total.*/\1/p' | head -n 1)
+
+PROJECT_ROOT=$(_gol_find_project_root "." || true)
+MODULES=""
+if [[ -n "$PROJECT_ROOT" ]]; then
+    MODULES=$(cd "$PROJECT_ROOT" && go list -m -f '{{.Path}}' 2>/dev/null || true)
+fi
+
+echo "## Go Profile Report"
+echo ""
+echo "**Profile:** $PROFILE"
+if [[ -n "$BASE" ]]; then
+    echo "**Base:** $BASE (values are profile minus base)"
+fi
+echo "**Type:** ${PROFILE_TYPE:-unknown}"
+if [[ -n "$TOTAL" ]]; then
+    echo "**Total:** $TOTAL${BASE:+ (base)}"
+fi
+if [[ -n "$PROJECT_ROOT" ]]; then
+    echo "**Project root:** $PROJECT_ROOT"
+fi
+echo ""
+
+if [[ -n "$BASE" ]]; then
+    echo "### Largest Changes by Flat"
+else
+    echo "### Top Functions by Flat"
+fi
+echo ""
+print_top_table "$FLAT_OUTPUT"
+echo ""
+
+if [[ -n "$BASE" ]]; then
+    echo "### Largest Changes by Cumulative"
+else
+    echo "### Top Functions by Cumulative"
+fi
+echo ""
+print_top_table "$CUM_OUTPUT"
+echo ""
+
+echo "### Module Hot Lines"
+echo ""
+if [[ -z "$MODULES" ]]; then
+    echo "No Go module found from $(pwd); run the command inside the profiled module to map costs to source."
+    exit 0
+fi
+
+# Lines of module functions outside tests, highest cumulative cost first:
+# "flat<TAB>flat%<TAB>cum<TAB>cum%<TAB>function<TAB>path<TAB>line"
+HOT_LINES=$(echo "$LINES_OUTPUT" | awk -v modules="$MODULES" '
+    BEGIN { n = split(modules, prefixes, "\n") }
+    /^ *flat  *flat%/ { rows = 1; next }
+    rows && NF >= 7 {
+        location = $NF
+        if (!match(location, /:[0-9]+$/) || location ~ /_test\.go:/) next
+        name = $6
+        for (i = 7; i < NF; i++) name = name " " $i
+        sub(/ \(inline\)$/, "", name)
+        for (i = 1; i <= n; i++) {
+            if (index(name, prefixes[i] ".") == 1 || index(name, prefixes[i] "/") == 1) {
+                print $1 "\t" $2 "\t" $4 "\t" $5 "\t" name "\t" substr(location, 1, RSTART - 1) "\t" substr(location, RSTART + 1)
+                break
+            }
+        }
+    }
+')
+
+if [[ -z "$HOT_LINES" ]]; then
+    echo "No samples in functions of $(echo "$MODULES" | paste -sd, - | sed 's/,/, /g')."
+    exit 0
+fi
+
+SHOWN=0
+while IFS=$'\t' read -r flat flat_pct cum cum_pct name path line; do
+    if [[ $SHOWN -ge $PROFILE_MAX_LINES ]]; then
+        break
+    fi
+    SHOWN=$((SHOWN + 1))
+
+    rel_path=$(project_file "$path") || rel_path=""
+    echo "#### ${rel_path:-$path}:$line \`$name\`"
+    echo ""
+    echo "flat $flat ($flat_pct), cum $cum ($cum_pct)"
+    echo ""
+    if [[ -z "$rel_path" ]]; then
+        echo "Source not found under $PROJECT_ROOT."
+        echo ""
+        continue
+    fi
+    echo '```go'
+    awk -v hot="$line" -v context="$PROFILE_CONTEXT_LINES" '
+        NR >= hot - context && NR <= hot + context {
+            printf "%s %5d | %s\n", (NR == hot ? ">" : " "), NR, $0
+        }
+        NR > hot + context { exit }
+    ' "$PROJECT_ROOT/$rel_path"
+    echo '```'
+    echo ""
+done <<< "$HOT_LINES"
@@ -8,8 +8,9 @@ set -euo pipefail
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
 
-# Source common library
+# Source common library and go-lint analyzer helpers
 source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
 
 # Parse target directory argument
 TARGET_DIR="${1:-.}"
@@ -39,45 +40,51 @@ if ! _gol_check_required_tools golangci-lint jq; then
     exit 1
 fi
 
-# Find project root
-PROJECT_ROOT=$(_gol_find_project_root "$TARGET_DIR")
-
-if [[ -z "$PROJECT_ROOT" ]]; then
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
     echo "## Go Linting Failed"
     echo ""
     echo "Could not find Go project root (no go.mod, go.work, or .git found)"
     echo "Searched from: $TARGET_DIR"
     exit 1
 fi
-
-# Get relative target path from project root
-REL_TARGET=$(_gol_get_relative_path "$PROJECT_ROOT" "$TARGET_DIR")
-if [[ "$REL_TARGET" == "." || "$REL_TARGET" == "$TARGET_DIR" ]]; then
-    LINT_TARGET="./..."
-else
-    LINT_TARGET="./$REL_TARGET/..."
-fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+LINT_TARGET="$_gol_PACKAGE_PATTERN"
 
 # Detect golangci-lint config
 CONFIG_ARGS=$(_gol_build_golangci_config_args "$PROJECT_ROOT")
 
+# Pick JSON output flags for the installed golangci-lint major version
+GOLANGCI_MAJOR=$(_gol_golangci_major_version)
+OUTPUT_ARGS=$(_gol_build_golangci_output_args "$GOLANGCI_MAJOR")
+
 # Run golangci-lint with JSON output
 # Use subshell to preserve working directory
-# NOTE: Don't capture stderr (no 2>&1) to avoid mixing error messages with JSON
+# NOTE: stderr goes to a temp file to avoid mixing error messages with JSON
+GOLANGCI_STDERR_FILE=$(mktemp)
+trap 'rm -f "$GOLANGCI_STDERR_FILE"' EXIT
+
 GOLANGCI_EXIT=0
 GOLANGCI_JSON=$(
-    cd "$PROJECT_ROOT" && golangci-lint run $CONFIG_ARGS --out-format=json --fix "$LINT_TARGET"
+    cd "$PROJECT_ROOT" && golangci-lint run $CONFIG_ARGS $OUTPUT_ARGS --fix "$LINT_TARGET" 2>"$GOLANGCI_STDERR_FILE"
 ) || GOLANGCI_EXIT=$?
+GOLANGCI_STDERR=$(cat "$GOLANGCI_STDERR_FILE")
 
-# Handle empty output (no issues found)
-if [[ -z "$GOLANGCI_JSON" ]]; then
-    echo "## Go Linting Succeeded"
-    echo ""
-    echo "No issues found!"
+# Handle empty output with a failing exit code (crash, timeout, bad flags)
+if [[ -z "$GOLANGCI_JSON" && $GOLANGCI_EXIT -ne 0 ]]; then
+    echo "## Go Linting Failed"
     echo ""
-    echo "**Target:** $LINT_TARGET"
-    echo "**Project root:** $PROJECT_ROOT"
-    exit 0
+    echo "golangci-lint exited with code $GOLANGCI_EXIT and produced no output:"
+    echo '```'
+    echo "$GOLANGCI_STDERR"
+    echo '```'
+    exit 1
+fi
+
+# Empty output means golangci-lint found no issues; the go-lint checks below
+# may still add some
+if [[ -z "$GOLANGCI_JSON" ]]; then
+    GOLANGCI_JSON='{}'
 fi
 
 # Parse JSON output - take the full JSON, not just first line
@@ -109,9 +116,28 @@ if ! echo "$REPORT_JSON" | jq empty 2>/dev/null; then
     exit 1
 fi
 
-# Extract issues
-ISSUES_JSON=$(echo "$REPORT_JSON" | jq -r '.Issues // []')
+# Run the go-lint checks that also apply project-wide (slog and security
+# packs) and report them in golangci-lint's issue format
+TARGET_REL_DIR=$(_gol_get_relative_path "$PROJECT_ROOT" "$TARGET_DIR")
+ANALYZER_OUTPUT=$(_gol_run_analyzer_checks "$PROJECT_ROOT" "$TARGET_REL_DIR" "slog,security")
+ANALYZER_ISSUES_JSON=$(echo "$ANALYZER_OUTPUT" | jq -R -s '
+    split("\n") | map(
+        capture("^(?<file>[^:]+):(?<line>[0-9]+):(?<col>[0-9]+): ((?<severity>security|warning): )?(?<text>.*) \\((?<check>[a-z]+)\\)$")
+        | {
+            Pos: {Filename: .file, Line: (.line | tonumber), Column: (.col | tonumber)},
+            FromLinter: "go-lint/\(.check)",
+            Text: .text,
+            Severity: (.severity // "")
+        }
+    )
+')
+
+# Extract issues, security findings first
+ISSUES_JSON=$(echo "$REPORT_JSON" | jq -r --argjson extra "$ANALYZER_ISSUES_JSON" '
+    (.Issues // []) + $extra | [.[] | select(.Severity == "security")] + [.[] | select(.Severity != "security")]
+')
 ISSUE_COUNT=$(echo "$ISSUES_JSON" | jq 'length')
+SECURITY_COUNT=$(echo "$ISSUES_JSON" | jq '[.[] | select(.Severity == "security")] | length')
 
 # Check if there are any issues
 if [[ "$ISSUE_COUNT" -eq 0 ]]; then
@@ -125,11 +151,12 @@ if [[ "$ISSUE_COUNT" -eq 0 ]]; then
 fi
 
 # Separate errors and warnings
-# golangci-lint doesn't distinguish severity in JSON, so we treat all as errors
-ERRORS_JSON="$ISSUES_JSON"
-ERROR_COUNT="$ISSUE_COUNT"
-WARNINGS_JSON="[]"
-WARNING_COUNT=0
+# golangci-lint doesn't distinguish severity in JSON, so we treat all as errors;
+# only go-lint's heuristic checks report warnings
+ERRORS_JSON=$(echo "$ISSUES_JSON" | jq '[.[] | select(.Severity != "warning")]')
+ERROR_COUNT=$(echo "$ERRORS_JSON" | jq 'length')
+WARNINGS_JSON=$(echo "$ISSUES_JSON" | jq '[.[] | select(.Severity == "warning")]')
+WARNING_COUNT=$(echo "$WARNINGS_JSON" | jq 'length')
 
 # Generate markdown report
 echo "## Go Linting Report"
@@ -145,6 +172,9 @@ echo "### Summary"
 echo ""
 echo "- **Errors:** $ERROR_COUNT"
 echo "- **Warnings:** $WARNING_COUNT"
+if [[ $SECURITY_COUNT -gt 0 ]]; then
+    echo "- **Security:** $SECURITY_COUNT"
+fi
 echo ""
 
 # Display top 20 errors
@@ -155,7 +185,7 @@ if [[ $ERROR_COUNT -gt 0 ]]; then
     # Format and limit to 20 errors
     ERROR_TEXT=$(echo "$ERRORS_JSON" | jq -r '
         .[0:20] | map(
-            "- **\(.Pos.Filename):\(.Pos.Line):\(.Pos.Column)** [\(.FromLinter)] \(.Text)"
+            "- **\(.Pos.Filename):\(.Pos.Line):\(.Pos.Column)** [\(.FromLinter)] \(if .Severity == "security" then "**security:** " else "" end)\(.Text)"
         ) | join("\n")
     ')
 
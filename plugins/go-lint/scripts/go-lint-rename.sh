@@ -0,0 +1,263 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Rename a Go identifier across its module with gopls
+# Usage: go-lint-rename.sh <file>:<line>:<col> <newName>
+#
+# gopls renames every reference it can type-check, including test packages in
+# other directories, and refuses renames that would change the meaning of the
+# code. The edits are previewed as a diff (to list the changed locations),
+# applied, formatted with goimports, and the touched packages are vetted.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+
+# Time limit for each gopls run (seconds)
+RENAME_TIMEOUT="${GO_LINT_RENAME_TIMEOUT:-120}"
+
+# Maximum locations listed per file
+RENAME_MAX_LOCATIONS=20
+
+# Print a failure report and exit
+# Args: message lines...
+fail() {
+    echo "## Go Rename Failed"
+    echo ""
+    printf '%s\n' "$@"
+    exit 1
+}
+
+# Parse arguments
+if [[ $# -ne 2 ]]; then
+    fail "Usage: /go-lint:rename <file>:<line>:<col> <newName>"
+fi
+POSITION="${1}"
+NEW_NAME="${2}"
+
+if [[ ! "$POSITION" =~ ^(.+):([0-9]+):([0-9]+)$ ]]; then
+    fail "Position must be <file>:<line>:<col>, got: $POSITION"
+fi
+FILE_ABS=$(_gol_get_absolute_path "${BASH_REMATCH[1]}")
+LINE="${BASH_REMATCH[2]}"
+COLUMN="${BASH_REMATCH[3]}"
+
+if [[ ! -f "$FILE_ABS" || "$FILE_ABS" != *.go ]]; then
+    fail "Not a Go file: $FILE_ABS"
+fi
+
+case "$NEW_NAME" in
+    break | case | chan | const | continue | default | defer | else | fallthrough | for | func | go | goto | \
+        if | import | interface | map | package | range | return | select | struct | switch | type | var)
+        fail "New name is a Go keyword: $NEW_NAME"
+        ;;
+esac
+if [[ ! "$NEW_NAME" =~ ^[A-Za-z_][A-Za-z0-9_]*$ ]]; then
+    fail "New name is not a Go identifier: $NEW_NAME"
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools gopls go; then
+    fail "Missing required tools: ${_gol_MISSING_TOOLS[*]}" \
+        "" \
+        "### Installation" \
+        "" \
+        '```bash' \
+        "go install golang.org/x/tools/gopls@latest" \
+        '```'
+fi
+
+# Find project root
+PROJECT_ROOT=$(_gol_find_project_root "$(dirname "$FILE_ABS")" || true)
+if [[ -z "$PROJECT_ROOT" ]]; then
+    fail "Could not find Go project root (no go.mod, go.work, or .git found)" \
+        "Searched from: $(dirname "$FILE_ABS")"
+fi
+REL_FILE=$(_gol_get_relative_path "$PROJECT_ROOT" "$FILE_ABS")
+
+# The identifier under the cursor (columns are 1-based bytes, as in gopls)
+OLD_NAME=$(LC_ALL=C awk -v line="$LINE" -v col="$COLUMN" '
+    NR == line {
+        if (substr($0, col, 1) !~ /[A-Za-z0-9_]/) exit
+        start = col
+        while (start > 1 && substr($0, start - 1, 1) ~ /[A-Za-z0-9_]/) start--
+        end = col
+        while (substr($0, end + 1, 1) ~ /[A-Za-z0-9_]/) end++
+        print substr($0, start, end - start + 1)
+        exit
+    }
+' "$FILE_ABS")
+if [[ -z "$OLD_NAME" || "$OLD_NAME" =~ ^[0-9] ]]; then
+    fail "No identifier at $REL_FILE:$LINE:$COLUMN"
+fi
+if [[ "$OLD_NAME" == "$NEW_NAME" ]]; then
+    fail "$OLD_NAME already has that name"
+fi
+
+# Run gopls rename from the project root
+# Args: mode flag (-d or -w)
+# Returns: gopls output (stderr included); exit code of gopls
+run_gopls_rename() {
+    local mode="${1}"
+    (
+        cd "$PROJECT_ROOT" && _gol_run_with_timeout "$RENAME_TIMEOUT" \
+            gopls rename "$mode" "$FILE_ABS:$LINE:$COLUMN" "$NEW_NAME" 2>&1
+    )
+}
+
+# Preview the edits; gopls prints one unified diff per file
+DIFF_EXIT=0
+DIFF=$(run_gopls_rename -d) || DIFF_EXIT=$?
+if [[ $DIFF_EXIT -eq 124 ]]; then
+    fail "gopls rename did not finish within ${RENAME_TIMEOUT}s (override with GO_LINT_RENAME_TIMEOUT)"
+fi
+if [[ $DIFF_EXIT -ne 0 ]]; then
+    fail "gopls could not rename \`$OLD_NAME\` to \`$NEW_NAME\`:" \
+        '```' \
+        "$(echo "$DIFF" | sed "s|$PROJECT_ROOT/||g")" \
+        '```'
+fi
+
+# Changed locations as "file<TAB>line<TAB>col", from the added lines of the
+# diff: every occurrence of the new name on them
+LOCATIONS=$(echo "$DIFF" | LC_ALL=C awk -v root="$PROJECT_ROOT/" -v name="$NEW_NAME" '
+    /^\+\+\+ / {
+        file = substr($0, 5)
+        sub(/\t.*/, "", file)
+        if (index(file, root) == 1) file = substr(file, length(root) + 1)
+        next
+    }
+    /^--- / { next }
+    /^@@ / {
+        split($3, range, ",")
+        new_line = substr(range[1], 2) + 0
+        next
+    }
+    file == "" { next }
+    /^-/ { next }
+    /^\+/ {
+        text = substr($0, 2)
+        offset = 0
+        while ((i = index(text, name)) > 0) {
+            before = offset + i - 1
+            after = substr(text, i + length(name), 1)
+            if ((before == 0 || substr($0, before + 1, 1) !~ /[A-Za-z0-9_]/) && after !~ /[A-Za-z0-9_]/) {
+                print file "\t" new_line "\t" (before + 1)
+            }
+            text = substr(text, i + length(name))
+            offset = before + length(name)
+        }
+        new_line++
+        next
+    }
+    { new_line++ }
+')
+if [[ -z "$LOCATIONS" ]]; then
+    fail "gopls found nothing to rename at $REL_FILE:$LINE:$COLUMN"
+fi
+CHANGED_FILES=$(echo "$LOCATIONS" | cut -f1 | sort -u)
+
+# Apply the edits
+APPLY_EXIT=0
+APPLY_OUTPUT=$(run_gopls_rename -w) || APPLY_EXIT=$?
+if [[ $APPLY_EXIT -ne 0 ]]; then
+    fail "gopls rename -w failed after a successful preview (exit $APPLY_EXIT):" \
+        '```' \
+        "$(echo "$APPLY_OUTPUT" | sed "s|$PROJECT_ROOT/||g")" \
+        '```'
+fi
+
+# Format the touched files like the edit hook does
+CHANGED_PATHS=()
+while IFS= read -r file; do
+    CHANGED_PATHS+=("$PROJECT_ROOT/$file")
+done <<< "$CHANGED_FILES"
+FORMAT_NOTE="Formatted the changed files with goimports."
+if command -v goimports &>/dev/null; then
+    if ! goimports -w "${CHANGED_PATHS[@]}" >/dev/null 2>&1; then
+        FORMAT_NOTE="goimports failed on the changed files; run it manually."
+    fi
+else
+    FORMAT_NOTE="goimports is not installed; the changed files were not reformatted."
+fi
+
+# gopls only sees files for the current GOOS/GOARCH and build tags, so
+# constrained files that still mention the old name may hold missed references
+UNSEEN_FILES=$(
+    cd "$PROJECT_ROOT" && find . \( -type d \( -name vendor -o -name testdata -o -name '.?*' \) -prune \) \
+        -o \( -type f -name '*.go' -print \) 2>/dev/null | sort | while IFS= read -r file; do
+        if grep -qE '^//(go:build| \+build) ' "$file" && grep -qw -- "$OLD_NAME" "$file"; then
+            echo "${file#./}"
+        fi
+    done
+)
+
+LOCATION_COUNT=$(echo "$LOCATIONS" | grep -c "^")
+FILE_COUNT=$(echo "$CHANGED_FILES" | grep -c "^")
+
+echo "## Go Rename Report"
+echo ""
+echo "**Renamed:** \`$OLD_NAME\` → \`$NEW_NAME\`"
+echo "**Position:** $REL_FILE:$LINE:$COLUMN"
+echo "**Project root:** $PROJECT_ROOT"
+echo ""
+echo "### Summary"
+echo ""
+echo "- **Files changed:** $FILE_COUNT"
+echo "- **Locations:** $LOCATION_COUNT"
+echo ""
+echo "### Changed Files"
+echo ""
+echo "$LOCATIONS" | awk -F'\t' -v max="$RENAME_MAX_LOCATIONS" '
+    $1 != file {
+        if (file != "") print_file()
+        file = $1
+        count = 0
+        list = ""
+    }
+    {
+        count++
+        if (count <= max) list = list (count > 1 ? ", " : "") $2 ":" $3
+    }
+    END { print_file() }
+    function print_file() {
+        more = count > max ? " and " (count - max) " more" : ""
+        print "- **" file "** (" count "): " list more
+    }
+'
+echo ""
+echo "$FORMAT_NOTE"
+echo ""
+
+if [[ -n "$UNSEEN_FILES" ]]; then
+    echo "### Check Manually"
+    echo ""
+    echo "gopls only renames in files built for the current platform and build tags. These files have build constraints and still mention \`$OLD_NAME\`:"
+    echo ""
+    echo "$UNSEEN_FILES" | sed 's/^/- /'
+    echo ""
+fi
+
+# The rename must leave the touched packages compiling and vet-clean
+VET_TARGETS=$(echo "$CHANGED_FILES" | while IFS= read -r file; do
+    dir=$(dirname "$file")
+    echo "./${dir#./}"
+done | sed 's|^\./\.$|.|' | sort -u)
+VET_EXIT=0
+# shellcheck disable=SC2086 # one package per word
+POST_VET=$(cd "$PROJECT_ROOT" && go vet $VET_TARGETS 2>&1) || VET_EXIT=$?
+
+echo "### go vet"
+echo ""
+if [[ $VET_EXIT -eq 0 ]]; then
+    echo "No issues found in the changed packages."
+    exit 0
+fi
+
+echo '```'
+echo "$POST_VET"
+echo '```'
+exit 1
@@ -0,0 +1,136 @@
+#!/usr/bin/env bash
+# Scratch module checks for go-lint plugin (Go files outside any module)
+# This file should be sourced after go-lint-common.sh and go-lint-budget.sh,
+# not executed directly
+#
+# Files with no go.mod in their directory or above (loose snippets, or files
+# in a git repository that is not a Go module) are type-checked and vetted as
+# a synthetic module. Its go.mod only exists in a go build -overlay, so nothing
+# is written next to the file, and the build runs offline (GOPROXY=off), which
+# limits it to standard library imports.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+# Time limit for go vet in the scratch module (seconds)
+_GOL_SCRATCH_TIMEOUT="${GO_LINT_VET_TIMEOUT:-20}"
+
+# Module path of the scratch module
+_GOL_SCRATCH_MODULE="scratch"
+
+# Check if a Go file is outside any module
+# Args: file_path (absolute)
+# Returns: 0 if no go.mod is found in the file's directory or its parents
+_gol_is_orphan_file() {
+    local current_dir
+    current_dir=$(dirname "${1}")
+
+    while [[ "$current_dir" != "/" ]]; do
+        if [[ -f "$current_dir/go.mod" ]]; then
+            return 1
+        fi
+        current_dir=$(dirname "$current_dir")
+    done
+
+    return 0
+}
+
+# Language version for the scratch module, from the installed toolchain
+# Returns: version such as 1.23 or 1.23.4, or empty string for devel builds
+_gol_scratch_go_version() {
+    go env GOVERSION 2>/dev/null | sed -n 's/^go\([0-9][0-9]*\.[0-9][0-9]*\(\.[0-9][0-9]*\)\{0,1\}\).*/\1/p'
+}
+
+# List imports that the scratch module cannot resolve from go vet output
+# Args: vet_output
+# Returns: one import path per line, or empty string
+_gol_scratch_unresolved_imports() {
+    echo "${1}" | sed -n \
+        -e 's/.*cannot find module providing package \([^:]*\):.*/\1/p' \
+        -e 's/.*package \([^ ]*\) is not in std.*/\1/p' \
+        | sort -u
+}
+
+# Type-check and vet an orphan Go file in a scratch module
+# Args: file_path (absolute), edit_region ("start<TAB>end", optional; see
+#       _gol_edit_region)
+# Exits via _gol_safe_exit when there is something to report
+_gol_check_scratch() {
+    local file_path="${1}"
+    local edit_region="${2:-}"
+    local file_dir file_name
+    file_dir=$(dirname "$file_path")
+    file_name=$(basename "$file_path")
+
+    local scratch_dir
+    scratch_dir=$(mktemp -d 2>/dev/null) || return 0
+
+    local go_version
+    go_version=$(_gol_scratch_go_version)
+    {
+        echo "module $_GOL_SCRATCH_MODULE"
+        if [[ -n "$go_version" ]]; then
+            echo ""
+            echo "go $go_version"
+        fi
+    } > "$scratch_dir/go.mod"
+
+    jq -n --arg target "$file_dir/go.mod" --arg source "$scratch_dir/go.mod" \
+        '{Replace: {($target): $source}}' > "$scratch_dir/overlay.json"
+
+    # GOWORK=off keeps a go.work further up from claiming the directory
+    local vet_exit=0
+    local vet_output
+    vet_output=$(
+        cd "$file_dir" && GOFLAGS=-mod=mod GOPROXY=off GOWORK=off \
+            _gol_run_with_timeout "$_GOL_SCRATCH_TIMEOUT" go vet -overlay "$scratch_dir/overlay.json" . 2>&1
+    ) || vet_exit=$?
+    rm -rf "$scratch_dir"
+
+    if [[ $vet_exit -eq 124 ]]; then
+        _gol_safe_exit "allow" \
+            "go vet timed out after ${_GOL_SCRATCH_TIMEOUT}s (outside any Go module)" \
+            "PostToolUse" \
+            "go vet of $file_dir as a scratch module did not finish within ${_GOL_SCRATCH_TIMEOUT}s"
+    fi
+
+    if [[ $vet_exit -eq 0 ]]; then
+        return 0
+    fi
+
+    # Third-party imports need a real module with its dependencies downloaded
+    local unresolved
+    unresolved=$(_gol_scratch_unresolved_imports "$vet_output")
+    if [[ -n "$unresolved" ]]; then
+        local unresolved_count
+        unresolved_count=$(echo "$unresolved" | grep -c "^")
+
+        _gol_safe_exit "allow" \
+            "Type-checking skipped: $unresolved_count import(s) outside the standard library (outside any Go module)" \
+            "PostToolUse" \
+            "$file_name is not inside a Go module, so go-lint checks it in an offline scratch module that can only resolve standard library imports. Not resolved:
+$(echo "$unresolved" | sed 's/^/- /')
+
+To type-check it, create a module in $file_dir with 'go mod init <module-path>' and 'go mod tidy'."
+    fi
+
+    local filtered_output
+    filtered_output=$(_gol_filter_vet_output "$vet_output" "$file_name")
+    if [[ -n "$filtered_output" ]]; then
+        local issue_count
+        issue_count=$(echo "$filtered_output" | grep -c "^")
+        filtered_output=$(_gol_budget_diagnostics "$filtered_output" "" \
+            "$(_gol_output_budget "")" "$file_name" \
+            "$(echo "$edit_region" | cut -f1)" "$(echo "$edit_region" | cut -sf2)")
+
+        _gol_safe_exit "block" \
+            "go vet found $issue_count issue(s) in file (outside any Go module)" \
+            "PostToolUse" \
+            "$filtered_output
+
+$file_name is not inside a Go module; it was checked with the other .go files in $file_dir as a scratch module."
+    fi
+}
@@ -0,0 +1,147 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Run the tests affected by the Go code changed in the working tree
+# Usage: go-lint-test-impact.sh [directory] [--callgraph] [--list]
+#
+# Selects every test package under the target whose test binary imports a
+# changed package (see go-lint-impact.sh) and runs it. --callgraph narrows the
+# run to the TestXxx functions that can reach a changed function; --list only
+# reports the selection.
+
+# Setup paths
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
+
+# Source common library
+source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-gocmd.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-impact.sh"
+
+# Time limit for the test run (seconds)
+IMPACT_TIMEOUT="${GO_LINT_IMPACT_TIMEOUT:-600}"
+
+# Parse arguments
+TARGET_DIR="."
+USE_CALL_GRAPH=false
+LIST_ONLY=false
+for arg in "$@"; do
+    case "$arg" in
+        --callgraph) USE_CALL_GRAPH=true ;;
+        --list) LIST_ONLY=true ;;
+        *) TARGET_DIR="$arg" ;;
+    esac
+done
+TARGET_DIR=$(_gol_get_absolute_path "$TARGET_DIR")
+
+# Check if target exists
+if [[ ! -d "$TARGET_DIR" ]]; then
+    echo "## Go Test Impact Failed"
+    echo ""
+    echo "Target directory does not exist: $TARGET_DIR"
+    exit 1
+fi
+
+# Check for required tools
+if ! _gol_check_required_tools go git; then
+    echo "## Go Test Impact Failed"
+    echo ""
+    echo "Missing required tools: ${_gol_MISSING_TOOLS[*]}"
+    exit 1
+fi
+
+# Find project root and the package pattern for the target
+if ! _gol_resolve_target "$TARGET_DIR"; then
+    echo "## Go Test Impact Failed"
+    echo ""
+    echo "Could not find Go project root (no go.mod, go.work, or .git found)"
+    echo "Searched from: $TARGET_DIR"
+    exit 1
+fi
+PROJECT_ROOT="$_gol_PROJECT_ROOT"
+TEST_TARGET="$_gol_PACKAGE_PATTERN"
+
+if ! _gol_impact_analyze "$PROJECT_ROOT" "$TEST_TARGET" "$USE_CALL_GRAPH"; then
+    echo "## Go Test Impact Failed"
+    echo ""
+    echo "Changed files are read from git, but $PROJECT_ROOT is not in a git work tree."
+    exit 1
+fi
+
+echo "## Go Test Impact Report"
+echo ""
+echo "**Target:** $TEST_TARGET"
+echo "**Project root:** $PROJECT_ROOT"
+
+if [[ -z "$_gol_IMPACT_CHANGED" ]]; then
+    echo ""
+    echo "No Go packages changed in the working tree."
+    exit 0
+fi
+
+echo "**Selection:** $_gol_IMPACT_NARROWING"
+echo ""
+
+SELECTED_COUNT=$(echo "$_gol_IMPACT_SELECTED" | grep -c . || true)
+CHANGED_COUNT=$(echo "$_gol_IMPACT_CHANGED" | grep -c .)
+
+echo "### Summary"
+echo ""
+echo "- **Changed files:** $_gol_IMPACT_FILE_COUNT"
+echo "- **Changed packages:** $CHANGED_COUNT"
+echo "- **Test packages selected:** $SELECTED_COUNT"
+if [[ -n "$_gol_IMPACT_TESTS" ]]; then
+    echo "- **Tests selected:** $(echo "$_gol_IMPACT_TESTS" | grep -c .)"
+fi
+echo ""
+
+echo "### Changed Packages"
+echo ""
+echo "$_gol_IMPACT_CHANGED" | sed 's/^/- /'
+echo ""
+
+if [[ $SELECTED_COUNT -eq 0 ]]; then
+    echo "No tests under the target depend on the changed packages."
+    exit 0
+fi
+
+echo "### Selected Tests"
+echo ""
+_gol_impact_format_selection
+echo ""
+
+if [[ -n "$_gol_IMPACT_SKIPPED" ]]; then
+    echo "### Skipped by Call Graph"
+    echo ""
+    echo "$_gol_IMPACT_SKIPPED" | sed 's/$/: no test reaches a changed function/; s/^/- /'
+    echo ""
+fi
+
+if [[ "$LIST_ONLY" == true ]]; then
+    exit 0
+fi
+
+_gol_impact_run_tests "$PROJECT_ROOT" "$IMPACT_TIMEOUT"
+
+echo "### Test Results"
+echo ""
+if [[ $_gol_IMPACT_EXIT -eq 124 ]]; then
+    echo "go test did not finish within ${IMPACT_TIMEOUT}s (set GO_LINT_IMPACT_TIMEOUT to allow more)."
+    exit 1
+fi
+
+if [[ $_gol_IMPACT_EXIT -eq 0 ]]; then
+    echo "All $SELECTED_COUNT selected test package(s) passed."
+    exit 0
+fi
+
+SUMMARY=$(_gol_summarize_go_output "test" "$_gol_IMPACT_OUTPUT" "$PROJECT_ROOT")
+if [[ -n "$SUMMARY" ]]; then
+    echo "$SUMMARY"
+else
+    echo '```'
+    echo "$_gol_IMPACT_OUTPUT"
+    echo '```'
+fi
+exit 1
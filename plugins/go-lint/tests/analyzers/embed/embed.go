@@ -0,0 +1,21 @@
+package embed
+
+import "embed"
+
+//go:embed static/index.html
+var index string
+
+//go:embed static/missing.css
+var missing string
+
+//go:embed assets/empty
+var empty embed.FS
+
+//go:embed "static/*.html" `static/app.js`
+var pages embed.FS
+
+//go:embed ../outside.txt
+var outside string
+
+//go:embed all:assets
+var assets embed.FS
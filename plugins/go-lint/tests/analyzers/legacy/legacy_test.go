@@ -0,0 +1,21 @@
+package legacy
+
+import "testing"
+
+func TestTable(t *testing.T) {
+	for _, name := range []string{"a", "b"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if name == "" {
+				t.Error("empty name")
+			}
+		})
+	}
+	for _, name := range []string{"c"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_ = name
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package security
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Download serves a report file.
+func Download(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("file")
+	path := filepath.Join("reports", name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+// Upload stores a file under its base name.
+func Upload(w http.ResponseWriter, r *http.Request) {
+	name := filepath.Base(r.FormValue("name"))
+	f, err := os.Create(filepath.Join("uploads", name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+}
+
+// Thumbnail reads an image after checking its path.
+func Thumbnail(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if strings.Contains(name, "..") {
+		http.Error(w, "bad name", http.StatusBadRequest)
+		return
+	}
+	http.ServeFile(w, r, filepath.Join("thumbs", name))
+}
+
+// Ping runs ping against a host from the query.
+func Ping(w http.ResponseWriter, r *http.Request) {
+	host := r.FormValue("host")
+	out, _ := exec.Command("ping", "-c", "1", host).Output()
+	_, _ = w.Write(out)
+}
+
+// Archive compresses a directory with a shell pipeline.
+func Archive(dir string) error {
+	if err := exec.Command("sh", "-c", "tar czf - "+dir+" | gzip -t").Run(); err != nil {
+		return err
+	}
+	return exec.Command("sh", "-c", "sync").Run()
+}
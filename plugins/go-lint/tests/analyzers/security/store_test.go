@@ -0,0 +1,5 @@
+package security
+
+import "crypto/tls"
+
+var testTLS = &tls.Config{InsecureSkipVerify: true}
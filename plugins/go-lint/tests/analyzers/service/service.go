@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"os/exec"
+	"time"
+)
+
+// Service talks to the database and upstream APIs.
+type Service struct {
+	db     *sql.DB
+	events chan string
+}
+
+// Fetch loads a user and pings the upstream API.
+func (s *Service) Fetch(ctx context.Context, id int) error {
+	row := s.db.QueryRow("SELECT name FROM users WHERE id = ?", id)
+	_ = row
+
+	resp, err := http.Get("https://example.com/ping")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	time.Sleep(time.Second)
+
+	go func() {
+		_ = exec.Command("true").Run()
+	}()
+
+	return s.audit(context.Background(), id)
+}
+
+// FetchContext does the same work correctly.
+func (s *Service) FetchContext(ctx context.Context, id int) error {
+	row := s.db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = ?", id)
+	_ = row
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/ping", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *Service) audit(ctx context.Context, id int) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO audit VALUES (?)", id)
+	return err
+}
+
+// Start launches background workers.
+func (s *Service) Start() {
+	ctx := context.Background()
+	_ = ctx
+
+	go func() {
+		for {
+			s.events <- "tick"
+		}
+	}()
+
+	ticker := time.NewTicker(time.Minute)
+	go func() {
+		for range ticker.C {
+			s.events <- "minute"
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case event := <-s.events:
+				_ = event
+			case <-time.After(5 * time.Second):
+				return
+			}
+		}
+	}()
+}
+
+// Run stops when its context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-s.events:
+				_ = event
+			}
+		}
+	}()
+
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(time.Second)
+		}
+	}
+}
+
+// Stoppable exits when done is closed.
+func (s *Service) Stoppable(done <-chan struct{}) {
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case s.events <- "beat":
+			}
+		}
+	}()
+}
+
+func init() {
+	_ = context.TODO()
+}
+
+// search reads the query string, which is unrelated to database queries.
+func (s *Service) search(ctx context.Context, r *http.Request) string {
+	q := r.URL.Query()
+	return q.Get("q")
+}
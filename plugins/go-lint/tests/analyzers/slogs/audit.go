@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+const keyUserID = "user_id"
+
+// Recorder writes audit events.
+type Recorder struct {
+	logger *slog.Logger
+}
+
+// Record logs one audit event.
+func (r *Recorder) Record(ctx context.Context, id int, email, action string) {
+	slog.Info("recorded", keyUserID, id, "action", action)
+	slog.Info("recorded", "userID", id)
+	slog.InfoContext(ctx, "recorded", "email", email, "action")
+	r.logger.Warn("retry", slog.Int("attempt", 2), "action", action)
+	r.logger.With(fmt.Sprintf("field_%d", id), action).Info("recorded")
+	slog.Error("failed", "Request-ID", id, slog.Group("user", "user_id", id))
+
+	attrs := []any{"action", action}
+	slog.Debug("recorded", attrs...)
+	log.Printf("recorded %d", id)
+}
@@ -0,0 +1,36 @@
+package structtag
+
+import "time"
+
+// Base holds fields shared by stored records.
+type Base struct {
+	ID      int64     `json:"id" db:"id"`
+	Created time.Time `json:"created" db:"created_at"`
+}
+
+// Audit tracks who touched a record.
+type Audit struct {
+	ID     int64  `json:"id"`
+	Editor string `json:"editor"`
+}
+
+// User is both stored and served over the API.
+type User struct {
+	Base
+	Audit
+	Name     string        `json:"name,omitempy" db:"userName"`
+	Email    string        `json:"email" db:"email" validate:"required,emial"`
+	Age      int           `json:"age" validate:"min=ten,max=130"`
+	Password string        `json:"-" validate:"required,min=8"`
+	Confirm  string        `json:"-" validate:"eqfield=Pasword"`
+	Timeout  time.Duration `json:"timeout" yaml:"timeout,flow" validate:"max=1h"`
+	Mail     string        `db:"email" mapstructure:"mail,squash" validate:"len"`
+	Role     string        `yaml:"role,inlin" validate:"oneof=admin user"`
+	Nick     string        `json:"nick" validate:"required_if=Role admin,,"`
+}
+
+// Account has two fields that claim the same JSON key.
+type Account struct {
+	Login string `json:"login"`
+	User  string `json:"login"`
+}
@@ -0,0 +1,7 @@
+package testcode
+
+// Store is the code under test.
+type Store struct{ items map[string]int }
+
+// Get returns the value of key.
+func (s *Store) Get(key string) int { return s.items[key] }
@@ -0,0 +1,83 @@
+package testcode
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) *Store {
+	dir, err := os.MkdirTemp("", "store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	return &Store{items: map[string]int{"a": 1}}
+}
+
+func mustGet(t testing.TB, s *Store, key string) int {
+	t.Helper()
+	v := s.Get(key)
+	if v == 0 {
+		t.Fatalf("missing %s", key)
+	}
+	return v
+}
+
+func checkGet(t *testing.T, s *Store, key string, want int) {
+	if got := s.Get(key); got != want {
+		t.Errorf("Get(%q) = %d, want %d", key, got, want)
+	}
+}
+
+func testEmpty(t *testing.T) {
+	if (&Store{}).Get("a") != 0 {
+		t.Error("empty store has a value")
+	}
+}
+
+func TestGet(t *testing.T) {
+	s := newStore(t)
+	tests := []struct {
+		key  string
+		want int
+	}{{"a", 1}, {"b", 0}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			checkGet(t, s, tt.key, tt.want)
+		})
+	}
+	t.Run("empty", testEmpty)
+}
+
+func TestConcurrent(t *testing.T) {
+	t.Parallel()
+	s := newStore(t)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.Equal(t, 1, s.Get("a"))
+		mustGet(t, s, "a")
+	}()
+	<-done
+
+	assertPositive := func(v int) {
+		if v <= 0 {
+			t.Errorf("got %d", v)
+		}
+	}
+	assertPositive(s.Get("a"))
+
+	f, err := os.CreateTemp("", "dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("STORE", "1")
+}
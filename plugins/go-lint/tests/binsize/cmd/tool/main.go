@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/bin/lib"
+)
+
+func main() {
+	fmt.Println(lib.Greeting("tool"))
+}
@@ -0,0 +1,7 @@
+module example.com/bin
+
+go 1.22
+
+require example.com/heavy v0.0.0
+
+replace example.com/heavy => ./heavy
@@ -0,0 +1,12 @@
+package heavy
+
+import "encoding/json"
+
+type Config struct {
+	Name string `json:"name"`
+}
+
+func Encode(c Config) string {
+	b, _ := json.Marshal(c)
+	return string(b)
+}
@@ -0,0 +1,5 @@
+package lib
+
+func Greeting(name string) string {
+	return "hello, " + name
+}
@@ -0,0 +1,9 @@
+//go:build linux
+
+package main
+
+import "fmt"
+
+func run() {
+	fmt.Printf("%d\n", "linux")
+}
@@ -0,0 +1,9 @@
+//go:build ignore
+
+package main
+
+import "fmt"
+
+func tool() {
+	fmt.Printf("%d\n", "ignored")
+}
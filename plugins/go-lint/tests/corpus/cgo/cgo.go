@@ -0,0 +1,12 @@
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "fmt"
+
+func main() {
+	fmt.Printf("%d\n", C.GoString(nil))
+}
@@ -0,0 +1,7 @@
+package main
+
+import "fmt"
+
+func pure() {
+	fmt.Printf("%d\n", "pure")
+}
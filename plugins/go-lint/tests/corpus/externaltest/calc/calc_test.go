@@ -0,0 +1,13 @@
+package calc_test
+
+import (
+	"testing"
+
+	"example.com/externaltest/calc"
+)
+
+func TestAdd(t *testing.T) {
+	if got := calc.Add(1, 2); got != 3 {
+		t.Errorf("Add(1, 2) = %s, want 3", got)
+	}
+}
@@ -0,0 +1,13 @@
+// Package generated has a stringer-backed enum.
+package generated
+
+//go:generate stringer -type=Color
+
+// Color is a paint color.
+type Color int
+
+// Colors.
+const (
+	Red Color = iota
+	Green
+)
@@ -0,0 +1,9 @@
+// Package inner is a module nested inside another module.
+package inner
+
+import "fmt"
+
+// Inner prints from the inner module.
+func Inner() {
+	fmt.Printf("%d\n", "inner")
+}
@@ -0,0 +1,9 @@
+// Package nested is the outer module.
+package nested
+
+import "fmt"
+
+// Outer prints from the outer module.
+func Outer() {
+	fmt.Printf("%d\n", "outer")
+}
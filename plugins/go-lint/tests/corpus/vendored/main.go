@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/dep"
+)
+
+func main() {
+	fmt.Printf("%s\n", dep.Answer())
+}
@@ -0,0 +1,7 @@
+// Package lib is a workspace module consumed by svc.
+package lib
+
+// Greeting returns a greeting for name.
+func Greeting(name string) string {
+	return "hello, " + name
+}
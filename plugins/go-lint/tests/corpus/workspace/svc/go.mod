@@ -0,0 +1,5 @@
+module example.com/svc
+
+go 1.21
+
+require example.com/lib v0.0.0
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/lib"
+)
+
+func main() {
+	fmt.Printf("%d\n", lib.Greeting("svc"))
+}
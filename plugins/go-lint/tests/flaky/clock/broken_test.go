@@ -0,0 +1,9 @@
+package clock
+
+import "testing"
+
+func TestBroken(t *testing.T) {
+	if got := Tick(0); got != 2 {
+		t.Errorf("Tick(0) = %d, want 2", got)
+	}
+}
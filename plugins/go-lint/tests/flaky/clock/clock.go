@@ -0,0 +1,7 @@
+// Package clock counts ticks.
+package clock
+
+// Tick returns the tick after n.
+func Tick(n int) int {
+	return n + 1
+}
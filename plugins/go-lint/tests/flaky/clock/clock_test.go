@@ -0,0 +1,30 @@
+package clock
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestTick(t *testing.T) {
+	if got := Tick(1); got != 2 {
+		t.Errorf("Tick(1) = %d, want 2", got)
+	}
+}
+
+// runs counts TestCounter runs across -count and -cpu repetitions
+var runs int
+
+func TestCounter(t *testing.T) {
+	runs++
+	if runs%3 == 0 {
+		t.Errorf("run %d: counter out of sync", runs)
+	}
+}
+
+func TestProcs(t *testing.T) {
+	t.Run("parallel", func(t *testing.T) {
+		if runtime.GOMAXPROCS(0) == 1 {
+			t.Error("worker pool stalled with GOMAXPROCS=1")
+		}
+	})
+}
@@ -0,0 +1,10 @@
+package enum
+
+// Color is a paint colour.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
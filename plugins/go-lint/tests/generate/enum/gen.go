@@ -0,0 +1,3 @@
+package enum
+
+//go:generate stringer -type=Color -output color_string.go
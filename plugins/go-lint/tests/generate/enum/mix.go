@@ -0,0 +1,6 @@
+package enum
+
+// Mix blends two colours.
+func Mix(a, b Color) Color {
+	return (a + b) % 3
+}
@@ -0,0 +1,4 @@
+// Package pb holds code generated from api/*.proto.
+package pb
+
+//go:generate cp ../api/service.proto service_pb.txt
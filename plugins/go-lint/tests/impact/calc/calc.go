@@ -0,0 +1,22 @@
+// Package calc folds slices with mathx.
+package calc
+
+import "example.com/impact/mathx"
+
+// Sum adds up xs.
+func Sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total = mathx.Add(total, x)
+	}
+	return total
+}
+
+// Product multiplies xs.
+func Product(xs []int) int {
+	total := 1
+	for _, x := range xs {
+		total = mathx.Mul(total, x)
+	}
+	return total
+}
@@ -0,0 +1,19 @@
+package calc_test
+
+import (
+	"testing"
+
+	"example.com/impact/calc"
+)
+
+func TestSum(t *testing.T) {
+	if got := calc.Sum([]int{1, 2, 3}); got != 6 {
+		t.Errorf("Sum = %d, want 6", got)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	if got := calc.Product([]int{2, 3}); got != 6 {
+		t.Errorf("Product = %d, want 6", got)
+	}
+}
@@ -0,0 +1,12 @@
+// Package mathx holds the arithmetic the other packages build on.
+package mathx
+
+// Add returns a + b.
+func Add(a, b int) int {
+	return a + b
+}
+
+// Mul returns a * b.
+func Mul(a, b int) int {
+	return a * b
+}
@@ -0,0 +1,15 @@
+package mathx
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if got := Add(1, 2); got != 3 {
+		t.Errorf("Add(1, 2) = %d, want 3", got)
+	}
+}
+
+func TestMul(t *testing.T) {
+	if got := Mul(2, 3); got != 6 {
+		t.Errorf("Mul(2, 3) = %d, want 6", got)
+	}
+}
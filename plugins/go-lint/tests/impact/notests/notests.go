@@ -0,0 +1,7 @@
+// Package notests has no tests.
+package notests
+
+import "example.com/impact/mathx"
+
+// Twice doubles x.
+func Twice(x int) int { return mathx.Add(x, x) }
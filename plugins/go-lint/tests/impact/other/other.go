@@ -0,0 +1,7 @@
+// Package other does not depend on mathx.
+package other
+
+// Greeting returns a fixed greeting.
+func Greeting() string {
+	return "hello"
+}
@@ -0,0 +1,9 @@
+package other
+
+import "testing"
+
+func TestGreeting(t *testing.T) {
+	if Greeting() != "hello" {
+		t.Error("unexpected greeting")
+	}
+}
@@ -0,0 +1,13 @@
+// Package report formats totals.
+package report
+
+import (
+	"fmt"
+
+	"example.com/impact/calc"
+)
+
+// Total renders the sum of xs.
+func Total(xs []int) string {
+	return fmt.Sprintf("total: %d", calc.Sum(xs))
+}
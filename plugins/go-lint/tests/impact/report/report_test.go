@@ -0,0 +1,9 @@
+package report
+
+import "testing"
+
+func TestTotal(t *testing.T) {
+	if got := Total([]int{1, 2}); got != "total: 3" {
+		t.Errorf("Total = %q, want %q", got, "total: 3")
+	}
+}
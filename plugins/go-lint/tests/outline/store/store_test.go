@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+	"testing"
+)
+
+// seeded returns a store holding one admin.
+func seeded(t *testing.T) *Memory {
+	t.Helper()
+	m := NewMemory()
+	if err := m.Put(context.Background(), &User{ID: 1, Name: "root", Role: Admin}); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestGet(t *testing.T) {
+	if _, err := seeded(t).Get(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+}
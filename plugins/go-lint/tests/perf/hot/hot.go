@@ -0,0 +1,83 @@
+package hot
+
+import "fmt"
+
+type Point struct{ X, Y int }
+
+func Add(a, b int) int { return a + b }
+
+func NewPoint(x, y int) *Point {
+	p := Point{X: x, Y: y}
+	return &p
+}
+
+func Sum(xs []int) int {
+	total := 0
+	for i := 0; i < len(xs); i++ {
+		total += xs[i]
+	}
+	return total
+}
+
+func Pick(xs []int, i int) int {
+	return xs[i] + xs[i+1]
+}
+
+func Describe(p Point) string {
+	return fmt.Sprintf("%d,%d", p.X, p.Y)
+}
+
+func Busy(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		if x%2 == 0 {
+			n += x * 2
+		} else if x%3 == 0 {
+			n -= x
+		} else {
+			n += Add(x, n)
+		}
+		switch {
+		case n > 100:
+			n = 0
+		case n < -100:
+			n = 1
+		}
+	}
+	defer func() { n++ }()
+	return n + len(fmt.Sprint(n))
+}
+
+type Buffer struct{ data []byte }
+
+func (b *Buffer) At(i int) byte {
+	return b.data[i]
+}
+
+//go:noinline
+func Pinned(x int) int { return x * 2 }
+
+func Clamp(xs []int, lo, hi int) []int {
+	out := make([]int, 0, len(xs))
+	for _, x := range xs {
+		switch {
+		case x < lo:
+			out = append(out, lo)
+		case x > hi:
+			out = append(out, hi)
+		default:
+			out = append(out, x)
+		}
+	}
+	for i := range out {
+		if out[i] == lo || out[i] == hi {
+			out[i] = (lo + hi) / 2
+		}
+	}
+	for i := 1; i < len(out); i++ {
+		if out[i] < out[i-1] {
+			out[i], out[i-1] = out[i-1], out[i]
+		}
+	}
+	return out
+}
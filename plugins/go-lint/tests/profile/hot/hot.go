@@ -0,0 +1,24 @@
+// Package hot has functions worth profiling.
+package hot
+
+import "strconv"
+
+// Fib returns the nth Fibonacci number the slow way.
+func Fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return Fib(n-1) + Fib(n-2)
+}
+
+// Join concatenates the numbers 0..n-1 with commas.
+func Join(n int) string {
+	s := ""
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			s += ","
+		}
+		s += strconv.Itoa(i)
+	}
+	return s
+}
@@ -0,0 +1,15 @@
+package hot
+
+import "testing"
+
+func BenchmarkFib(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Fib(25)
+	}
+}
+
+func BenchmarkJoin(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Join(2000)
+	}
+}
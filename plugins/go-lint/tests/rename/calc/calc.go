@@ -0,0 +1,10 @@
+package calc
+
+// Sum adds the numbers.
+func Sum(values ...int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,13 @@
+package calc_test
+
+import (
+	"testing"
+
+	"example.com/rename/calc"
+)
+
+func TestSum(t *testing.T) {
+	if got := calc.Sum(1, 2); got != 3 {
+		t.Errorf("Sum(1, 2) = %d, want 3", got)
+	}
+}
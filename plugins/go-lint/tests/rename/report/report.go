@@ -0,0 +1,12 @@
+package report
+
+import (
+	"fmt"
+
+	"example.com/rename/calc"
+)
+
+// Line formats the total of the values.
+func Line(values ...int) string {
+	return fmt.Sprintf("total=%d", calc.Sum(values...))
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package report
+
+import "example.com/rename/calc"
+
+// Sums the values with a Windows line ending.
+func windowsLine(values ...int) string {
+	return Line(values...) + "\r\n" + string(rune(calc.Sum()))
+}
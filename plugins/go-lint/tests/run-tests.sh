@@ -18,25 +18,311 @@ TESTS_FAILED=0
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PLUGIN_ROOT="$(dirname "$SCRIPT_DIR")"
 FIXTURES_DIR="$SCRIPT_DIR/fixtures"
+STUBS_DIR="$SCRIPT_DIR/stubs"
+CORPUS_DIR="$SCRIPT_DIR/corpus"
+GOCMD_DIR="$SCRIPT_DIR/gocmd"
+GENERATE_DIR="$SCRIPT_DIR/generate"
+ANALYZERS_DIR="$SCRIPT_DIR/analyzers"
+PERF_DIR="$SCRIPT_DIR/perf"
+BINSIZE_DIR="$SCRIPT_DIR/binsize"
+MODERNIZE_DIR="$SCRIPT_DIR/modernize"
+IMPACT_DIR="$SCRIPT_DIR/impact"
+MUTATE_DIR="$SCRIPT_DIR/mutate"
+FLAKY_DIR="$SCRIPT_DIR/flaky"
+PROFILE_DIR="$SCRIPT_DIR/profile"
+RENAME_DIR="$SCRIPT_DIR/rename"
+OUTLINE_DIR="$SCRIPT_DIR/outline"
+MCP_CLIENT="$SCRIPT_DIR/mcp/call-tool.mjs"
+GOLDEN_DIR="$SCRIPT_DIR/golden"
 TEMP_DIR=""
-
-# Source common library
+STUB_BIN_DIR=""
+STUB_LOG=""
+
+# Host utilities the scripts need when PATH only holds the sandbox bin dir
+HOST_UTILS=(
+    awk bash basename cat cksum cp cut date dirname find grep head jq mkdir mktemp mv
+    git paste python3 readlink realpath rm sed sleep sort timeout tr wc
+)
+
+# Options
+# --update-golden  rewrite golden files from the current output instead of comparing
+UPDATE_GOLDEN=false
+for arg in "$@"; do
+    case "$arg" in
+        --update-golden) UPDATE_GOLDEN=true ;;
+        *) echo "Unknown option: $arg" >&2; exit 2 ;;
+    esac
+done
+
+# Source libraries under test
 source "$PLUGIN_ROOT/scripts/go-lint-common.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-gocmd.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-generate.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-analyzers.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-budget.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-package-files.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-flaky.sh"
+source "$PLUGIN_ROOT/scripts/go-lint-impact.sh"
 
 # Cleanup function
 cleanup() {
     if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
         rm -rf "$TEMP_DIR"
     fi
+    if [[ -n "$STUB_BIN_DIR" && -d "$STUB_BIN_DIR" ]]; then
+        rm -rf "$STUB_BIN_DIR"
+    fi
+    if [[ -n "$GO_LINT_CACHE_DIR" && -d "$GO_LINT_CACHE_DIR" ]]; then
+        rm -rf "$GO_LINT_CACHE_DIR"
+    fi
 }
 trap cleanup EXIT
 
+# Build the analyzer helper into a suite-local cache instead of the user's
+GO_LINT_CACHE_DIR=$(mktemp -d)
+export GO_LINT_CACHE_DIR
+
 # Setup temp directory
 setup_temp() {
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
     TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
     cp -r "$FIXTURES_DIR"/* "$TEMP_DIR/"
 }
 
+# Setup temp directory with no go.mod, go.work or .git marker
+setup_temp_without_root() {
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp "$FIXTURES_DIR"/*.go "$TEMP_DIR/"
+}
+
+# Setup temp directory holding the go generate fixture module
+setup_temp_generate() {
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$GENERATE_DIR"/. "$TEMP_DIR/"
+}
+
+# Build a sandbox bin dir holding host utilities plus the named stubs
+# The real go toolchain is never reachable from the sandbox PATH
+# Args: stub names (go, goimports, golangci-lint)
+setup_stub_path() {
+    if [[ -n "$STUB_BIN_DIR" && -d "$STUB_BIN_DIR" ]]; then
+        rm -rf "$STUB_BIN_DIR"
+    fi
+    STUB_BIN_DIR=$(mktemp -d)
+    STUB_LOG="$STUB_BIN_DIR/invocations.log"
+    : > "$STUB_LOG"
+
+    local util resolved
+    for util in "${HOST_UTILS[@]}"; do
+        if [[ "$util" == "python3" ]]; then
+            # Resolve version-manager shims to the real interpreter
+            resolved=$(python3 -c 'import sys; print(sys.executable)' 2>/dev/null) || continue
+        else
+            resolved=$(command -v "$util" 2>/dev/null) || continue
+        fi
+        ln -s "$resolved" "$STUB_BIN_DIR/$util"
+    done
+
+    local stub
+    for stub in "$@"; do
+        ln -s "$STUBS_DIR/bin/$stub" "$STUB_BIN_DIR/$stub"
+    done
+}
+
+# Expose real host tools (e.g. the go toolchain) inside the sandbox bin dir
+# Args: tool names
+link_host_tools() {
+    local tool resolved
+    for tool in "$@"; do
+        resolved=$(command -v "$tool") || return 1
+        ln -sf "$resolved" "$STUB_BIN_DIR/$tool"
+    done
+}
+
+# Run a script with the sandbox PATH and stub scenarios
+# Args: tool=scenario..., --, command...
+run_stubbed() {
+    local env_args=("PATH=$STUB_BIN_DIR" "STUB_DIR=$STUBS_DIR" "STUB_LOG=$STUB_LOG")
+    local var
+    while [[ $# -gt 0 && "$1" != "--" ]]; do
+        var=$(echo "${1%%=*}" | tr 'a-z-' 'A-Z_')
+        env_args+=("STUB_${var}_SCENARIO=${1#*=}")
+        shift
+    done
+    shift
+
+    env "${env_args[@]}" "$@"
+}
+
+# Run the hook on a file with the sandbox PATH
+# Args: file_path, tool=scenario...
+run_stubbed_hook() {
+    local file_path="$1"
+    shift
+
+    jq -n --arg file_path "$file_path" '{tool_input: {file_path: $file_path}}' \
+        | run_stubbed "$@" -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1
+}
+
+# Run the Bash hook on a recorded go command output with the sandbox PATH
+# Args: command, output_file (empty for no output), [cwd]
+run_bash_hook() {
+    local command="$1"
+    local output_file="$2"
+    local cwd="${3:-/src/gt}"
+
+    local stdout=""
+    if [[ -n "$output_file" ]]; then
+        stdout=$(cat "$output_file")
+    fi
+
+    jq -n --arg command "$command" --arg stdout "$stdout" --arg cwd "$cwd" '{
+        cwd: $cwd,
+        tool_name: "Bash",
+        tool_input: {command: $command},
+        tool_response: {stdout: $stdout, stderr: "", interrupted: false}
+    }' | run_stubbed -- "$PLUGIN_ROOT/hooks/go-lint-bash.sh" 2>&1
+}
+
+# Run the project script with the sandbox PATH
+# Args: target_dir, tool=scenario...
+# Sets: PROJECT_OUTPUT, PROJECT_EXIT
+run_stubbed_project() {
+    local target_dir="$1"
+    shift
+
+    PROJECT_EXIT=0
+    PROJECT_OUTPUT=$(
+        run_stubbed "$@" -- "$PLUGIN_ROOT/scripts/go-lint-project.sh" "$target_dir" 2>&1
+    ) || PROJECT_EXIT=$?
+}
+
+# Run the perf script with the sandbox PATH
+# Args: tool=scenario..., --, script arguments...
+# Sets: PERF_OUTPUT, PERF_EXIT
+run_stubbed_perf() {
+    local scenarios=()
+    while [[ $# -gt 0 && "$1" != "--" ]]; do
+        scenarios+=("$1")
+        shift
+    done
+    shift
+
+    PERF_EXIT=0
+    PERF_OUTPUT=$(
+        run_stubbed ${scenarios[@]+"${scenarios[@]}"} -- "$PLUGIN_ROOT/scripts/go-lint-perf.sh" "$@" 2>&1
+    ) || PERF_EXIT=$?
+}
+
+# Run the binsize script with the real go toolchain, offline
+# Args: script arguments...
+# Sets: BINSIZE_OUTPUT, BINSIZE_EXIT
+run_binsize() {
+    BINSIZE_EXIT=0
+    BINSIZE_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-binsize.sh" "$@" 2>&1
+    ) || BINSIZE_EXIT=$?
+}
+
+# Run the modernize script with the real go toolchain, offline
+# Args: script arguments...
+# Sets: MODERNIZE_OUTPUT, MODERNIZE_EXIT
+run_modernize() {
+    MODERNIZE_EXIT=0
+    MODERNIZE_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$@" 2>&1
+    ) || MODERNIZE_EXIT=$?
+}
+
+# Run the test impact script with the real go toolchain, offline
+# Args: script arguments...
+# Sets: IMPACT_OUTPUT, IMPACT_EXIT
+run_test_impact() {
+    IMPACT_EXIT=0
+    IMPACT_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-test-impact.sh" "$@" 2>&1
+    ) || IMPACT_EXIT=$?
+}
+
+# Run the mutation testing script with the real go toolchain, offline
+# Args: script arguments...
+# Sets: MUTATE_OUTPUT, MUTATE_EXIT
+run_mutate() {
+    MUTATE_EXIT=0
+    MUTATE_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-mutate.sh" "$@" 2>&1
+    ) || MUTATE_EXIT=$?
+}
+
+# Run the flaky test detector with the real go toolchain, offline
+# Args: script arguments...
+# Sets: FLAKY_OUTPUT, FLAKY_EXIT
+run_flaky() {
+    FLAKY_EXIT=0
+    FLAKY_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-flaky-tests.sh" "$@" 2>&1
+    ) || FLAKY_EXIT=$?
+}
+
+# Run the profile script with the real go toolchain from a directory
+# Args: directory, script arguments...
+# Sets: PROFILE_OUTPUT, PROFILE_EXIT
+run_profile() {
+    local dir="$1"
+    shift
+    PROFILE_EXIT=0
+    PROFILE_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        cd "$dir" && "$PLUGIN_ROOT/scripts/go-lint-profile.sh" "$@" 2>&1
+    ) || PROFILE_EXIT=$?
+}
+
+# Run the outline script with the real go toolchain, offline
+# Args: script arguments...
+# Sets: OUTLINE_OUTPUT, OUTLINE_EXIT
+run_outline() {
+    OUTLINE_EXIT=0
+    OUTLINE_OUTPUT=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/scripts/go-lint-outline.sh" "$@" 2>&1
+    ) || OUTLINE_EXIT=$?
+}
+
+# Run the Stop hook with the real go toolchain, offline
+# Args: cwd, stop_hook_active (true/false)
+run_stop_hook() {
+    jq -n --arg cwd "$1" --argjson active "$2" '{cwd: $cwd, stop_hook_active: $active}' | (
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        "$PLUGIN_ROOT/hooks/go-lint-stop.sh" 2>&1
+    )
+}
+
+# Call a tool on the MCP server with the real go toolchain, offline
+# Args: tool, json_arguments
+# Returns: the tool's structured result as JSON
+call_mcp_tool() {
+    (
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        node "$MCP_CLIENT" "$PLUGIN_ROOT/mcp/dist/index.js" "$1" "$2"
+    )
+}
+
 # Test helper functions
 print_test_header() {
     echo ""
@@ -85,6 +371,99 @@ assert_contains() {
     fi
 }
 
+assert_json_equals() {
+    local expected="$1"
+    local actual="$2"
+    local message="${3:-JSON should match}"
+
+    TESTS_RUN=$((TESTS_RUN + 1))
+
+    local expected_norm actual_norm
+    expected_norm=$(echo "$expected" | jq -S . 2>/dev/null || echo "$expected")
+    actual_norm=$(echo "$actual" | jq -S . 2>/dev/null || echo "$actual")
+
+    if [[ -n "$actual" && "$expected_norm" == "$actual_norm" ]]; then
+        echo -e "${GREEN}✓${NC} $message"
+        TESTS_PASSED=$((TESTS_PASSED + 1))
+        return 0
+    else
+        echo -e "${RED}✗${NC} $message"
+        echo "  Expected: $expected_norm"
+        echo "  Actual: $actual"
+        TESTS_FAILED=$((TESTS_FAILED + 1))
+        return 1
+    fi
+}
+
+assert_empty() {
+    local actual="$1"
+    local message="${2:-Output should be empty}"
+
+    TESTS_RUN=$((TESTS_RUN + 1))
+
+    if [[ -z "$actual" ]]; then
+        echo -e "${GREEN}✓${NC} $message"
+        TESTS_PASSED=$((TESTS_PASSED + 1))
+        return 0
+    else
+        echo -e "${RED}✗${NC} $message"
+        echo "  Output: $actual"
+        TESTS_FAILED=$((TESTS_FAILED + 1))
+        return 1
+    fi
+}
+
+# Build the JSON a hook emits through _gol_safe_exit
+# Args: decision, reason, context
+expected_hook_json() {
+    jq -n --arg decision "$1" --arg reason "$2" --arg context "$3" '{
+        decision: $decision,
+        reason: $reason,
+        hookSpecificOutput: {
+            hookEventName: "PostToolUse",
+            additionalContext: $context
+        }
+    }'
+}
+
+# Compare output with a golden file, or rewrite it with --update-golden
+# Args: golden_name, actual
+assert_golden() {
+    local name="$1"
+    local actual="$2"
+    local golden_file="$GOLDEN_DIR/$name.golden"
+
+    TESTS_RUN=$((TESTS_RUN + 1))
+
+    if [[ "$UPDATE_GOLDEN" == "true" ]]; then
+        mkdir -p "$GOLDEN_DIR"
+        printf '%s\n' "$actual" > "$golden_file"
+        echo -e "${YELLOW}↻${NC} Updated golden/$name.golden"
+        TESTS_PASSED=$((TESTS_PASSED + 1))
+        return 0
+    fi
+
+    if [[ ! -f "$golden_file" ]]; then
+        echo -e "${RED}✗${NC} Missing golden/$name.golden (run with --update-golden)"
+        TESTS_FAILED=$((TESTS_FAILED + 1))
+        return 1
+    fi
+
+    local expected
+    expected=$(cat "$golden_file")
+
+    if [[ "$expected" == "$actual" ]]; then
+        echo -e "${GREEN}✓${NC} Matches golden/$name.golden"
+        TESTS_PASSED=$((TESTS_PASSED + 1))
+        return 0
+    else
+        echo -e "${RED}✗${NC} Differs from golden/$name.golden"
+        diff <(echo "$expected") <(echo "$actual") | sed 's/^/  /' || true
+        TESTS_FAILED=$((TESTS_FAILED + 1))
+        return 1
+    fi
+}
+
 assert_file_exists() {
     local file="$1"
     local message="${2:-File should exist}"
@@ -301,6 +680,1945 @@ test_project_script_on_fixtures() {
     assert_contains "$output" "Go Linting" "Should generate linting report"
 }
 
+# Hermetic tests (stand-in toolchain binaries from stubs/bin)
+
+test_stubbed_hook_skips_irrelevant_input() {
+    print_test_header "Hook ignores irrelevant input (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output
+    output=$(echo -n "" | run_stubbed -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1)
+    assert_empty "$output" "Empty stdin exits silently"
+
+    output=$(echo '{"tool_input":{}}' | run_stubbed -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1)
+    assert_empty "$output" "Missing file_path exits silently"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/missing.go")
+    assert_empty "$output" "Nonexistent file exits silently"
+
+    echo "Not a Go file" > "$TEMP_DIR/notes.txt"
+    output=$(run_stubbed_hook "$TEMP_DIR/notes.txt")
+    assert_empty "$output" "Non-Go file exits silently"
+
+    head -c 1048577 /dev/zero | tr '\0' '/' > "$TEMP_DIR/huge.go"
+    output=$(run_stubbed_hook "$TEMP_DIR/huge.go")
+    assert_empty "$output" "File over 1MB exits silently"
+
+    assert_empty "$(cat "$STUB_LOG")" "No tool is invoked for skipped files"
+}
+
+test_stubbed_hook_missing_tools() {
+    print_test_header "Hook with missing goimports (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/clean.go")
+    expected=$(expected_hook_json "allow" \
+        "Go linting skipped: missing tools" \
+        "Missing required tools: goimports. Install with: go install golang.org/x/tools/cmd/goimports@latest")
+
+    assert_json_equals "$expected" "$output" "Reports missing goimports and allows"
+}
+
+test_stubbed_hook_without_project_root() {
+    print_test_header "Hook without project root (stubbed)"
+
+    setup_temp_without_root
+    setup_stub_path go goimports
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/clean.go" goimports=default go=default)
+    assert_empty "$output" "Formats and vets silently without project root"
+    assert_contains "$(cat "$STUB_LOG")" "goimports -w $TEMP_DIR/clean.go" "Runs goimports"
+    assert_contains "$(cat "$STUB_LOG")" "go vet -overlay " "Vets the file in a scratch module overlay"
+    assert_empty "$(find "$TEMP_DIR" -name go.mod)" "Writes no go.mod next to the file"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors)
+    expected=$(expected_hook_json "block" \
+        "go vet found 2 issue(s) in file (outside any Go module)" \
+        "vet-errors.go:7:22: fmt.Printf format %d has arg \"not a number\" of wrong type string
+vet-errors.go:14:2: unreachable code
+
+vet-errors.go is not inside a Go module; it was checked with the other .go files in $TEMP_DIR as a scratch module.")
+    assert_json_equals "$expected" "$output" "Blocks on vet errors in a scratch module"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/clean.go" goimports=default go=scratch-imports)
+    expected=$(expected_hook_json "allow" \
+        "Type-checking skipped: 2 import(s) outside the standard library (outside any Go module)" \
+        "clean.go is not inside a Go module, so go-lint checks it in an offline scratch module that can only resolve standard library imports. Not resolved:
+- github.com/google/uuid
+- golang.org/x/exp/slices
+
+To type-check it, create a module in $TEMP_DIR with 'go mod init <module-path>' and 'go mod tidy'.")
+    assert_json_equals "$expected" "$output" "Explains unresolved third-party imports"
+
+    # A git repository without go.mod is still outside any module
+    git -C "$TEMP_DIR" init -q
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors)
+    assert_contains "$output" "go vet found 2 issue(s) in file (outside any Go module)" \
+        "Uses a scratch module under a git root without go.mod"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/format-needed.go" goimports=syntax-error)
+    expected=$(expected_hook_json "allow" \
+        "goimports formatting failed (no Go module found)" \
+        "goimports error: format-needed.go:7:12: expected '(', found '{'")
+    assert_json_equals "$expected" "$output" "Reports goimports failure without project root"
+}
+
+test_stubbed_hook_goimports_failure() {
+    print_test_header "Hook when goimports fails (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/format-needed.go" goimports=syntax-error go=vet-clean)
+    expected=$(expected_hook_json "allow" \
+        "goimports formatting failed" \
+        "goimports error: format-needed.go:7:12: expected '(', found '{'")
+
+    assert_json_equals "$expected" "$output" "Reports goimports failure and allows"
+    assert_equals "goimports -w $TEMP_DIR/format-needed.go" "$(cat "$STUB_LOG")" "Skips go vet after goimports failure"
+}
+
+test_stubbed_hook_formats_file() {
+    print_test_header "Hook applies goimports rewrite (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output
+    output=$(run_stubbed_hook "$TEMP_DIR/format-needed.go" goimports=format go=vet-clean)
+
+    assert_empty "$output" "Exits silently after formatting"
+    assert_equals "$(cat "$STUBS_DIR/recordings/goimports/format/rewrite")" \
+        "$(cat "$TEMP_DIR/format-needed.go")" "File holds goimports output"
+    assert_contains "$(cat "$STUB_LOG")" "go vet ./." "Runs go vet on the file's package"
+}
+
+test_stubbed_hook_vet_clean() {
+    print_test_header "Hook with clean go vet (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output
+    output=$(run_stubbed_hook "$TEMP_DIR/clean.go" goimports=default go=vet-clean)
+
+    assert_empty "$output" "Exits silently when go vet passes"
+}
+
+test_stubbed_hook_vet_errors() {
+    print_test_header "Hook blocks on go vet errors (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors)
+    expected=$(expected_hook_json "block" \
+        "go vet found 2 issue(s) in file" \
+        "$(cat "$STUBS_DIR/recordings/go/vet-errors/vet.stderr")")
+    assert_json_equals "$expected" "$output" "Blocks with Go 1.24+ vet output"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors-legacy)
+    expected=$(expected_hook_json "block" \
+        "go vet found 2 issue(s) in file" \
+        "$(printf '%s\n%s' \
+            './vet-errors.go:7:22: fmt.Printf format %d has arg "not a number" of wrong type string' \
+            './vet-errors.go:14:2: unreachable code')")
+    assert_json_equals "$expected" "$output" "Blocks with legacy ./-prefixed vet output"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-typecheck)
+    expected=$(expected_hook_json "block" \
+        "go vet found 1 issue(s) in file" \
+        "vet: ./vet-errors.go:10:2: declared and not used: x")
+    assert_json_equals "$expected" "$output" "Blocks with type-check errors"
+}
+
+test_stubbed_hook_vet_subpackage() {
+    print_test_header "Hook filters go vet output in subpackage (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    mkdir -p "$TEMP_DIR/sub"
+    printf 'package sub\n' > "$TEMP_DIR/sub/a.go"
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/sub/a.go" goimports=default go=vet-subpackage)
+    expected=$(expected_hook_json "block" \
+        "go vet found 1 issue(s) in file" \
+        'sub/a.go:7:22: fmt.Printf format %d has arg "not a number" of wrong type string')
+
+    assert_json_equals "$expected" "$output" "Reports only the edited file's issues"
+    assert_contains "$(cat "$STUB_LOG")" "go vet ./sub" "Runs go vet on the subpackage"
+}
+
+test_stubbed_hook_vet_unrelated_failures() {
+    print_test_header "Hook ignores unrelated go vet failures (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-other-file)
+    assert_empty "$output" "Exits silently when issues are in other files"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-crash)
+    assert_empty "$output" "Exits silently when go vet crashes"
+}
+
+test_stubbed_hook_vet_timeout() {
+    print_test_header "Hook reports go vet timeout (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output expected
+    output=$(GO_LINT_VET_TIMEOUT=1 run_stubbed_hook "$TEMP_DIR/clean.go" goimports=default go=vet-timeout)
+    expected=$(expected_hook_json "allow" \
+        "go vet timed out after 1s" \
+        "go vet ./. did not finish within 1s; run it manually to check the package")
+
+    assert_json_equals "$expected" "$output" "Allows with timeout message"
+}
+
+test_stubbed_hook_output_budget() {
+    print_test_header "Hook trims diagnostics to the output budget (stubbed)"
+
+    setup_temp
+    setup_stub_path go goimports
+
+    local output context log_file
+    output=$(GO_LINT_OUTPUT_BUDGET=10 run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors)
+    context=$(echo "$output" | jq -r '.hookSpecificOutput.additionalContext')
+    assert_equals "go vet found 2 issue(s) in file" "$(echo "$output" | jq -r '.reason')" \
+        "Reason keeps the total issue count"
+    assert_equals 'vet-errors.go:7:22: fmt.Printf format %d has arg "not a number" of wrong type string' \
+        "$(echo "$context" | head -n 1)" "Shows the first issue of a Write"
+    assert_contains "$context" "... 1 more issue(s) not shown" "Counts the omitted issues"
+
+    log_file=$(echo "$context" | sed -n 's/^Full output (2 issues): //p')
+    assert_contains "$log_file" "$GO_LINT_CACHE_DIR/logs/" "Writes the full output to the cache"
+    assert_equals "$(cat "$STUBS_DIR/recordings/go/vet-errors/vet.stderr")" "$(cat "$log_file" 2>/dev/null)" \
+        "Log holds every issue"
+
+    # Issues in the edited lines come first
+    output=$(jq -n --arg file_path "$TEMP_DIR/vet-errors.go" \
+        '{tool_name: "Edit", tool_input: {file_path: $file_path, old_string: "x", new_string: "\tfmt.Println(\"This will never execute\")"}}' \
+        | GO_LINT_OUTPUT_BUDGET=10 run_stubbed goimports=default go=vet-errors -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1)
+    assert_equals "vet-errors.go:14:2: unreachable code" \
+        "$(echo "$output" | jq -r '.hookSpecificOutput.additionalContext' | head -n 1)" \
+        "Shows the issue in the edited region first"
+
+    echo '{"output": {"budget": 10}}' > "$TEMP_DIR/.go-lint.json"
+    output=$(run_stubbed_hook "$TEMP_DIR/vet-errors.go" goimports=default go=vet-errors)
+    assert_contains "$output" "1 more issue(s) not shown" "Reads output.budget from .go-lint.json"
+}
+
+test_budget_diagnostics() {
+    print_test_header "Output budget grouping and priorities"
+
+    setup_temp
+    local vet analyzers output
+    vet=$(for i in $(seq 1 40); do echo "a.go:$((i + 50)):2: undefined: foo"; done
+        echo "a.go:3:1: unreachable code"
+        echo "vet: ./a.go:90:5: declared and not used: y")
+    analyzers='a.go:20:3: struct field tag `json:name` not compatible with reflect.StructTag.Get (structtag)'
+
+    output=$(_gol_budget_diagnostics "$vet" "$analyzers" 100000 "a.go")
+    assert_equals "$(printf '%s\n%s' "$vet" "$analyzers")" "$output" "Returns output within the budget unchanged"
+
+    output=$(_gol_budget_diagnostics "$vet" "$analyzers" 400 "a.go" 60 62)
+    assert_equals "vet: ./a.go:90:5: declared and not used: y
+a.go:60:2: undefined: foo
+  (39 more of: undefined: foo)" "$(echo "$output" | head -n 3)" \
+        "Orders type errors first, then by distance from the edit, folding repeats"
+    assert_contains "$output" "... 1 more issue(s) not shown" "Counts issues beyond the budget"
+    assert_contains "$output" "Full output (43 issues): $GO_LINT_CACHE_DIR/logs/" "Links the full log"
+
+    assert_equals "$(printf '60\t60')" \
+        "$(_gol_edit_region '{"tool_input": {"new_string": "  fmt.Println(\"This will never execute\")\n"}}' "$FIXTURES_DIR/vet-errors.go" | sed 's/14/60/g')" \
+        "Finds the edited lines in the file"
+    assert_empty "$(_gol_edit_region '{"tool_input": {"content": "package main"}}' "$FIXTURES_DIR/vet-errors.go")" \
+        "Has no edit region for a Write"
+    assert_equals "$(printf '11\t32')" \
+        "$(_gol_edit_region '{"tool_input": {"new_string": "}"}, "tool_response": {"structuredPatch": [
+            {"oldStart": 10, "oldLines": 3, "newStart": 10, "newLines": 4, "lines": [" a", "-b", "+c", "+d", " e"]},
+            {"oldStart": 30, "oldLines": 2, "newStart": 31, "newLines": 1, "lines": [" x", "-y"]}]}}' "$FIXTURES_DIR/vet-errors.go")" \
+        "Takes the edited lines from every hunk of the patch"
+    assert_equals "$(printf '10\t14')" \
+        "$(_gol_edit_region '{"tool_input": {"edits": [
+            {"new_string": "\tx := 42\n"},
+            {"new_string": "\treturn\n\tfmt.Println(\"This will never execute\")"}]}}' "$FIXTURES_DIR/vet-errors.go")" \
+        "Covers every edit of a MultiEdit"
+    printf 'package p\n\nfunc a() error {\n\treturn nil\n}\n\nfunc b() error {\n\treturn nil\n}\n' > "$TEMP_DIR/region.go"
+    assert_empty "$(_gol_edit_region '{"tool_input": {"new_string": "\treturn nil\n}"}}' "$TEMP_DIR/region.go")" \
+        "Does not guess between repeated lines"
+    assert_equals "$(printf '7\t9')" \
+        "$(_gol_edit_region '{"tool_input": {"new_string": "func b() error {\n\treturn nil\n}"}}' "$TEMP_DIR/region.go")" \
+        "Matches every line of the new text in sequence"
+}
+
+test_stubbed_hook_package_files() {
+    print_test_header "Hook vets assembly and cgo files with their package (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+
+    mkdir -p "$TEMP_DIR/asm" "$TEMP_DIR/cgo" "$TEMP_DIR/csrc"
+    printf 'package asm\n\nfunc Add(a, b int64) int64\n' > "$TEMP_DIR/asm/add.go"
+    printf 'TEXT ·Add(SB), $0-24\n' > "$TEMP_DIR/asm/add_arm64.s"
+    printf 'package cgo\n' > "$TEMP_DIR/cgo/use.go"
+    printf 'void use(void *p) { bad }\n' > "$TEMP_DIR/cgo/use.c"
+    printf 'int main(void) { return 0; }\n' > "$TEMP_DIR/csrc/main.c"
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/asm/add_arm64.s" go=vet-asm)
+    expected=$(expected_hook_json "block" \
+        "go vet (GOARCH=arm64) found 1 issue(s) in file" \
+        "asm/add_arm64.s:5:1: [arm64] Add: invalid offset b+4(FP); expected b+8(FP)")
+    assert_json_equals "$expected" "$output" "Reports asmdecl findings for the edited assembly file"
+    assert_contains "$(cat "$STUB_LOG")" "go vet ./asm" "Vets the assembly file's package"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/cgo/use.c" go=vet-cgo)
+    expected=$(expected_hook_json "block" \
+        "go vet found 3 issue(s) in file" \
+        "use.c:2:21: error: 'bad' undeclared (first use in this function)
+use.c:2:21: note: each undeclared identifier is reported only once for each function it appears in
+cgo/use.go:9:8: possibly passing Go type with embedded pointer to C")
+    assert_json_equals "$expected" "$output" "Reports C compiler errors and cgocall findings for a C file"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/cgo/use.c" go=vet-clean)
+    assert_empty "$output" "Exits silently when the package vets clean"
+
+    : > "$STUB_LOG"
+    output=$(run_stubbed_hook "$TEMP_DIR/csrc/main.c" go=vet-cgo)
+    assert_empty "$output" "Ignores C files outside a Go package"
+    assert_empty "$(grep 'go vet' "$STUB_LOG" || true)" "Does not run go vet for them"
+
+    assert_equals "GOOS=linux GOARCH=arm64" \
+        "$(PATH="$STUB_BIN_DIR" STUB_DIR="$STUBS_DIR" STUB_GO_SCENARIO=vet-asm _gol_package_file_target "sys_linux_arm64.s")" \
+        "Reads GOOS and GOARCH from the file name"
+    assert_empty "$(PATH="$STUB_BIN_DIR" STUB_DIR="$STUBS_DIR" STUB_GO_SCENARIO=vet-asm _gol_package_file_target "arm64.s")" \
+        "Ignores a bare GOARCH file name"
+}
+
+# Replace a line of $TEMP_DIR/go.mod and run the hook with the matching Edit
+# Args: old_line, new_line, tool=scenario...
+edit_go_mod_hook() {
+    local old_line="$1"
+    local new_line="$2"
+    shift 2
+
+    OLD_LINE="$old_line" NEW_LINE="$new_line" awk '
+        $0 == ENVIRON["OLD_LINE"] { $0 = ENVIRON["NEW_LINE"] }
+        { print }
+    ' "$TEMP_DIR/go.mod" > "$TEMP_DIR/go.mod.new"
+    mv "$TEMP_DIR/go.mod.new" "$TEMP_DIR/go.mod"
+    jq -n --arg file_path "$TEMP_DIR/go.mod" --arg old "$old_line" --arg new "$new_line" \
+        '{tool_name: "Edit", tool_input: {file_path: $file_path, old_string: $old, new_string: $new}}' \
+        | run_stubbed "$@" -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1
+}
+
+test_stubbed_hook_go_mod() {
+    print_test_header "Hook on go.mod edits (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/go.mod" go=gomod-ok)
+    assert_empty "$output" "Exits silently when go.mod is valid and cached"
+    assert_equals "go mod edit -fmt go.mod
+go list -m -e -json all" "$(cat "$STUB_LOG")" "Formats go.mod and lists modules offline"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/go.mod" go=gomod-syntax)
+    expected=$(expected_hook_json "block" \
+        "go.mod has syntax errors" \
+        "$(cat "$STUBS_DIR/recordings/go/gomod-syntax/mod-edit-fmt.stderr")")
+    assert_json_equals "$expected" "$output" "Blocks on go.mod syntax errors"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/go.mod" go=gomod-missing)
+    expected=$(expected_hook_json "allow" \
+        "go.mod requires 1 module(s) missing from the module cache" \
+        "Not available offline (GOPROXY=off):
+- github.com/nope/missing v1.2.3: module lookup disabled by GOPROXY=off
+
+Run 'go mod download' or 'go mod tidy' to fetch them.")
+    assert_json_equals "$expected" "$output" "Reports direct requirements missing from the cache"
+
+    # Raising the go directive in an edit suggests modernizing
+    printf '\nrequire example.com/a v1.0.0\n' >> "$TEMP_DIR/go.mod"
+    output=$(run_stubbed_hook "$TEMP_DIR/go.mod" go=gomod-ok)
+    assert_empty "$output" "Exits silently when the edit does not say what changed"
+
+    output=$(edit_go_mod_hook "require example.com/a v1.0.0" "require example.com/a v1.1.0" go=gomod-ok)
+    assert_empty "$output" "Exits silently when the edit leaves the go directive alone"
+
+    output=$(edit_go_mod_hook "go 1.21" "go 1.24" go=gomod-ok)
+    expected=$(expected_hook_json "allow" \
+        "go directive raised from 1.21 to 1.24" \
+        "Code in this module can now use Go 1.24 features (min/max builtins, slices and maps helpers, range over int, any, ...).
+
+Preview the modernize rewrites with '/go-lint:modernize $TEMP_DIR', then apply them with '/go-lint:modernize $TEMP_DIR --apply'.")
+    assert_json_equals "$expected" "$output" "Suggests modernizing after a go directive bump"
+
+    # Later edits compare against the file before them, not the last commit
+    output=$(edit_go_mod_hook "require example.com/a v1.0.0" "require example.com/a v1.1.0" go=gomod-ok)
+    assert_empty "$output" "Does not repeat the report on later go.mod edits"
+
+    output=$(jq -n --arg file_path "$TEMP_DIR/go.mod" --arg original "$(cat "$TEMP_DIR/go.mod")" \
+        '{tool_name: "Write", tool_input: {file_path: $file_path}, tool_response: {originalFile: $original}}' \
+        | run_stubbed go=gomod-ok -- "$PLUGIN_ROOT/hooks/go-lint.sh" 2>&1)
+    assert_empty "$output" "Compares a Write against the original file"
+
+    output=$(edit_go_mod_hook "go 1.24" "go 1.9" go=gomod-ok)
+    assert_empty "$output" "Exits silently when the go directive is lowered"
+
+    output=$(edit_go_mod_hook "go 1.9" "go 1.20" go=gomod-missing)
+    assert_contains "$output" "go directive raised from 1.9 to 1.20" "Checks the go directive when modules are missing"
+    assert_contains "$output" "- github.com/nope/missing v1.2.3: module lookup disabled by GOPROXY=off" \
+        "Adds the missing modules to the go directive report"
+
+    # Crossing 1.22 lists loops whose variables change semantics
+    : > "$STUB_LOG"
+    output=$(edit_go_mod_hook "go 1.20" "go 1.21" go=gomod-ok)
+    output=$(edit_go_mod_hook "go 1.21" "go 1.22" go=gomod-loopvar)
+    expected=$(expected_hook_json "block" \
+        "go directive change from 1.21 to 1.22 changes loop variable semantics in 2 loop(s)" \
+        "From Go 1.22 every iteration gets its own copy of the loop variable. These loops capture their variable in a closure or take its address, so closures, goroutines and pointers created in them may see different values:
+- p/p.go:5:6: loop variable i (stack-allocated)
+- p/p.go:13:9: loop variable x (heap-allocated)
+
+Check that each loop keeps its intended behaviour, or restore the go directive to 1.21. List them again with 'go build -gcflags=-d=loopvar=2 ./...'.
+
+Preview the modernize rewrites with '/go-lint:modernize $TEMP_DIR', then apply them with '/go-lint:modernize $TEMP_DIR --apply'.")
+    assert_json_equals "$expected" "$output" "Blocks on loops affected by raising the go directive past 1.22"
+    assert_contains "$(cat "$STUB_LOG")" "go build -o /dev/null -gcflags=-d=loopvar=2 ./..." \
+        "Builds the module with loopvar diagnostics"
+
+    output=$(edit_go_mod_hook "require example.com/a v1.1.0" "require example.com/a v1.2.0" go=gomod-loopvar)
+    assert_empty "$output" "Does not list the loops again on later go.mod edits"
+
+    output=$(edit_go_mod_hook "go 1.22" "go 1.21" go=gomod-loopvar)
+    assert_contains "$output" "go directive change from 1.22 to 1.21 changes loop variable semantics in 2 loop(s)" \
+        "Blocks on loops affected by lowering the go directive below 1.22"
+    assert_contains "$output" "Before Go 1.22 all iterations share one loop variable." "Explains per-loop semantics"
+
+    output=$(edit_go_mod_hook "go 1.21" "go 1.22" go=gomod-ok)
+    output=$(edit_go_mod_hook "go 1.22" "go 1.23" go=gomod-loopvar)
+    assert_contains "$output" "go directive raised from 1.22 to 1.23" "Skips the loop check when 1.22 is not crossed"
+}
+
+test_stubbed_hook_go_work() {
+    print_test_header "Hook on go.work edits (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+    mkdir -p "$TEMP_DIR/svc" "$TEMP_DIR/lib"
+    touch "$TEMP_DIR/go.work" "$TEMP_DIR/svc/go.mod" "$TEMP_DIR/lib/go.mod"
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/go.work" go=gowork-ok)
+    assert_empty "$output" "Exits silently when every use directory has a go.mod"
+    assert_equals "go work edit -fmt go.work
+go work edit -json go.work" "$(cat "$STUB_LOG")" "Formats and inspects go.work"
+
+    rm "$TEMP_DIR/lib/go.mod"
+    output=$(run_stubbed_hook "$TEMP_DIR/go.work" go=gowork-ok)
+    expected=$(expected_hook_json "block" \
+        "go.work uses 1 directory(ies) without a go.mod" \
+        "use directives pointing at directories with no go.mod:
+- ./lib")
+    assert_json_equals "$expected" "$output" "Blocks on use directories without go.mod"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/go.work" go=gowork-syntax)
+    expected=$(expected_hook_json "block" \
+        "go.work has syntax errors" \
+        "$(cat "$STUBS_DIR/recordings/go/gowork-syntax/work-edit-fmt.stderr")")
+    assert_json_equals "$expected" "$output" "Blocks on go.work syntax errors"
+}
+
+test_stubbed_hook_go_sum() {
+    print_test_header "Hook on go.sum edits (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+    touch "$TEMP_DIR/go.sum"
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/go.sum" go=default)
+    expected=$(expected_hook_json "allow" \
+        "go.sum was edited directly" \
+        "go.sum is maintained by the go command and hand edits are easy to get wrong. Run 'go mod tidy' to regenerate it, then 'go mod verify' to check the cached modules.")
+
+    assert_json_equals "$expected" "$output" "Warns about direct go.sum edits"
+    assert_empty "$(cat "$STUB_LOG")" "Does not run the go command"
+}
+
+test_stubbed_hook_golangci_config() {
+    print_test_header "Hook on golangci-lint config edits (stubbed)"
+
+    setup_temp
+    setup_stub_path go golangci-lint
+
+    cat > "$TEMP_DIR/.golangci.yml" <<'YAML'
+run:
+  timeout: 5m
+
+linters:
+  enable:
+    - errcheck
+    - errchek
+
+linter-settings:
+  govet:
+    enable-all: true
+YAML
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.yml" golangci-lint=config-valid)
+    assert_empty "$output" "Exits silently when config verify passes"
+    assert_equals "golangci-lint config verify --config .golangci.yml" "$(cat "$STUB_LOG")" "Runs config verify on the edited file"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.yml" golangci-lint=config-invalid)
+    expected=$(expected_hook_json "block" \
+        "golangci-lint config has 2 problem(s)" \
+        ".golangci.yml:7: linters.enable.1: unknown linter 'errchek'
+.golangci.yml:9: linter-settings: additional properties 'linter-settings' not allowed")
+    assert_json_equals "$expected" "$output" "Reports schema errors with line numbers"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.yml" golangci-lint=config-unsupported-version)
+    assert_json_decision "$output" "block" "Blocks v1 config under golangci-lint v2"
+    assert_contains "$output" "golangci-lint migrate" "Suggests golangci-lint migrate"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.yml" golangci-lint=config-verify-missing)
+    expected=$(expected_hook_json "allow" \
+        "golangci-lint config was not fully verified" \
+        "golangci-lint config verify is not available (requires v1.58+); only the offline checks ran.")
+    assert_json_equals "$expected" "$output" "Notes when config verify is unavailable"
+}
+
+test_stubbed_hook_golangci_v2_config_offline() {
+    print_test_header "Hook flags v1-only keys in v2 config (stubbed, no golangci-lint)"
+
+    setup_temp
+    setup_stub_path go
+
+    cat > "$TEMP_DIR/.golangci.yaml" <<'YAML'
+version: "2"
+
+run:
+  skip-dirs:
+    - vendor
+
+linters:
+  disable-all: true
+  enable:
+    - errcheck
+    - gosimple
+  settings:
+    errcheck:
+      check-type-assertions: true
+
+issues:
+  exclude-rules:
+    - path: _test\.go
+      linters: [errcheck]
+YAML
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.yaml")
+    expected=$(expected_hook_json "block" \
+        "golangci-lint config has 4 problem(s)" \
+        ".golangci.yaml:4: 'run.skip-dirs' is not valid in a v2 config (moved to linters.exclusions.paths)
+.golangci.yaml:8: 'linters.disable-all' is not valid in a v2 config (replaced by linters.default: none)
+.golangci.yaml:11: linter 'gosimple' merged into staticcheck
+.golangci.yaml:17: 'issues.exclude-rules' is not valid in a v2 config (moved to linters.exclusions.rules)")
+    assert_json_equals "$expected" "$output" "Reports v1-only keys with line numbers"
+}
+
+test_stubbed_hook_golangci_json_config() {
+    print_test_header "Hook on JSON golangci-lint config (stubbed)"
+
+    setup_temp
+    setup_stub_path go golangci-lint
+
+    cat > "$TEMP_DIR/.golangci.json" <<'JSON'
+{
+  "linters": {
+    "enable": ["errcheck", "errchek"]
+  },
+  "linter-settings": {}
+}
+JSON
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/.golangci.json" golangci-lint=config-invalid)
+    expected=$(expected_hook_json "block" \
+        "golangci-lint config has 2 problem(s)" \
+        ".golangci.json:3: linters.enable.1: unknown linter 'errchek'
+.golangci.json:5: linter-settings: additional properties 'linter-settings' not allowed")
+    assert_json_equals "$expected" "$output" "Locates keys in JSON configs"
+}
+
+test_detect_golangci_config_toml() {
+    print_test_header "Detect TOML golangci-lint config"
+
+    setup_temp
+    touch "$TEMP_DIR/.golangci.toml"
+
+    assert_equals "$TEMP_DIR/.golangci.toml" "$(_gol_detect_golangci_config "$TEMP_DIR")" "Finds .golangci.toml"
+    assert_equals "--config=$TEMP_DIR/.golangci.toml" "$(_gol_build_golangci_config_args "$TEMP_DIR")" "Passes TOML config to golangci-lint"
+}
+
+test_hook_deadline() {
+    print_test_header "Shared hook deadline"
+
+    assert_equals "20" "$(_gol_deadline_limit 20)" "Keeps a stage's limit without a deadline"
+
+    _gol_start_deadline 50
+    local limit
+    limit=$(_gol_deadline_limit 200)
+    assert_equals "true" "$([[ $limit -le 50 && $limit -ge 49 ]] && echo true)" "Caps a stage's limit by the time left"
+    assert_equals "3" "$(_gol_deadline_limit 3)" "Keeps a shorter stage limit"
+
+    local marker exit_code=0
+    marker=$(mktemp -u)
+    _GOL_DEADLINE=$((SECONDS - 1))
+    _gol_run_with_timeout 20 touch "$marker" || exit_code=$?
+    _GOL_DEADLINE=""
+    assert_equals "124" "$exit_code" "Reports a timeout once the deadline has passed"
+    assert_equals "false" "$([[ -e "$marker" ]] && echo true || echo false)" "Does not start stages after the deadline"
+}
+
+test_impact_timeout() {
+    print_test_header "Impact test runs share one timeout (stubbed)"
+
+    setup_temp
+    setup_stub_path go
+
+    # Each package's go test takes 2s, so only the first fits in 3s
+    _gol_IMPACT_SELECTED=$(printf '%s\tchanged package\n' example.com/x/a example.com/x/b example.com/x/c)
+    _gol_IMPACT_TESTS=$(printf '%s\tTestOne\tTestOne\n' example.com/x/a example.com/x/b example.com/x/c)
+    PATH="$STUB_BIN_DIR" STUB_DIR="$STUBS_DIR" STUB_LOG="$STUB_LOG" STUB_GO_SCENARIO=test-slow \
+        _gol_impact_run_tests "$TEMP_DIR" 3
+    assert_equals "124" "$_gol_IMPACT_EXIT" "Reports a timeout when the packages overrun the total"
+    assert_equals "go test -run ^(TestOne)$ example.com/x/a
+go test -run ^(TestOne)$ example.com/x/b" "$(cat "$STUB_LOG")" "Skips the packages left once the timeout is used up"
+    _gol_IMPACT_SELECTED=""
+    _gol_IMPACT_TESTS=""
+}
+
+test_stubbed_project_preconditions() {
+    print_test_header "Project script preconditions (stubbed)"
+
+    setup_temp_without_root
+    setup_stub_path golangci-lint
+
+    run_stubbed_project "$TEMP_DIR/missing"
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 for nonexistent target"
+    assert_equals "## Go Linting Failed
+
+Target directory does not exist: $TEMP_DIR/missing" "$PROJECT_OUTPUT" "Reports nonexistent target"
+
+    run_stubbed_project "$TEMP_DIR"
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 without project root"
+    assert_equals "## Go Linting Failed
+
+Could not find Go project root (no go.mod, go.work, or .git found)
+Searched from: $TEMP_DIR" "$PROJECT_OUTPUT" "Reports missing project root"
+
+    setup_stub_path go
+    run_stubbed_project "$TEMP_DIR"
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 without golangci-lint"
+    assert_equals '## Go Linting Failed
+
+Missing required tools: golangci-lint
+
+### Installation
+
+Install golangci-lint:
+```bash
+go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest
+```' "$PROJECT_OUTPUT" "Reports missing golangci-lint"
+}
+
+test_stubbed_project_clean() {
+    print_test_header "Project script with no issues (stubbed)"
+
+    setup_temp
+    setup_stub_path golangci-lint
+
+    local expected="## Go Linting Succeeded
+
+No issues found!
+
+**Target:** ./...
+**Project root:** $TEMP_DIR"
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-clean
+    assert_equals "0" "$PROJECT_EXIT" "Exits 0 with empty Issues"
+    assert_equals "$expected" "$PROJECT_OUTPUT" "Reports success for empty Issues"
+    assert_contains "$(cat "$STUB_LOG")" "golangci-lint run --out-format=json --fix ./..." "Uses v1 output flags"
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-empty
+    assert_equals "0" "$PROJECT_EXIT" "Exits 0 with empty output"
+    assert_equals "$expected" "$PROJECT_OUTPUT" "Reports success for empty output"
+}
+
+test_stubbed_project_issues() {
+    print_test_header "Project script with issues (stubbed)"
+
+    setup_temp
+    setup_stub_path golangci-lint
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-issues
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 with issues"
+    assert_equals "## Go Linting Report
+
+**Target:** ./...
+**Project root:** $TEMP_DIR
+
+### Summary
+
+- **Errors:** 2
+- **Warnings:** 0
+
+### Errors
+
+- **clean.go:12:11** [errcheck] Error return value of \`os.Remove\` is not checked
+- **vet-errors.go:7:2** [govet] printf: fmt.Printf format %d has arg \"not a number\" of wrong type string" \
+        "$PROJECT_OUTPUT" "Renders issues report"
+
+    touch "$TEMP_DIR/.golangci.yml"
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-issues
+    assert_contains "$PROJECT_OUTPUT" "**Config:** $TEMP_DIR/.golangci.yml" "Reports detected config"
+    assert_contains "$(cat "$STUB_LOG")" "--config=$TEMP_DIR/.golangci.yml" "Passes config to golangci-lint"
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-many-issues
+    assert_contains "$PROJECT_OUTPUT" "- **Errors:** 22" "Counts all issues"
+    assert_equals "20" "$(echo "$PROJECT_OUTPUT" | grep -c '^- \*\*clean.go')" "Lists only the top 20"
+    assert_contains "$PROJECT_OUTPUT" "_...and 2 more errors_" "Mentions remaining issues"
+}
+
+test_stubbed_project_v2() {
+    print_test_header "Project script with golangci-lint v2 (stubbed)"
+
+    setup_temp
+    setup_stub_path golangci-lint
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v2-issues
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 with issues"
+    assert_contains "$(cat "$STUB_LOG")" \
+        "golangci-lint run --output.json.path=stdout --show-stats=false --fix ./..." "Uses v2 output flags"
+    assert_contains "$PROJECT_OUTPUT" \
+        "- **clean.go:9:2** [staticcheck] SA4006: this value of \`err\` is never used" "Renders v2 issues"
+}
+
+test_stubbed_project_subdirectory() {
+    print_test_header "Project script on subdirectory (stubbed)"
+
+    setup_temp
+    setup_stub_path golangci-lint
+    mkdir -p "$TEMP_DIR/internal/api"
+
+    run_stubbed_project "$TEMP_DIR/internal/api" golangci-lint=v1-clean
+    assert_contains "$PROJECT_OUTPUT" "**Target:** ./internal/api/..." "Targets the subdirectory"
+    assert_contains "$(cat "$STUB_LOG")" "--fix ./internal/api/..." "Passes subdirectory pattern"
+}
+
+test_stubbed_project_failures() {
+    print_test_header "Project script when golangci-lint fails (stubbed)"
+
+    setup_temp
+    setup_stub_path golangci-lint
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-invalid-json
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 on invalid JSON"
+    assert_equals "## Go Linting Failed
+
+golangci-lint produced invalid JSON output:
+\`\`\`
+$(cat "$STUBS_DIR/recordings/golangci-lint/v1-invalid-json/run.stdout")
+\`\`\`" "$PROJECT_OUTPUT" "Reports invalid JSON"
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-crash
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 on crash"
+    assert_equals "## Go Linting Failed
+
+golangci-lint exited with code 2 and produced invalid output:
+\`\`\`
+$(cat "$STUBS_DIR/recordings/golangci-lint/v1-crash/run.stdout")
+\`\`\`" "$PROJECT_OUTPUT" "Reports crash output"
+
+    run_stubbed_project "$TEMP_DIR" golangci-lint=v1-timeout
+    assert_equals "1" "$PROJECT_EXIT" "Exits 1 on timeout"
+    assert_equals "## Go Linting Failed
+
+golangci-lint exited with code 4 and produced no output:
+\`\`\`
+$(cat "$STUBS_DIR/recordings/golangci-lint/v1-timeout/run.stderr")
+\`\`\`" "$PROJECT_OUTPUT" "Reports timeout from stderr"
+}
+
+test_stubbed_perf() {
+    print_test_header "Perf script on compiler diagnostics (stubbed)"
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$PERF_DIR"/. "$TEMP_DIR/"
+    setup_stub_path go
+
+    run_stubbed_perf go=perf -- "$TEMP_DIR"
+    assert_equals "0" "$PERF_EXIT" "Exits 0 with findings"
+    assert_golden "perf-report" "${PERF_OUTPUT//$TEMP_DIR/\$ROOT}"
+    assert_contains "$(cat "$STUB_LOG")" "go build -o /dev/null -gcflags=-m=2 -d=ssa/check_bce/debug=1 ./..." \
+        "Builds the target with escape, inlining and BCE diagnostics"
+
+    run_stubbed_perf go=perf -- "$TEMP_DIR/hot"
+    assert_contains "$PERF_OUTPUT" "**Target:** ./hot/..." "Targets the subdirectory"
+
+    # In a git work tree only changed files are reported
+    git -C "$TEMP_DIR" init -q
+    git -C "$TEMP_DIR" add -A
+    git -C "$TEMP_DIR" -c user.name=test -c user.email=test@example.com commit -qm init
+
+    run_stubbed_perf go=perf -- "$TEMP_DIR"
+    assert_contains "$PERF_OUTPUT" "No Go files changed under the target" "Reports an unchanged tree"
+
+    printf 'package hot\n' > "$TEMP_DIR/hot/other.go"
+    run_stubbed_perf go=perf -- "$TEMP_DIR"
+    assert_contains "$PERF_OUTPUT" "**Scope:** 1 changed file(s)" "Counts untracked files"
+    assert_contains "$PERF_OUTPUT" "- **Heap escapes:** 0" "Skips findings in unchanged files"
+
+    echo "// edited" >> "$TEMP_DIR/hot/hot.go"
+    run_stubbed_perf go=perf -- "$TEMP_DIR"
+    assert_contains "$PERF_OUTPUT" "**Scope:** 2 changed file(s)" "Counts modified files"
+    assert_contains "$PERF_OUTPUT" "- **Heap escapes:** 8" "Reports findings in modified files"
+
+    run_stubbed_perf go=perf -- "$TEMP_DIR" --all
+    assert_contains "$PERF_OUTPUT" "**Scope:** all files" "--all reports every file"
+
+    run_stubbed_perf go=perf-fail -- "$TEMP_DIR"
+    assert_equals "1" "$PERF_EXIT" "Exits 1 when the build fails"
+    assert_equals "## Go Performance Report Failed
+
+go build exited with code 1:
+\`\`\`
+$(cat "$STUBS_DIR/recordings/go/perf-fail/build.stderr")
+\`\`\`" "$PERF_OUTPUT" "Reports build errors"
+}
+
+test_binsize() {
+    print_test_header "Binary size report (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$BINSIZE_DIR"/. "$TEMP_DIR/"
+
+    run_binsize "$TEMP_DIR"
+    assert_equals "0" "$BINSIZE_EXIT" "Exits 0 outside git"
+    assert_contains "$BINSIZE_OUTPUT" "**Base:** not a git repository, no base to compare against" "Reports missing base"
+    assert_contains "$BINSIZE_OUTPUT" "- **Binaries:** 1" "Builds the main package"
+    assert_contains "$BINSIZE_OUTPUT" "### example.com/bin/cmd/tool" "Lists the binary"
+    assert_contains "$BINSIZE_OUTPUT" "- example.com/bin: " "Attributes symbols to the main module"
+    assert_contains "$BINSIZE_OUTPUT" "- std: " "Attributes symbols to the standard library"
+
+    run_binsize "$TEMP_DIR/lib"
+    assert_contains "$BINSIZE_OUTPUT" "No main packages found." "Skips targets without main packages"
+
+    # Adding a dependency after the base commit shows up as new
+    git -C "$TEMP_DIR" init -q
+    git -C "$TEMP_DIR" add -A
+    git -C "$TEMP_DIR" -c user.name=test -c user.email=test@example.com commit -qm init
+    cp "$TEMP_DIR/cmd/tool/main.go.heavy" "$TEMP_DIR/cmd/tool/main.go"
+
+    run_binsize "$TEMP_DIR/cmd"
+    assert_contains "$BINSIZE_OUTPUT" "**Target:** ./cmd/..." "Targets the subdirectory"
+    assert_contains "$BINSIZE_OUTPUT" "**Base:** HEAD (" "Compares against HEAD"
+    assert_contains "$BINSIZE_OUTPUT" "- example.com/heavy: " "Attributes symbols to the new module"
+    assert_contains "$BINSIZE_OUTPUT" "### New Dependencies" "Reports new dependencies"
+    assert_contains "$BINSIZE_OUTPUT" "- example.com/heavy adds " "Names the new module"
+
+    run_binsize "$TEMP_DIR" --base nope
+    assert_contains "$BINSIZE_OUTPUT" "**Base:** unknown git ref: nope" "Reports an unknown base ref"
+
+    echo "package main" > "$TEMP_DIR/cmd/tool/main.go"
+    run_binsize "$TEMP_DIR"
+    assert_equals "1" "$BINSIZE_EXIT" "Exits 1 when no binary builds"
+    assert_contains "$BINSIZE_OUTPUT" "No main package could be built:" "Reports build failures"
+}
+
+test_modernize() {
+    print_test_header "Modernize script (real go toolchain)"
+
+    # Before Go 1.26 go fix runs on its own, and explains how to add modernize
+    setup_stub_path go
+    local output exit_code=0
+    output=$(run_stubbed go=modernize-old -- "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$MODERNIZE_DIR" 2>&1) || exit_code=$?
+    assert_equals "0" "$exit_code" "Exits 0 with go fix alone"
+    assert_contains "$output" "**Fixer:** go fix (go1.23.4)" "Runs go fix without modernize"
+    assert_contains "$output" "- **legacy/platform_linux.go** [go fix] buildtag" "Lists go fix rewrites by file"
+    assert_contains "$output" "-// +build linux" "Shows the go fix diff"
+    assert_contains "$output" "go1.23.4 has no modernize analyzers in go fix (Go 1.26+) and modernize is not installed" \
+        "Notes the missing modernize analyzers"
+    assert_contains "$output" "go install golang.org/x/tools/gopls/internal/analysis/modernize/cmd/modernize@latest" \
+        "Shows installation instructions"
+
+    : > "$STUB_LOG"
+    output=$(run_stubbed go=modernize-old -- "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$MODERNIZE_DIR" --apply 2>&1) || true
+    assert_contains "$(cat "$STUB_LOG")" "go fix ./..." "Applies go fix"
+    assert_contains "$(cat "$STUB_LOG")" "go vet ./..." "Runs go vet after go fix"
+
+    # modernize adds its analyzers on top of go fix
+    setup_stub_path go modernize
+    output=$(run_stubbed go=modernize-old -- "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$MODERNIZE_DIR" 2>&1) || true
+    assert_contains "$output" "**Fixer:** go fix and modernize (go1.23.4)" "Runs modernize after go fix"
+    assert_contains "$output" "- **Rewrites:** 2" "Counts the rewrites of both fixers"
+    assert_contains "$output" "- **legacy/legacy.go:23:6** [rangeint] " "Lists modernize rewrites"
+    assert_contains "$output" "+	for i := range n {" "Shows the modernize diff"
+    : > "$STUB_LOG"
+    output=$(run_stubbed go=modernize-old -- "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$MODERNIZE_DIR" --apply 2>&1) || true
+    assert_contains "$(cat "$STUB_LOG")" "modernize -fix ./..." "Applies modernize after go fix"
+
+    setup_stub_path go
+    exit_code=0
+    output=$(run_stubbed go=modernize-old-broken -- "$PLUGIN_ROOT/scripts/go-lint-modernize.sh" "$MODERNIZE_DIR" 2>&1) || exit_code=$?
+    assert_equals "1" "$exit_code" "Exits 1 when no fixer can analyze the target"
+    assert_contains "$output" "legacy/legacy.go:5:1: expected declaration, found broken" "Shows why go fix failed"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+    local go_minor
+    go_minor=$(go env GOVERSION | sed -n 's/^go1\.\([0-9]*\).*/\1/p')
+    if [[ "${go_minor:-0}" -lt 26 ]] && ! command -v modernize &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go fix has no modernize analyzers before Go 1.26)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$MODERNIZE_DIR"/. "$TEMP_DIR/"
+    cp "$TEMP_DIR/legacy/legacy.go" "$TEMP_DIR/legacy.go.orig"
+
+    run_modernize "$TEMP_DIR"
+    assert_equals "0" "$MODERNIZE_EXIT" "Exits 0 on a dry run"
+    assert_contains "$MODERNIZE_OUTPUT" "**Go directive:** 1.24" "Shows the go directive"
+    assert_contains "$MODERNIZE_OUTPUT" "**Mode:** dry run (pass --apply to rewrite files)" "Defaults to a dry run"
+    assert_contains "$MODERNIZE_OUTPUT" "- **legacy/legacy.go:13:2** [slicescontains] " "Lists slices.Contains rewrites"
+    assert_contains "$MODERNIZE_OUTPUT" "- **legacy/legacy.go:23:6** [rangeint] " "Lists range-over-int rewrites"
+    assert_contains "$MODERNIZE_OUTPUT" "- **legacy/legacy.go:34:14** [any] " "Lists interface{} rewrites"
+    assert_contains "$MODERNIZE_OUTPUT" "+++ legacy/legacy.go (new)" "Shows the diff relative to the project root"
+    assert_contains "$MODERNIZE_OUTPUT" "+	return slices.Contains(xs, s)" "Shows the rewritten code"
+    assert_equals "$(cat "$TEMP_DIR/legacy.go.orig")" "$(cat "$TEMP_DIR/legacy/legacy.go")" "Leaves files alone on a dry run"
+
+    run_modernize "$TEMP_DIR/legacy" --apply
+    assert_equals "0" "$MODERNIZE_EXIT" "Exits 0 when go vet passes after applying"
+    assert_contains "$MODERNIZE_OUTPUT" "**Target:** ./legacy/..." "Targets the subdirectory"
+    assert_contains "$MODERNIZE_OUTPUT" "No issues found after applying the rewrites." "Runs go vet after applying"
+    assert_contains "$(cat "$TEMP_DIR/legacy/legacy.go")" "for i := range n {" "Rewrites the files"
+
+    run_modernize "$TEMP_DIR"
+    assert_contains "$MODERNIZE_OUTPUT" "No modernizations suggested for this go directive." "Reports a modernized tree"
+
+    echo "func broken() { undefined() }" >> "$TEMP_DIR/legacy/legacy.go"
+    run_modernize "$TEMP_DIR"
+    assert_equals "1" "$MODERNIZE_EXIT" "Exits 1 when the target does not type-check"
+    assert_contains "$MODERNIZE_OUTPUT" "## Go Modernize Failed" "Reports analysis failures"
+}
+
+# Run the rename script with the sandbox PATH
+# Args: tool=scenario..., --, script arguments...
+# Sets: RENAME_OUTPUT, RENAME_EXIT
+run_stubbed_rename() {
+    local scenarios=()
+    while [[ $# -gt 0 && "$1" != "--" ]]; do
+        scenarios+=("$1")
+        shift
+    done
+    shift
+
+    RENAME_EXIT=0
+    RENAME_OUTPUT=$(
+        run_stubbed ${scenarios[@]+"${scenarios[@]}"} -- "$PLUGIN_ROOT/scripts/go-lint-rename.sh" "$@" 2>&1
+    ) || RENAME_EXIT=$?
+}
+
+test_stubbed_rename() {
+    print_test_header "Rename script (stubbed gopls)"
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$RENAME_DIR"/. "$TEMP_DIR/"
+
+    setup_stub_path go goimports
+    run_stubbed_rename -- "$TEMP_DIR/calc/calc.go:4:6" Total
+    assert_equals "1" "$RENAME_EXIT" "Exits 1 without gopls"
+    assert_contains "$RENAME_OUTPUT" "go install golang.org/x/tools/gopls@latest" "Shows installation instructions"
+
+    setup_stub_path go goimports gopls
+    run_stubbed_rename -- "$TEMP_DIR/calc/calc.go" Total
+    assert_contains "$RENAME_OUTPUT" "Position must be <file>:<line>:<col>" "Requires a position"
+    run_stubbed_rename -- "$TEMP_DIR/calc/calc.go:4:6" range
+    assert_contains "$RENAME_OUTPUT" "New name is a Go keyword: range" "Rejects keywords"
+    run_stubbed_rename -- "$TEMP_DIR/calc/calc.go:4:5" Total
+    assert_contains "$RENAME_OUTPUT" "No identifier at calc/calc.go:4:5" "Requires an identifier at the position"
+    assert_empty "$(grep "^gopls" "$STUB_LOG" || true)" "Validates arguments before running gopls"
+
+    run_stubbed_rename gopls=rename-conflict -- "$TEMP_DIR/calc/calc.go:4:6" Line
+    assert_equals "1" "$RENAME_EXIT" "Exits 1 when gopls refuses the rename"
+    assert_contains "$RENAME_OUTPUT" 'gopls could not rename `Sum` to `Line`:' "Reports the refusal"
+    assert_contains "$RENAME_OUTPUT" 'gopls: calc/calc.go:4:6: renaming this func "Sum" to "Line" would conflict' \
+        "Shows gopls' reason relative to the project root"
+
+    : > "$STUB_LOG"
+    run_stubbed_rename gopls=rename-ok go=vet-clean -- "$TEMP_DIR/calc/calc.go:4:8" Total
+    assert_equals "0" "$RENAME_EXIT" "Exits 0 after a clean rename"
+    assert_equals "## Go Rename Report
+
+**Renamed:** \`Sum\` → \`Total\`
+**Position:** calc/calc.go:4:8
+**Project root:** $TEMP_DIR
+
+### Summary
+
+- **Files changed:** 3
+- **Locations:** 3
+
+### Changed Files
+
+- **calc/calc.go** (1): 4:6
+- **calc/calc_test.go** (1): 10:17
+- **report/report.go** (1): 11:38
+
+Formatted the changed files with goimports.
+
+### Check Manually
+
+gopls only renames in files built for the current platform and build tags. These files have build constraints and still mention \`Sum\`:
+
+- report/report_windows.go
+
+### go vet
+
+No issues found in the changed packages." "$RENAME_OUTPUT" "Reports changed files and locations"
+    assert_contains "$(cat "$TEMP_DIR/calc/calc_test.go")" "calc.Total(1, 2)" "Applies the edits in test packages"
+    assert_contains "$(cat "$TEMP_DIR/report/report.go")" "calc.Total(values...)" "Applies the edits in other packages"
+    assert_contains "$(cat "$STUB_LOG")" "gopls rename -w $TEMP_DIR/calc/calc.go:4:8 Total" "Applies the rename with gopls"
+    assert_contains "$(cat "$STUB_LOG")" "goimports -w $TEMP_DIR/calc/calc.go $TEMP_DIR/calc/calc_test.go $TEMP_DIR/report/report.go" \
+        "Formats the changed files"
+    assert_contains "$(cat "$STUB_LOG")" "go vet ./calc ./report" "Vets the changed packages"
+
+    cp -R "$RENAME_DIR"/. "$TEMP_DIR/"
+    run_stubbed_rename gopls=rename-ok go=vet-errors -- "$TEMP_DIR/calc/calc.go:4:6" Total
+    assert_equals "1" "$RENAME_EXIT" "Exits 1 when go vet fails after the rename"
+    assert_contains "$RENAME_OUTPUT" "### go vet" "Shows go vet output"
+}
+
+test_test_impact() {
+    print_test_header "Test impact analysis (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$IMPACT_DIR"/. "$TEMP_DIR/"
+
+    run_test_impact "$TEMP_DIR"
+    assert_equals "1" "$IMPACT_EXIT" "Exits 1 outside git"
+    assert_contains "$IMPACT_OUTPUT" "is not in a git work tree" "Needs git to find changed files"
+
+    git -C "$TEMP_DIR" init -q
+    git -C "$TEMP_DIR" add -A
+    git -C "$TEMP_DIR" -c user.name=test -c user.email=test@example.com commit -qm init
+
+    run_test_impact "$TEMP_DIR"
+    assert_equals "0" "$IMPACT_EXIT" "Exits 0 without changes"
+    assert_contains "$IMPACT_OUTPUT" "No Go packages changed in the working tree." "Reports an unchanged tree"
+
+    # Changing mathx.Mul selects mathx and every package importing it
+    sed -i.bak 's/return a \* b/return b * a/' "$TEMP_DIR/mathx/mathx.go"
+    rm "$TEMP_DIR/mathx/mathx.go.bak"
+    run_test_impact "$TEMP_DIR" --list
+    assert_equals "0" "$IMPACT_EXIT" "Exits 0 when only listing"
+    assert_contains "$IMPACT_OUTPUT" "**Selection:** package imports" "Selects by package imports by default"
+    assert_contains "$IMPACT_OUTPUT" "- **example.com/impact/mathx** changed package" "Selects the changed package"
+    assert_contains "$IMPACT_OUTPUT" "- **example.com/impact/calc** imports example.com/impact/mathx" \
+        "Selects direct importers"
+    assert_contains "$IMPACT_OUTPUT" "- **example.com/impact/report** imports example.com/impact/mathx via example.com/impact/report -> example.com/impact/calc -> example.com/impact/mathx" \
+        "Selects transitive importers with the import chain"
+    assert_contains "$IMPACT_OUTPUT" "- **Test packages selected:** 3" "Skips unrelated and test-less packages"
+
+    run_test_impact "$TEMP_DIR" --callgraph
+    assert_equals "0" "$IMPACT_EXIT" "Exits 0 when the selected tests pass"
+    assert_contains "$IMPACT_OUTPUT" "**Selection:** call graph" "Narrows by call graph"
+    assert_contains "$IMPACT_OUTPUT" "  - \`TestProduct\`: TestProduct -> calc.Product -> mathx.Mul" \
+        "Explains the call chain to the changed function"
+    assert_contains "$IMPACT_OUTPUT" "- example.com/impact/report: no test reaches a changed function" \
+        "Drops packages whose tests cannot reach the change"
+    assert_contains "$IMPACT_OUTPUT" "All 2 selected test package(s) passed." "Runs the narrowed selection"
+
+    printf '\n// Zero is the additive identity.\nconst Zero = 0\n' >> "$TEMP_DIR/mathx/mathx.go"
+    run_test_impact "$TEMP_DIR/calc" --callgraph --list
+    assert_contains "$IMPACT_OUTPUT" "**Selection:** package imports (call graph skipped: changes outside functions at mathx/mathx.go:15)" \
+        "Falls back to package imports for changes outside functions"
+    assert_contains "$IMPACT_OUTPUT" "**Target:** ./calc/..." "Limits test packages to the target"
+    assert_contains "$IMPACT_OUTPUT" "- **Test packages selected:** 1" "Selects only packages under the target"
+
+    # Broken code fails the selected tests
+    git -C "$TEMP_DIR" checkout -q .
+    sed -i.bak 's/return a + b/return a - b/' "$TEMP_DIR/mathx/mathx.go"
+    rm "$TEMP_DIR/mathx/mathx.go.bak"
+    run_test_impact "$TEMP_DIR" --callgraph
+    assert_equals "1" "$IMPACT_EXIT" "Exits 1 when selected tests fail"
+    assert_contains "$IMPACT_OUTPUT" "- example.com/impact/report TestTotal: report_test.go:7: Total = \"total: -3\", want \"total: 3\"" \
+        "Summarises failing tests"
+
+    # The Stop hook is opt-in
+    assert_empty "$(run_stop_hook "$TEMP_DIR" false)" "Stop hook does nothing unless enabled"
+
+    echo '{"impact": {"stop_hook": true}}' > "$TEMP_DIR/.go-lint.json"
+    local output
+    output=$(run_stop_hook "$TEMP_DIR" false)
+    assert_equals "block" "$(echo "$output" | jq -r '.decision')" "Stop hook blocks on failing tests"
+    assert_contains "$(echo "$output" | jq -r '.reason')" "- example.com/impact/mathx TestAdd: mathx_test.go:7: Add(1, 2) = -1, want 3" \
+        "Stop hook reports the failures"
+    assert_contains "$(echo "$output" | jq -r '.reason')" "Selected by package imports:" "Stop hook reports the selection"
+    assert_empty "$(run_stop_hook "$TEMP_DIR" true)" "Stop hook never blocks a continued stop"
+
+    # Known-flaky tests are marked, and do not block when they are the only failures
+    echo '{"tests": [{"package": "example.com/impact/mathx", "test": "TestAdd"}]}' > "$TEMP_DIR/.go-lint-flaky.json"
+    output=$(run_stop_hook "$TEMP_DIR" false)
+    assert_equals "block" "$(echo "$output" | jq -r '.decision')" "Stop hook blocks on other failures"
+    assert_contains "$(echo "$output" | jq -r '.reason')" "- example.com/impact/mathx TestAdd (known flaky): mathx_test.go:7:" \
+        "Stop hook marks known-flaky tests"
+    jq -n '{tests: [
+        {package: "example.com/impact/calc", test: "TestSum"},
+        {package: "example.com/impact/mathx", test: "TestAdd"},
+        {package: "example.com/impact/report", test: "TestTotal"}
+    ]}' > "$TEMP_DIR/.go-lint-flaky.json"
+    assert_empty "$(run_stop_hook "$TEMP_DIR" false)" "Stop hook lets known-flaky failures through"
+    rm "$TEMP_DIR/.go-lint-flaky.json"
+
+    git -C "$TEMP_DIR" checkout -q .
+    assert_empty "$(run_stop_hook "$TEMP_DIR" false)" "Stop hook is silent when the selected tests pass"
+}
+
+test_flaky() {
+    print_test_header "Flaky test detection (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$FLAKY_DIR"/. "$TEMP_DIR/"
+
+    run_flaky "$TEMP_DIR" --count 3 --cpu 1,2,4
+    assert_equals "0" "$FLAKY_EXIT" "Exits 0 after reporting flaky tests"
+    assert_contains "$FLAKY_OUTPUT" "**Runs:** 9 per test (-count=3 -cpu=1,2,4 -shuffle=on)" "Runs every test count x cpu times"
+    assert_contains "$FLAKY_OUTPUT" "- **Tests run:** 4" "Counts subtests but not their parents"
+    assert_contains "$FLAKY_OUTPUT" "- **Flaky:** 2" "Counts flaky tests"
+    assert_contains "$FLAKY_OUTPUT" "- **example.com/flaky/clock TestCounter** failed 3 of 9 runs
+  - run 3 (GOMAXPROCS=1): clock_test.go:20: run 3: counter out of sync
+  - run 6 (GOMAXPROCS=2): clock_test.go:20: run 6: counter out of sync
+  - run 9 (GOMAXPROCS=4): clock_test.go:20: run 9: counter out of sync" "Lists the failure message of each failed run"
+    assert_contains "$FLAKY_OUTPUT" "- **example.com/flaky/clock TestProcs/parallel** failed 3 of 9 runs" \
+        "Reports tests that fail for one GOMAXPROCS value"
+    assert_contains "$FLAKY_OUTPUT" "- **example.com/flaky/clock TestBroken**: broken_test.go:7: Tick(0) = 1, want 2" \
+        "Separates tests that fail in every run"
+    assert_contains "$FLAKY_OUTPUT" "- example.com/flaky/clock: -shuffle=" "Shows the shuffle seed"
+    assert_equals "example.com/flaky/clock	TestCounter
+example.com/flaky/clock	TestProcs/parallel" "$(_gol_flaky_known "$TEMP_DIR")" "Records the flaky tests"
+    assert_equals "6 3" "$(jq -r '.tests[0] | "\(.passed) \(.failed)"' "$TEMP_DIR/.go-lint-flaky.json")" \
+        "Records the run counts"
+
+    run_flaky "$TEMP_DIR/clock" --run '^TestTick$' --count 2
+    assert_contains "$FLAKY_OUTPUT" "No test changed its outcome across 6 runs." "Reports stable tests"
+    assert_equals "2" "$(_gol_flaky_known "$TEMP_DIR" | grep -c .)" "Keeps earlier records"
+
+    run_flaky "$TEMP_DIR" --count 0
+    assert_equals "1" "$FLAKY_EXIT" "Exits 1 for an invalid count"
+    assert_contains "$FLAKY_OUTPUT" "## Go Flaky Test Detection Failed" "Reports invalid arguments"
+
+    echo "func broken() { undefined() }" >> "$TEMP_DIR/clock/clock.go"
+    run_flaky "$TEMP_DIR" --count 1
+    assert_equals "1" "$FLAKY_EXIT" "Exits 1 when the tests do not build"
+    assert_contains "$FLAKY_OUTPUT" "undefined: undefined" "Shows build errors"
+}
+
+test_flaky_annotation() {
+    print_test_header "Known-flaky tests in test summaries"
+
+    setup_temp
+    setup_stub_path
+    echo "module example.com/gt" > "$TEMP_DIR/go.mod"
+    echo '{"tests": [{"package": "example.com/gt/calc", "test": "TestTable/zero"}]}' > "$TEMP_DIR/.go-lint-flaky.json"
+
+    local output context
+    output=$(run_bash_hook "go test ./..." "$GOCMD_DIR/test.txt" "$TEMP_DIR")
+    context=$(echo "$output" | jq -r '.hookSpecificOutput.additionalContext')
+    assert_contains "$context" "- example.com/gt/calc TestTable/zero (known flaky): calc_test.go:16: Add(0, 0) = 1, want 0" \
+        "Marks known-flaky tests"
+    assert_contains "$context" "- example.com/gt/calc TestTable/one (known flaky): " "Matches subtests by their top-level test"
+    assert_contains "$context" "- example.com/gt/calc TestAdd: calc_test.go:7:" "Leaves other tests alone"
+    assert_contains "$context" "are listed in .go-lint-flaky.json" "Explains the mark"
+
+    local summary
+    summary=$(_gol_summarize_go_output "test" "$(cat "$GOCMD_DIR/test.txt")")
+    assert_equals "no" "$(_gol_flaky_all_known "$(_gol_flaky_annotate "$summary" "$TEMP_DIR")" && echo yes || echo no)" \
+        "Other failures still count"
+
+    summary="go test: 1 failed test(s) in 1 failing package(s)
+
+Failed tests:
+- example.com/gt/calc TestTable/zero: calc_test.go:16: Add(0, 0) = 1, want 0
+
+Failing packages:
+- example.com/gt/calc"
+    assert_equals "yes" "$(_gol_flaky_all_known "$(_gol_flaky_annotate "$summary" "$TEMP_DIR")" && echo yes || echo no)" \
+        "Recognises summaries with only known-flaky failures"
+
+    rm "$TEMP_DIR/.go-lint-flaky.json"
+    output=$(run_bash_hook "go test ./..." "$GOCMD_DIR/test.txt" "$TEMP_DIR")
+    assert_empty "$(echo "$output" | grep 'known flaky' || true)" "Marks nothing without a record"
+}
+
+test_profile() {
+    print_test_header "Profile report (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    # The profiles were recorded in tests/profile; a copy elsewhere still maps
+    # their paths to the module source
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    mkdir "$TEMP_DIR/app"
+    cp -R "$PROFILE_DIR"/. "$TEMP_DIR/app/"
+
+    run_profile "$TEMP_DIR/app" cpu.pprof --top 5
+    assert_equals "0" "$PROFILE_EXIT" "Exits 0 for a CPU profile"
+    assert_contains "$PROFILE_OUTPUT" "**Type:** cpu" "Shows the profile type"
+    assert_contains "$PROFILE_OUTPUT" "**Total:** 2690ms" "Shows the total"
+    assert_contains "$PROFILE_OUTPUT" "### Top Functions by Flat
+
+| Flat | Flat% | Cum | Cum% | Function |
+|------|-------|-----|------|----------|
+| 1190ms | 44.24% | 1190ms | 44.24% | \`example.com/prof/hot.Fib\` |" "Lists functions by flat cost"
+    assert_equals "5" "$(echo "$PROFILE_OUTPUT" | sed -n '/^### Top Functions by Cumulative/,/^### Module/p' | grep -c '^| [^F-]')" \
+        "Limits the tables to --top rows"
+    assert_contains "$PROFILE_OUTPUT" "#### hot/hot.go:11 \`example.com/prof/hot.Fib\`
+
+flat 0.29s (10.78%), cum 1.19s (44.24%)" "Maps module lines to source relative to the project"
+    assert_contains "$PROFILE_OUTPUT" ">    21 | 		s += strconv.Itoa(i)" "Shows an annotated source snippet"
+    assert_empty "$(echo "$PROFILE_OUTPUT" | grep '^#### .*_test.go' || true)" "Skips benchmark code"
+
+    run_profile "$TEMP_DIR/app" mem.pprof --sample alloc_space --top 3
+    assert_contains "$PROFILE_OUTPUT" "**Type:** alloc_space" "Reads heap profiles with a sample index"
+    assert_contains "$PROFILE_OUTPUT" "| 7.91GB | 99.92% | 7.92GB | 100% | \`example.com/prof/hot.Join\` |" \
+        "Lists allocating functions"
+
+    run_profile "$TEMP_DIR/app" cpu.pprof --base base.pprof --top 4
+    assert_contains "$PROFILE_OUTPUT" "**Base:** base.pprof (values are profile minus base)" "Diffs against a base profile"
+    assert_contains "$PROFILE_OUTPUT" "| 0 | 0% | 1.49s | 121.14% | \`example.com/prof/hot.Join\` |" \
+        "Shows cost added since the base"
+    assert_contains "$PROFILE_OUTPUT" "### Largest Changes by Cumulative" "Labels the tables as changes"
+
+    run_profile "$TEMP_DIR" app/cpu.pprof --top 1
+    assert_contains "$PROFILE_OUTPUT" "No Go module found from $TEMP_DIR" "Skips source mapping outside a module"
+
+    run_profile "$TEMP_DIR/app" mem.pprof --sample bogus
+    assert_equals "1" "$PROFILE_EXIT" "Exits 1 when pprof fails"
+    assert_contains "$PROFILE_OUTPUT" 'sample_index "bogus" must be one of' "Shows the pprof error"
+
+    run_profile "$TEMP_DIR/app" missing.pprof
+    assert_contains "$PROFILE_OUTPUT" "Profile does not exist: missing.pprof" "Reports missing profiles"
+}
+
+test_mutate() {
+    print_test_header "Mutation testing (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$MUTATE_DIR"/. "$TEMP_DIR/"
+    cp "$TEMP_DIR/shop/shop.go" "$TEMP_DIR/shop.go.orig"
+
+    # Outside git every function is mutated
+    run_mutate "$TEMP_DIR/shop"
+    assert_equals "0" "$MUTATE_EXIT" "Exits 0 with surviving mutants"
+    assert_contains "$MUTATE_OUTPUT" "**Package:** ./shop" "Targets the package"
+    assert_contains "$MUTATE_OUTPUT" "**Scope:** all functions ($TEMP_DIR is not in a git work tree)" \
+        "Mutates every function outside git"
+    assert_contains "$MUTATE_OUTPUT" "- **Mutants:** 9" "Generates one mutant per operator and error return"
+    assert_contains "$MUTATE_OUTPUT" "- **Killed:** 6" "Counts mutants the tests detect"
+    assert_contains "$MUTATE_OUTPUT" "- **Did not compile:** 1" "Counts mutants that do not compile"
+    assert_contains "$MUTATE_OUTPUT" "- **Mutation score:** 75%" "Scores compiling mutants only"
+    assert_contains "$MUTATE_OUTPUT" '- **shop/shop.go:18:9** boundary: `sum > threshold` → `sum >= threshold`' \
+        "Reports surviving boundary mutants"
+    assert_contains "$MUTATE_OUTPUT" '- **shop/shop.go:26:7** boundary: `a >= b` → `a > b`' \
+        "Reports mutants with file, line and the mutation"
+    assert_empty "$(echo "$MUTATE_OUTPUT" | grep 'error-return' || true)" "Error returns are covered by the tests"
+    assert_equals "$(cat "$TEMP_DIR/shop.go.orig")" "$(cat "$TEMP_DIR/shop/shop.go")" "Leaves the source files alone"
+
+    git -C "$TEMP_DIR" init -q
+    git -C "$TEMP_DIR" add -A
+    git -C "$TEMP_DIR" -c user.name=test -c user.email=test@example.com commit -qm init
+
+    run_mutate "$TEMP_DIR/shop"
+    assert_equals "0" "$MUTATE_EXIT" "Exits 0 without changes"
+    assert_contains "$MUTATE_OUTPUT" "No functions in ./shop changed in the working tree." "Limits mutations to changed functions"
+
+    # Changing Max limits the mutants to Max
+    sed -i.bak 's/return b$/return b + 0/' "$TEMP_DIR/shop/shop.go"
+    rm "$TEMP_DIR/shop/shop.go.bak"
+    run_mutate "$TEMP_DIR/shop"
+    assert_contains "$MUTATE_OUTPUT" "**Scope:** changed functions" "Defaults to changed functions in git"
+    assert_contains "$MUTATE_OUTPUT" "- **Functions:** 1" "Mutates only the changed function"
+    assert_contains "$MUTATE_OUTPUT" '- **shop/shop.go:29:11** arithmetic: `b + 0` → `b - 0`' "Reports arithmetic mutants"
+    assert_contains "$MUTATE_OUTPUT" "- **Mutants:** 3" "Skips mutants in unchanged functions"
+
+    run_mutate "$TEMP_DIR/shop" --all
+    assert_contains "$MUTATE_OUTPUT" "- **Functions:** 3" "Mutates every function with --all"
+
+    # The tests must pass before mutants are tested
+    sed -i.bak 's/want 30/want 31/; s/got != 30/got != 31/' "$TEMP_DIR/shop/shop_test.go"
+    rm "$TEMP_DIR/shop/shop_test.go.bak"
+    run_mutate "$TEMP_DIR/shop" --all
+    assert_equals "1" "$MUTATE_EXIT" "Exits 1 when the tests fail"
+    assert_contains "$MUTATE_OUTPUT" "The tests of ./shop fail without mutations; fix them first." "Requires a passing baseline"
+
+    run_mutate "$TEMP_DIR/missing"
+    assert_equals "1" "$MUTATE_EXIT" "Exits 1 for a missing directory"
+    assert_contains "$MUTATE_OUTPUT" "## Go Mutation Testing Failed" "Reports missing targets"
+}
+
+test_outline() {
+    print_test_header "Outline command (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$OUTLINE_DIR"/. "$TEMP_DIR/"
+
+    run_outline "$TEMP_DIR"
+    assert_equals "0" "$OUTLINE_EXIT" "Exits 0 for the whole module"
+    assert_golden "outline-module" "${OUTLINE_OUTPUT//$TEMP_DIR//path/to/project}"
+
+    run_outline "$TEMP_DIR/store" --exported --no-docs
+    assert_contains "$OUTLINE_OUTPUT" "**Filters:** exported only" "Lists the filters"
+    assert_contains "$OUTLINE_OUTPUT" "type User struct{ ID int; Name string; Role Role; ... } // store.go:29" \
+        "Hides unexported fields and doc summaries"
+    assert_contains "$OUTLINE_OUTPUT" "} // implemented by: *cache.Cache, *Memory" \
+        "Finds implementers outside the target"
+    assert_empty "$(echo "$OUTLINE_OUTPUT" | grep -E 'validate|normalize|defaultRole' || true)" \
+        "Leaves out unexported declarations"
+
+    run_outline "$TEMP_DIR/cache" --depth 0 --match '^(Get|Stats)$'
+    assert_contains "$OUTLINE_OUTPUT" "**Filters:** depth 0, names matching \`^(Get|Stats)\$\`" "Lists depth and match filters"
+    assert_contains "$OUTLINE_OUTPUT" "**Packages:** 1" "Stops at the depth limit"
+    assert_contains "$OUTLINE_OUTPUT" "	func (c *Cache) Get(ctx context.Context, id int) (*store.User, error)" \
+        "Keeps matching methods under their type"
+    assert_empty "$(echo "$OUTLINE_OUTPUT" | grep -E 'func New|Put|Close' || true)" "Drops methods that do not match"
+
+    run_outline "$TEMP_DIR/store" --tests
+    assert_contains "$OUTLINE_OUTPUT" "	func seeded(t *testing.T) *Memory // store_test.go:9: seeded returns a store holding one admin." \
+        "Includes test helpers with --tests"
+    assert_empty "$(echo "$OUTLINE_OUTPUT" | grep 'TestGet' || true)" "Leaves out test functions"
+
+    echo '{"outline": {"depth": 0, "exported": true}}' > "$TEMP_DIR/.go-lint.json"
+    run_outline "$TEMP_DIR"
+    assert_contains "$OUTLINE_OUTPUT" "**Filters:** depth 0, exported only" "Reads defaults from .go-lint.json"
+    assert_contains "$OUTLINE_OUTPUT" "No declarations found under the target." "Reports an empty outline"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    GO_LINT_OUTLINE_MAX_LINES=12 run_outline "$TEMP_DIR"
+    assert_contains "$OUTLINE_OUTPUT" "Outline cut at the 12-line limit" "Caps long outlines"
+    assert_empty "$(echo "$OUTLINE_OUTPUT" | grep 'outline/cache/lru' || true)" "Cuts at a package boundary"
+
+    run_outline "$TEMP_DIR" --match '('
+    assert_equals "1" "$OUTLINE_EXIT" "Exits 1 for an invalid regexp"
+    assert_contains "$OUTLINE_OUTPUT" "-match: error parsing regexp" "Reports the invalid regexp"
+
+    run_outline "$TEMP_DIR" --depth
+    assert_contains "$OUTLINE_OUTPUT" "--depth needs a number" "Requires a depth value"
+
+    run_outline "$TEMP_DIR/missing"
+    assert_equals "1" "$OUTLINE_EXIT" "Exits 1 for a missing directory"
+    assert_contains "$OUTLINE_OUTPUT" "## Go Outline Failed" "Reports missing targets"
+}
+
+test_mcp_server() {
+    print_test_header "MCP server tools (real go toolchain)"
+
+    if ! command -v go &>/dev/null || ! command -v node &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go or node not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    mkdir -p "$TEMP_DIR/calc"
+    printf 'module example.com/mcp\n\ngo 1.22\n' > "$TEMP_DIR/go.mod"
+    cat > "$TEMP_DIR/calc/calc.go" <<'GO'
+package calc
+
+import "fmt"
+
+func Add(a, b int) int { return a - b }
+
+func Show(n int) { fmt.Sprintf("%d", n) }
+GO
+    cat > "$TEMP_DIR/calc/calc_test.go" <<'GO'
+package calc
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if got := Add(1, 2); got != 3 {
+		t.Errorf("Add(1, 2) = %d, want 3", got)
+	}
+}
+GO
+
+    local result
+    result=$(call_mcp_tool go_vet "{\"path\": \"$TEMP_DIR\"}")
+    assert_json_equals '{"file": "calc/calc.go", "line": 7, "linter": "unusedresult"}' \
+        "$(echo "$result" | jq -c '.diagnostics[0] | {file, line, linter}')" "go_vet returns analyzer findings"
+
+    result=$(call_mcp_tool go_test "{\"path\": \"$TEMP_DIR/calc\", \"run\": \"TestAdd\"}")
+    assert_json_equals '{"file": "calc/calc_test.go", "line": 7, "test": "TestAdd", "message": "Add(1, 2) = -1, want 3"}' \
+        "$(echo "$result" | jq -c '.diagnostics[0] | {file, line, test, message}')" "go_test returns failing tests"
+    assert_json_equals '{"passed": 0, "failed": 1, "skipped": 0}' "$(echo "$result" | jq -c '.summary')" "go_test counts results"
+
+    printf 'package calc\nfunc Sub(a,b int)int{return a-b}\n' > "$TEMP_DIR/calc/sub.go"
+    result=$(call_mcp_tool go_format "{\"path\": \"$TEMP_DIR\"}")
+    assert_equals "calc/sub.go" "$(echo "$result" | jq -r '.diagnostics[0].file')" "go_format lists unformatted files"
+    assert_contains "$(echo "$result" | jq -r '.diagnostics[0].fix')" "+func Sub(a, b int) int { return a - b }" "go_format returns the diff"
+
+    result=$(call_mcp_tool go_vet '{"path": "/nonexistent"}')
+    assert_equals "Target does not exist: /nonexistent" "$(echo "$result" | jq -r '.error')" "Reports a missing target"
+}
+
+# Golden tests over the real-world layout corpus (tests/corpus)
+# Uses the real go toolchain with a stand-in goimports; cgo is disabled and
+# the module proxy is off so results only depend on the Go version
+
+# Each case is "golden name|edited file relative to the corpus"
+GOLDEN_CASES=(
+    "workspace-module|workspace/svc/main.go"
+    "workspace-library|workspace/lib/lib.go"
+    "vendored-module|vendored/main.go"
+    "build-tag-matching|buildtags/run_linux.go"
+    "build-tag-excluded|buildtags/run_other.go"
+    "build-tag-ignore|buildtags/tools.go"
+    "generated-file|generated/color_string.go"
+    "cgo-file|cgo/cgo.go"
+    "cgo-sibling-file|cgo/pure.go"
+    "external-test-package|externaltest/calc/calc_test.go"
+    "nested-outer-module|nested/outer.go"
+    "nested-inner-module|nested/inner/inner.go"
+    "quoted-path|odd \"dir\" it's/main.go"
+)
+
+# Render the root, relative path and hook output for one corpus file
+# Args: edited file relative to the corpus copy in TEMP_DIR
+render_golden_case() {
+    local rel_file="$1"
+    local file_path="$TEMP_DIR/$rel_file"
+
+    local root rel_root rel_path output
+    root=$(_gol_find_project_root "$(dirname "$file_path")" || true)
+    rel_root=$(_gol_get_relative_path "$TEMP_DIR" "$root")
+    rel_path=$(_gol_get_relative_path "$root" "$file_path")
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local CGO_ENABLED=0 GOWORK=""
+        run_stubbed_hook "$file_path" goimports=default
+    )
+
+    echo "root: $rel_root"
+    echo "rel: $rel_path"
+    echo "hook:"
+    echo "${output//"$TEMP_DIR"/\$CORPUS}"
+}
+
+test_golden_corpus() {
+    print_test_header "Golden corpus (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+
+    local case_spec name rel_file
+    for case_spec in "${GOLDEN_CASES[@]}"; do
+        name="${case_spec%%|*}"
+        rel_file="${case_spec#*|}"
+
+        # Fresh copy per case since the hook rewrites files in place
+        rm -rf "${TEMP_DIR:?}"/*
+        cp -R "$CORPUS_DIR"/. "$TEMP_DIR/"
+        setup_stub_path goimports
+        link_host_tools go
+
+        assert_golden "$name" "$(render_golden_case "$rel_file")"
+    done
+}
+
+test_generate_helpers() {
+    print_test_header "go generate input detection"
+
+    setup_temp_generate
+
+    assert_equals "Color" "$(_gol_declared_types "$TEMP_DIR/enum/color.go")" "Finds declared types"
+    assert_equals "" "$(_gol_declared_types "$TEMP_DIR/enum/mix.go")" "No types in a file with only funcs"
+
+    assert_equals "yes" "$(_gol_is_generated_file "$TEMP_DIR/enum/color_string.go" && echo yes || echo no)" \
+        "Detects generated file header"
+    assert_equals "no" "$(_gol_is_generated_file "$TEMP_DIR/enum/color.go" && echo yes || echo no)" \
+        "Hand-written file is not generated"
+
+    assert_equals "gen.go	3	stringer -type=Color -output color_string.go" \
+        "$(_gol_generate_directives "$TEMP_DIR/enum" "$TEMP_DIR/enum/color.go")" \
+        "stringer directive matches the file declaring its type"
+    assert_equals "gen.go	3	stringer -type=Color -output color_string.go" \
+        "$(_gol_generate_directives "$TEMP_DIR/enum" "$TEMP_DIR/enum/gen.go")" \
+        "Directive matches the file it lives in"
+    assert_equals "" "$(_gol_generate_directives "$TEMP_DIR/enum" "$TEMP_DIR/enum/mix.go")" \
+        "Unrelated file in the package does not match"
+    assert_equals "pb.go	4	cp ../api/service.proto service_pb.txt" \
+        "$(_gol_generate_directives "$TEMP_DIR/pb" "$TEMP_DIR/pb/service.proto")" \
+        "Directive mentioning a file by name matches it"
+
+    assert_equals "./pb" "$(_gol_generate_mapped_packages "$TEMP_DIR" "api/service.proto")" "Config maps proto inputs"
+    assert_equals "" "$(_gol_generate_mapped_packages "$TEMP_DIR" "api/README.md")" "Unmapped file has no packages"
+
+    assert_equals "false" "$(_gol_project_config "$TEMP_DIR" '.generate.run' 'false')" "Missing key uses default"
+    echo '{"generate": {"run": false, "timeout": 5}}' > "$TEMP_DIR/.go-lint.json"
+    assert_equals "false" "$(_gol_project_config "$TEMP_DIR" '.generate.run' 'true')" "False is not treated as missing"
+    assert_equals "5" "$(_gol_project_config "$TEMP_DIR" '.generate.timeout' '15')" "Reads numeric settings"
+}
+
+test_stubbed_hook_generate_reminder() {
+    print_test_header "Hook flags stale generated code (stubbed)"
+
+    setup_temp_generate
+    setup_stub_path go goimports
+
+    local output expected
+    output=$(run_stubbed_hook "$TEMP_DIR/enum/color.go" go=vet-clean goimports=default)
+    expected=$(expected_hook_json "allow" \
+        "enum/color.go is a go generate input; generated code may be stale" \
+        "Generators consuming enum/color.go:
+- enum/gen.go:3: //go:generate stringer -type=Color -output color_string.go
+
+Regenerate with:
+(cd enum && go generate gen.go)
+
+Set \"generate\": {\"run\": true} in .go-lint.json to run go generate automatically after edits.")
+    assert_json_equals "$expected" "$output" "Type named by stringer directive is reported"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/api/service.proto")
+    expected=$(expected_hook_json "allow" \
+        "api/service.proto is a go generate input; generated code may be stale" \
+        "Generators consuming api/service.proto:
+- .go-lint.json generate.inputs: ./pb
+
+Regenerate with:
+go generate ./pb
+
+Set \"generate\": {\"run\": true} in .go-lint.json to run go generate automatically after edits.")
+    assert_json_equals "$expected" "$output" "Mapped non-Go input is reported"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/enum/mix.go" go=vet-clean goimports=default)
+    assert_empty "$output" "Unrelated Go file stays silent"
+
+    output=$(run_stubbed_hook "$TEMP_DIR/enum/color_string.go" go=vet-clean goimports=default)
+    assert_empty "$output" "Generated file is not treated as an input"
+
+    assert_empty "$(grep "^go generate" "$STUB_LOG" || true)" "go generate is not run by default"
+}
+
+test_hook_generate_run() {
+    print_test_header "Hook runs go generate when enabled (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    setup_temp_generate
+    setup_stub_path goimports stringer
+    link_host_tools go
+
+    local output expected
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_GENERATE=1
+        run_stubbed_hook "$TEMP_DIR/enum/color.go" goimports=default
+    )
+    expected=$(expected_hook_json "allow" \
+        "go generate updated 1 file(s) after editing enum/color.go" \
+        "Ran:
+(cd enum && go generate gen.go)
+
+Changed files:
+- enum/color_string.go")
+    assert_json_equals "$expected" "$output" "Reports regenerated stringer output"
+    assert_equals "$(cat "$STUBS_DIR/recordings/stringer/default/rewrite")" \
+        "$(cat "$TEMP_DIR/enum/color_string.go")" "Generated file was rewritten"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_GENERATE=1
+        run_stubbed_hook "$TEMP_DIR/enum/color.go" goimports=default
+    )
+    assert_empty "$output" "Up-to-date generated code stays silent"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_GENERATE=1
+        run_stubbed_hook "$TEMP_DIR/api/service.proto"
+    )
+    expected=$(expected_hook_json "allow" \
+        "go generate updated 1 file(s) after editing api/service.proto" \
+        "Ran:
+go generate ./pb
+
+Changed files:
+- pb/service_pb.txt")
+    assert_json_equals "$expected" "$output" "Runs go generate in mapped package"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_GENERATE=1
+        run_stubbed_hook "$TEMP_DIR/enum/gen.go" goimports=default stringer=fail
+    )
+    expected=$(expected_hook_json "allow" \
+        "go generate failed for enum/gen.go" \
+        "go generate gen.go failed (exit 1):
+stringer: checking package: color.go:4:6: Color redeclared in this block
+gen.go:3: running \"stringer\": exit status 1
+
+Changed files:
+none")
+    assert_json_equals "$expected" "$output" "Reports generator failures"
+}
+
+test_analyzers() {
+    print_test_header "go-lint analyzers (real go toolchain)"
+
+    if ! command -v go &>/dev/null; then
+        echo -e "${YELLOW}⊘${NC} Skipped (go not installed)"
+        return 0
+    fi
+
+    if [[ -n "$TEMP_DIR" && -d "$TEMP_DIR" ]]; then
+        rm -rf "$TEMP_DIR"
+    fi
+    TEMP_DIR=$(mktemp -d)
+    TEMP_DIR=$(cd "$TEMP_DIR" && pwd -P)
+    cp -R "$ANALYZERS_DIR"/. "$TEMP_DIR/"
+
+    local binary
+    binary=$(_gol_analyzer_binary)
+    assert_file_exists "$binary" "Builds the analyzer binary into the cache"
+    assert_equals "$binary" "$(_gol_analyzer_binary)" "Reuses the cached binary"
+
+    assert_golden "analyzers-structtag" "$(_gol_run_analyzers "$TEMP_DIR" structtag/model.go)"
+    assert_golden "analyzers-embed" "$(_gol_run_analyzers "$TEMP_DIR" embed/embed.go)"
+
+    cat > "$TEMP_DIR/.go-lint.json" <<'JSON'
+{
+  "analyzers": {
+    "disable": ["embed"],
+    "structtag": {
+      "tags": {
+        "db": {"pattern": "^[a-zA-Z_]+$"},
+        "validate": {"rules": ["emial"]}
+      }
+    }
+  }
+}
+JSON
+    local output
+    output=$(_gol_run_analyzers "$TEMP_DIR" structtag/model.go)
+    assert_equals "" "$(echo "$output" | grep -e 'userName' -e 'emial' || true)" "Config overrides tag grammars"
+    assert_contains "$output" 'db name "email" is also used by field Email' "Other structtag findings remain"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" embed/embed.go)" "Disabled checks do not run"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    assert_golden "analyzers-context" "$(_gol_run_analyzers "$TEMP_DIR" service/service.go)"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" ctxmain/main.go)" "context.Background is fine in package main"
+
+    assert_golden "analyzers-testcode" "$(_gol_run_analyzers "$TEMP_DIR" testcode/store_test.go)"
+    assert_equals "legacy/legacy_test.go:9:7: parallel subtest uses loop variable name, which every iteration shares before Go 1.22; copy it with name := name before t.Run (testloopvar)" \
+        "$(_gol_run_analyzers "$TEMP_DIR" legacy/legacy_test.go)" "Requires loop variable copies before Go 1.22"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" testcode/store.go)" "Test checks skip non-test files"
+
+    echo '{"analyzers": {"testparallel": {"require": "subtests"}}}' > "$TEMP_DIR/.go-lint.json"
+    output=$(_gol_run_analyzers "$TEMP_DIR" testcode/store_test.go)
+    assert_contains "$output" "store_test.go:40:6: TestGet does not call t.Parallel(), which this project requires (testparallel)" \
+        "Requires t.Parallel() when configured"
+    assert_empty "$(echo "$output" | grep -e 'TestEnv' -e 'TestConcurrent' || true)" \
+        "Skips parallel tests and tests that use t.Setenv"
+    echo '{"analyzers": {"disable": ["tests"]}}' > "$TEMP_DIR/.go-lint.json"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" testcode/store_test.go)" "Disabling the tests pack disables its checks"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    assert_golden "analyzers-slog" "$(_gol_run_analyzers "$TEMP_DIR" slogs/audit.go)"
+    echo '{"analyzers": {"slog": {"key_pattern": "^[a-zA-Z-]+$", "forbidden_keys": ["Email"]}}}' > "$TEMP_DIR/.go-lint.json"
+    output=$(_gol_run_analyzers "$TEMP_DIR" slogs/audit.go)
+    assert_contains "$output" 'slogs/audit.go:21:36: warning: slog.InfoContext: key "email" is on the project'"'"'s forbidden list' \
+        "Reports forbidden keys case-insensitively"
+    assert_empty "$(echo "$output" | grep -e 'userID' -e 'Request-ID' || true)" "Config overrides the key style"
+    echo '{"analyzers": {"disable": ["slog"]}}' > "$TEMP_DIR/.go-lint.json"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" slogs/audit.go)" "Disabling the slog pack disables its checks"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    assert_golden "analyzers-security" "$(_gol_run_analyzers "$TEMP_DIR" security/store.go; _gol_run_analyzers "$TEMP_DIR" security/handler.go)"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" security/store_test.go)" "Security checks skip test files"
+    assert_equals "3" "$(_gol_count_security_findings "$(_gol_run_analyzers "$TEMP_DIR" security/handler.go)")" \
+        "Counts security findings"
+    echo '{"analyzers": {"security": {"secret_patterns": ["\\bhdr-[A-Z]+-[a-z]+\\b"]}}}' > "$TEMP_DIR/.go-lint.json"
+    assert_contains "$(_gol_run_analyzers "$TEMP_DIR" security/store.go)" \
+        "store.go:19:16: security: string contains a secret matching" "Config adds secret patterns"
+    echo '{"analyzers": {"disable": ["security"]}}' > "$TEMP_DIR/.go-lint.json"
+    assert_empty "$(_gol_run_analyzers "$TEMP_DIR" security/store.go)" "Disabling the security pack disables its checks"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    echo '{"analyzers": {"ctxpropagate": {"calls": {"time.Sleep": "clock.Sleep(ctx, d)"}}}}' > "$TEMP_DIR/.go-lint.json"
+    assert_contains "$(_gol_run_analyzers "$TEMP_DIR" service/service.go)" \
+        "time.Sleep ignores ctx; use clock.Sleep(ctx, d)" "Config overrides ctx-less call replacements"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    setup_stub_path golangci-lint
+    link_host_tools go
+    run_stubbed_project "$TEMP_DIR/slogs" golangci-lint=v1-clean
+    assert_equals "0" "$PROJECT_EXIT" "Project report does not fail on slog warnings alone"
+    assert_contains "$PROJECT_OUTPUT" "- **Warnings:** 7" "Project report counts slog findings as warnings"
+    assert_contains "$PROJECT_OUTPUT" \
+        "- **slogs/audit.go:21:52** [go-lint/slogargs] slog.InfoContext: key \"action\" has no value (odd number of key-value arguments)" \
+        "Project report lists slog findings"
+    assert_empty "$(echo "$PROJECT_OUTPUT" | grep -e 'structtag/' -e 'testcode/' || true)" \
+        "Project report only checks the target directory"
+
+    run_stubbed_project "$TEMP_DIR/security" golangci-lint=v1-clean
+    assert_contains "$PROJECT_OUTPUT" "- **Security:** 13" "Project report counts security findings"
+    assert_contains "$PROJECT_OUTPUT" \
+        "- **security/store.go:59:19** [go-lint/tlsinsecure] **security:** MinVersion tls.VersionTLS10 allows" \
+        "Project report marks security findings"
+
+    setup_stub_path goimports
+    link_host_tools go
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        run_stubbed_hook "$TEMP_DIR/structtag/model.go" goimports=default
+    )
+    assert_json_decision "$output" "block"
+    assert_equals "go vet and go-lint analyzers found 13 issue(s) in file" \
+        "$(echo "$output" | jq -r '.reason')" "Hook merges vet and analyzer findings"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        run_stubbed_hook "$TEMP_DIR/service/service.go" goimports=default
+    )
+    assert_json_decision "$output" "allow"
+    assert_equals "go-lint analyzers found 9 issue(s) in file (9 warning(s))" \
+        "$(echo "$output" | jq -r '.reason')" "Hook reports heuristic warnings without blocking"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        run_stubbed_hook "$TEMP_DIR/testcode/store_test.go" goimports=default
+    )
+    assert_json_decision "$output" "block"
+    assert_contains "$(echo "$output" | jq -r '.hookSpecificOutput.additionalContext')" "(testhelper)" \
+        "Hook blocks on tests pack findings"
+
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        run_stubbed_hook "$TEMP_DIR/security/handler.go" goimports=default
+    )
+    assert_json_decision "$output" "block"
+    assert_equals "go-lint analyzers found 3 issue(s) in file (3 security)" \
+        "$(echo "$output" | jq -r '.reason')" "Hook marks security findings"
+    echo '{"analyzers": {"security": {"block": false}}}' > "$TEMP_DIR/.go-lint.json"
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK=""
+        run_stubbed_hook "$TEMP_DIR/security/handler.go" goimports=default
+    )
+    assert_json_decision "$output" "allow"
+    assert_contains "$output" "security: exec.Command runs input from r.FormValue" "Reports security findings without blocking"
+    rm "$TEMP_DIR/.go-lint.json"
+
+    # A cold cache must not hold up the hook: the binary is built in the
+    # background and used from the next edit on
+    local cold_cache
+    cold_cache=$(mktemp -d)
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_CACHE_DIR="$cold_cache"
+        run_stubbed_hook "$TEMP_DIR/structtag/model.go" goimports=default
+    )
+    assert_equals "go vet found 2 issue(s) in file" "$(echo "$output" | jq -r '.reason')" \
+        "Skips the analyzers while their binary is not built"
+    local waited=0
+    while [[ $waited -lt 120 ]] && ! compgen -G "$cold_cache/go-lint-analyze-*[0-9]" >/dev/null; do
+        sleep 1
+        waited=$((waited + 1))
+    done
+    while [[ $waited -lt 120 ]] && compgen -G "$cold_cache/*.lock" >/dev/null; do
+        sleep 1
+        waited=$((waited + 1))
+    done
+    output=$(
+        export GOFLAGS="" GOPROXY=off GOTOOLCHAIN=local GOWORK="" GO_LINT_CACHE_DIR="$cold_cache"
+        run_stubbed_hook "$TEMP_DIR/structtag/model.go" goimports=default
+    )
+    assert_equals "go vet and go-lint analyzers found 13 issue(s) in file" "$(echo "$output" | jq -r '.reason')" \
+        "Builds the binary in the background for the next edit"
+    rm -rf "$cold_cache"
+}
+
+# Render the reason and context of a Bash hook response for golden files
+# Args: command, output_file
+render_bash_hook_case() {
+    local output
+    output=$(run_bash_hook "$1" "$GOCMD_DIR/$2")
+
+    echo "decision: $(echo "$output" | jq -r '.decision')"
+    echo "reason: $(echo "$output" | jq -r '.reason')"
+    echo "context:"
+    echo "$output" | jq -r '.hookSpecificOutput.additionalContext'
+}
+
+test_bash_hook_go_subcommand() {
+    print_test_header "Recognise go build/test/vet commands"
+
+    assert_equals "test" "$(_gol_go_subcommand "go test ./...")" "Plain go test"
+    assert_equals "build" "$(_gol_go_subcommand "cd svc && go build -o bin/app .")" "go build after cd"
+    assert_equals "vet" "$(_gol_go_subcommand "GOFLAGS=-mod=mod go vet ./... 2>&1 | head")" "go vet with env and pipe"
+    assert_equals "test" "$(_gol_go_subcommand "(go test -run TestX ./calc)")" "go test in a subshell"
+    assert_equals "" "$(_gol_go_subcommand "go mod tidy")" "go mod is ignored"
+    assert_equals "" "$(_gol_go_subcommand "echo go testing")" "Other words are ignored"
+    assert_equals "" "$(_gol_go_subcommand "cargo test")" "Other test runners are ignored"
+}
+
+test_bash_hook_skips_irrelevant_input() {
+    print_test_header "Bash hook ignores irrelevant commands"
+
+    setup_temp
+    setup_stub_path
+
+    local output
+    output=$(echo -n "" | run_stubbed -- "$PLUGIN_ROOT/hooks/go-lint-bash.sh" 2>&1)
+    assert_empty "$output" "Empty stdin exits silently"
+
+    output=$(run_bash_hook "ls -la" "$GOCMD_DIR/test.txt")
+    assert_empty "$output" "Non-go command exits silently"
+
+    output=$(run_bash_hook "go mod tidy" "$GOCMD_DIR/test.txt")
+    assert_empty "$output" "Other go subcommands exit silently"
+
+    output=$(run_bash_hook "go test ./..." "$GOCMD_DIR/test-pass.txt")
+    assert_empty "$output" "Passing go test exits silently"
+
+    output=$(run_bash_hook "go build ./..." "")
+    assert_empty "$output" "Successful go build exits silently"
+}
+
+test_bash_hook_string_response() {
+    print_test_header "Bash hook accepts a plain string tool response"
+
+    setup_temp
+    setup_stub_path
+
+    local output
+    output=$(jq -n --rawfile out "$GOCMD_DIR/build.txt" '{
+        tool_input: {command: "go build ./..."},
+        tool_response: $out
+    }' | run_stubbed -- "$PLUGIN_ROOT/hooks/go-lint-bash.sh" 2>&1)
+
+    assert_equals "go build: 2 build/vet error(s)" "$(echo "$output" | jq -r '.reason')" "Headline counts build errors"
+    assert_contains "$output" "broken/b.go:3:23: undefined: undefinedThing" "Compiler error keeps its location"
+}
+
+test_golden_bash_hook() {
+    print_test_header "Bash hook summaries (golden)"
+
+    setup_temp
+    setup_stub_path
+
+    assert_golden "gocmd-test" "$(render_bash_hook_case "go test ./..." test.txt)"
+    assert_golden "gocmd-test-verbose" "$(render_bash_hook_case "go test -v ./calc ./ok" test-verbose.txt)"
+    assert_golden "gocmd-test-json" "$(render_bash_hook_case "go test -json ./calc ./panicky" test-json.txt)"
+    assert_golden "gocmd-test-many" "$(render_bash_hook_case "go test ./cases" test-many.txt)"
+    assert_golden "gocmd-build" "$(render_bash_hook_case "go build ./..." build.txt)"
+    assert_golden "gocmd-vet" "$(render_bash_hook_case "go vet ./..." vet.txt)"
+}
+
 # Run all tests
 echo "======================================"
 echo "  Go-Lint Plugin Test Suite"
@@ -319,6 +2637,60 @@ test_hook_formats_file
 test_project_script_missing_tools
 test_project_script_on_fixtures
 
+# Hermetic tests
+test_stubbed_hook_skips_irrelevant_input
+test_stubbed_hook_missing_tools
+test_stubbed_hook_without_project_root
+test_stubbed_hook_goimports_failure
+test_stubbed_hook_formats_file
+test_stubbed_hook_vet_clean
+test_stubbed_hook_vet_errors
+test_stubbed_hook_vet_subpackage
+test_stubbed_hook_vet_unrelated_failures
+test_stubbed_hook_vet_timeout
+test_hook_deadline
+test_impact_timeout
+test_stubbed_hook_output_budget
+test_budget_diagnostics
+test_stubbed_hook_package_files
+test_stubbed_hook_go_mod
+test_stubbed_hook_go_work
+test_stubbed_hook_go_sum
+test_stubbed_hook_golangci_config
+test_stubbed_hook_golangci_v2_config_offline
+test_stubbed_hook_golangci_json_config
+test_detect_golangci_config_toml
+test_generate_helpers
+test_stubbed_hook_generate_reminder
+test_hook_generate_run
+test_analyzers
+test_stubbed_project_preconditions
+test_stubbed_project_clean
+test_stubbed_project_issues
+test_stubbed_project_v2
+test_stubbed_project_subdirectory
+test_stubbed_project_failures
+test_stubbed_perf
+test_binsize
+test_modernize
+test_stubbed_rename
+test_outline
+test_test_impact
+test_mutate
+test_flaky
+test_profile
+test_mcp_server
+
+# Bash hook tests
+test_bash_hook_go_subcommand
+test_bash_hook_skips_irrelevant_input
+test_bash_hook_string_response
+test_flaky_annotation
+
+# Golden tests
+test_golden_corpus
+test_golden_bash_hook
+
 # Print summary
 echo ""
 echo "======================================"
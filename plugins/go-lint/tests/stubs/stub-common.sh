@@ -0,0 +1,142 @@
+#!/usr/bin/env bash
+# Replay logic shared by the stand-in toolchain binaries in stubs/bin
+# This file should be sourced, not executed directly
+#
+# Each stub replays a recording from recordings/<tool>/<scenario>/, where the
+# scenario comes from STUB_<TOOL>_SCENARIO (e.g. STUB_GOLANGCI_LINT_SCENARIO).
+# Files in a recording are keyed by the leading arguments with dashes stripped,
+# joined by "-" and tried longest first ("mod-edit-fmt", "mod-edit", "mod"), up
+# to three arguments or the first one containing a "/". Unkeyed names are the
+# final fallback:
+#   <key>.stdout | stdout  - written to stdout
+#   <key>.stderr | stderr  - written to stderr
+#   <key>.exit   | exit    - exit code (default 0)
+#   <key>.sleep  | sleep   - seconds to sleep before replying (timeouts)
+#   rewrite                - copied over the last argument (goimports -w)
+#   <key>.files/           - copied over the working directory (tools that
+#                            write several files, gopls rename -w)
+# "{{PWD}}" in stdout and stderr is replaced with the working directory, for
+# tools that print absolute paths.
+# Every invocation is appended to $STUB_LOG when it is set.
+
+# Source guard
+if [[ "${BASH_SOURCE[0]}" == "${0}" ]]; then
+    echo "Error: This script should be sourced, not executed directly" >&2
+    exit 1
+fi
+
+STUB_DIR="${STUB_DIR:-$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)}"
+STUB_RECORDINGS_DIR="${STUB_RECORDINGS_DIR:-$STUB_DIR/recordings}"
+
+# Find a recording file for the current invocation
+# Args: recording_dir, name, keys (longest first)
+# Returns: file path or empty string
+_stub_lookup() {
+    local dir="${1}"
+    local name="${2}"
+    shift 2
+
+    local key
+    for key in "$@"; do
+        if [[ -f "$dir/$key.$name" ]]; then
+            echo "$dir/$key.$name"
+            return 0
+        fi
+    done
+
+    if [[ -f "$dir/$name" ]]; then
+        echo "$dir/$name"
+    fi
+}
+
+# Build lookup keys from invocation arguments, longest first
+# Args: original arguments...
+# Returns: one key per line
+_stub_keys() {
+    local keys=()
+    local key=""
+    local arg
+
+    for arg in "$@"; do
+        if [[ "$arg" == */* || ${#keys[@]} -ge 3 ]]; then
+            break
+        fi
+        arg="${arg#-}"
+        arg="${arg#-}"
+        key="${key:+$key-}$arg"
+        keys=("$key" "${keys[@]}")
+    done
+
+    if [[ ${#keys[@]} -gt 0 ]]; then
+        printf '%s\n' "${keys[@]}"
+    fi
+}
+
+# Print a recorded output file with {{PWD}} expanded
+# Args: file
+_stub_print() {
+    if grep -q '{{PWD}}' "${1}"; then
+        PWD_PATH="$PWD" awk '{ gsub(/\{\{PWD\}\}/, ENVIRON["PWD_PATH"]); print }' "${1}"
+    else
+        cat "${1}"
+    fi
+}
+
+# Replay a recording and exit with its exit code
+# Args: tool, scenario, original arguments...
+_stub_replay() {
+    local tool="${1}"
+    local scenario="${2}"
+    shift 2
+
+    if [[ -n "${STUB_LOG:-}" ]]; then
+        echo "$tool $*" >> "$STUB_LOG"
+    fi
+
+    local dir="$STUB_RECORDINGS_DIR/$tool/$scenario"
+    if [[ ! -d "$dir" ]]; then
+        echo "stub $tool: unknown scenario '$scenario'" >&2
+        exit 99
+    fi
+
+    local keys=()
+    local key
+    while IFS= read -r key; do
+        keys+=("$key")
+    done < <(_stub_keys "$@")
+
+    local file
+    file=$(_stub_lookup "$dir" sleep ${keys[@]+"${keys[@]}"})
+    if [[ -n "$file" ]]; then
+        sleep "$(cat "$file")"
+    fi
+
+    if [[ -f "$dir/rewrite" && $# -gt 0 ]]; then
+        cp "$dir/rewrite" "${!#}"
+    fi
+
+    for key in ${keys[@]+"${keys[@]}"}; do
+        if [[ -d "$dir/$key.files" ]]; then
+            cp -R "$dir/$key.files"/. .
+            break
+        fi
+    done
+
+    file=$(_stub_lookup "$dir" stdout ${keys[@]+"${keys[@]}"})
+    if [[ -n "$file" ]]; then
+        _stub_print "$file"
+    fi
+
+    file=$(_stub_lookup "$dir" stderr ${keys[@]+"${keys[@]}"})
+    if [[ -n "$file" ]]; then
+        _stub_print "$file" >&2
+    fi
+
+    local exit_code=0
+    file=$(_stub_lookup "$dir" exit ${keys[@]+"${keys[@]}"})
+    if [[ -n "$file" ]]; then
+        exit_code=$(cat "$file")
+    fi
+
+    exit "$exit_code"
+}